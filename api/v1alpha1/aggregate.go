@@ -0,0 +1,27 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// Aggregate advertises a summary of the routes of a VRF contained in
+// prefix, while at least one of them is present.
+type Aggregate struct {
+	// prefix is the CIDR of the aggregate route. It must be contained in
+	// one of the subnets configured in the VRF.
+	// +kubebuilder:validation:XValidation:rule="isCIDR(self)",message="prefix must be a valid CIDR"
+	// +kubebuilder:validation:MaxLength:=43
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Prefix string `json:"prefix,omitempty"`
+
+	// summaryOnly suppresses the advertisement of the routes contained in
+	// the aggregate, leaving the aggregate route only.
+	// Defaults to false.
+	// +optional
+	SummaryOnly *bool `json:"summaryOnly,omitempty"`
+
+	// asSet includes the AS numbers of the paths of the aggregated routes
+	// in the AS path of the aggregate route, as an AS set.
+	// Defaults to false.
+	// +optional
+	ASSet *bool `json:"asSet,omitempty"`
+}
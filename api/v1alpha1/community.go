@@ -0,0 +1,93 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// VRFCommunities describes the BGP communities attached to the routes a VRF
+// exports to the fabric, and the ones required to import routes from it.
+// +kubebuilder:validation:XValidation:rule="has(self.export) || has(self.exportPrefixes) || has(self.importMatch)",message="at least one of export, exportPrefixes or importMatch must be set"
+type VRFCommunities struct {
+	// export are the communities added to all the routes exported
+	// to the fabric from the VRF.
+	// +optional
+	Export *Communities `json:"export,omitempty"`
+
+	// exportPrefixes adds communities to the exported routes contained in
+	// the given prefixes, on top of the ones listed in export. When a route
+	// is contained in more than one prefix, only the first one is applied.
+	// +kubebuilder:validation:MaxItems:=100
+	// +listType=atomic
+	// +optional
+	ExportPrefixes []PrefixCommunities `json:"exportPrefixes,omitempty"`
+
+	// importMatch restricts the routes imported from the fabric to the ones
+	// carrying at least one of the given communities. When not set, all the
+	// routes matching the import route targets are imported.
+	// +optional
+	ImportMatch *Communities `json:"importMatch,omitempty"`
+}
+
+// Communities is a set of BGP communities.
+// +kubebuilder:validation:XValidation:rule="has(self.standard) || has(self.large) || has(self.extended)",message="at least one community must be set"
+type Communities struct {
+	// standard are RFC1997 communities in the AA:NN format, or one of the
+	// well known no-export, no-advertise, local-AS and no-peer.
+	// +kubebuilder:validation:MaxItems:=32
+	// +kubebuilder:validation:items:MaxLength:=12
+	// +listType=set
+	// +optional
+	Standard []string `json:"standard,omitempty"`
+
+	// large are RFC8092 large communities in the GA:LD1:LD2 format.
+	// +kubebuilder:validation:MaxItems:=32
+	// +kubebuilder:validation:items:MaxLength:=32
+	// +listType=set
+	// +optional
+	Large []string `json:"large,omitempty"`
+
+	// extended are RFC4360 extended communities.
+	// +kubebuilder:validation:MaxItems:=32
+	// +listType=atomic
+	// +optional
+	Extended []ExtendedCommunity `json:"extended,omitempty"`
+}
+
+// ExtendedCommunityType is the type of an ExtendedCommunity.
+// +kubebuilder:validation:Enum=RouteTarget;SiteOfOrigin
+type ExtendedCommunityType string
+
+const (
+	// ExtendedCommunityRouteTarget is a route target extended community.
+	ExtendedCommunityRouteTarget ExtendedCommunityType = "RouteTarget"
+
+	// ExtendedCommunitySiteOfOrigin is a site of origin extended community.
+	ExtendedCommunitySiteOfOrigin ExtendedCommunityType = "SiteOfOrigin"
+)
+
+// ExtendedCommunity is an RFC4360 extended community.
+type ExtendedCommunity struct {
+	// type is the type of the extended community.
+	// +required
+	Type ExtendedCommunityType `json:"type,omitempty"`
+
+	// value of the extended community, in the ASN:NN or IPv4Address:NN
+	// format.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=21
+	// +required
+	Value string `json:"value,omitempty"`
+}
+
+// PrefixCommunities are the communities added to the routes contained in prefix.
+type PrefixCommunities struct {
+	// prefix is the CIDR the exported routes are matched against. All the
+	// routes contained in it are matched, regardless of their length.
+	// +kubebuilder:validation:XValidation:rule="isCIDR(self)",message="prefix must be a valid CIDR"
+	// +kubebuilder:validation:MaxLength:=43
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Prefix string `json:"prefix,omitempty"`
+
+	// communities are added to the matching routes.
+	// +required
+	Communities Communities `json:"communities,omitzero"`
+}
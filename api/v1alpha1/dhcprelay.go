@@ -0,0 +1,80 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// DHCPRelayConfig contains the DHCP relay settings of the gateway interface
+// of an L2VNI.
+type DHCPRelayConfig struct {
+	// servers are the IPv4 addresses of the DHCP servers the requests of the
+	// workloads are relayed to. They are reached through the routing domain
+	// of the L2VNI, and they reply to the address of the node taken from
+	// sourceCIDR, which the requests are relayed from.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:XValidation:rule="isIP(self) && ip(self).family() == 4",message="servers must be valid IPv4 addresses"
+	// +kubebuilder:validation:items:MaxLength=15
+	// +listType=set
+	// +required
+	Servers []string `json:"servers,omitempty"`
+
+	// sourceCIDR is the IPv4 CIDR each node takes an address from, based on
+	// its index, to relay the requests from. The address is assigned to the
+	// gateway interface and advertised in the routing domain, so that the
+	// replies of the servers come back to the node relaying the request,
+	// as the gatewayIPs are shared by all the nodes. The subnet of the
+	// gatewayIPs is sent to the servers with the link selection sub-option
+	// of option 82.
+	// +kubebuilder:validation:XValidation:rule="isCIDR(self) && cidr(self).ip().family() == 4",message="sourceCIDR must be a valid IPv4 CIDR"
+	// +kubebuilder:validation:MaxLength=18
+	// +required
+	SourceCIDR string `json:"sourceCIDR,omitempty"`
+
+	// option82 sets the circuit ID and remote ID sub-options of the relay
+	// agent information option (option 82) inserted in the relayed requests,
+	// and removed from the replies.
+	// When omitted, option 82 carries only the link selection and server
+	// identifier override sub-options.
+	// +optional
+	Option82 *DHCPRelayOption82 `json:"option82,omitempty"`
+}
+
+// DHCPRelayOption82 contains the relay agent information option (option 82)
+// inserted in the relayed requests.
+type DHCPRelayOption82 struct {
+	// circuitID is the value of the circuit ID sub-option.
+	// Defaults to the name of the L2VNI.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	CircuitID *string `json:"circuitID,omitempty"`
+
+	// remoteID is the value of the remote ID sub-option. The sub-option is
+	// not inserted when omitted.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	RemoteID *string `json:"remoteID,omitempty"`
+
+	// policy selects how the requests already carrying option 82, set by
+	// the workloads or by another relay, are handled. Keep relays them with
+	// their own option 82, Replace replaces it and Drop discards them.
+	// Defaults to Keep.
+	// +optional
+	Policy *DHCPRelayOption82Policy `json:"policy,omitempty"`
+}
+
+// DHCPRelayOption82Policy is the handling of the requests already carrying
+// option 82.
+// +kubebuilder:validation:Enum=Keep;Replace;Drop
+type DHCPRelayOption82Policy string
+
+const (
+	// DHCPRelayOption82Keep relays the requests with their own option 82.
+	DHCPRelayOption82Keep DHCPRelayOption82Policy = "Keep"
+
+	// DHCPRelayOption82Replace replaces the option 82 of the requests.
+	DHCPRelayOption82Replace DHCPRelayOption82Policy = "Replace"
+
+	// DHCPRelayOption82Drop discards the requests.
+	DHCPRelayOption82Drop DHCPRelayOption82Policy = "Drop"
+)
@@ -6,6 +6,11 @@ package v1alpha1
 // A BGP session is established over this leg.
 // +kubebuilder:validation:XValidation:rule="has(self.hostASN) || has(self.hostType)",message="either HostASN or HostType must be set"
 // +kubebuilder:validation:XValidation:rule="!has(self.hostASN) || !has(self.hostType)",message="HostASN and HostType cannot be set together"
+// +kubebuilder:validation:XValidation:rule="has(self.holdTimeSeconds) == has(self.keepaliveTimeSeconds)",message="holdTimeSeconds and keepaliveTimeSeconds must be both set or both unset"
+// +kubebuilder:validation:XValidation:rule="!has(self.holdTimeSeconds) || self.holdTimeSeconds == 0 || self.holdTimeSeconds >= 3",message="holdTimeSeconds must be 0 or >=3"
+// +kubebuilder:validation:XValidation:rule="!has(self.holdTimeSeconds) || !has(self.keepaliveTimeSeconds) || self.keepaliveTimeSeconds <= self.holdTimeSeconds",message="keepaliveTimeSeconds must be lower than or equal to holdTimeSeconds"
+// +kubebuilder:validation:XValidation:rule="!has(self.password) || !has(self.passwordSecret)",message="password and passwordSecret cannot be set together"
+// +kubebuilder:validation:XValidation:rule="!has(self.exportPolicy) || !has(self.defaultOriginate) || !has(self.defaultOriginate.onlyDefaultRoute) || !self.defaultOriginate.onlyDefaultRoute",message="exportPolicy cannot be set when defaultOriginate.onlyDefaultRoute is true"
 type HostSession struct {
 	// asn is the local AS number to use to establish a BGP session with
 	// the default namespace.
@@ -34,6 +39,164 @@ type HostSession struct {
 	// +required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="LocalCIDR can't be changed"
 	LocalCIDR LocalCIDRConfig `json:"localCIDR,omitzero"` //nolint:kubeapilinter // CEL rule on LocalCIDRConfig enforces at least one of ipv4/ipv6
+
+	// importPolicy filters the routes received from the host over this
+	// session. When not set, all the routes are accepted.
+	// +optional
+	ImportPolicy *PrefixPolicy `json:"importPolicy,omitempty"`
+
+	// exportPolicy filters the routes advertised to the host over this
+	// session. When not set, all the routes are advertised.
+	// +optional
+	ExportPolicy *PrefixPolicy `json:"exportPolicy,omitempty"`
+
+	// password to be used for establishing the BGP session with the host.
+	// Password and PasswordSecret are mutually exclusive.
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern=`^\S+$`
+	// +optional
+	Password *string `json:"password,omitempty"`
+
+	// passwordSecret is name of the authentication secret for the session.
+	// the secret must be of type "kubernetes.io/basic-auth", and created in the
+	// same namespace as the perouter daemon. The password is stored in the
+	// secret as the key "password".
+	// Password and PasswordSecret are mutually exclusive.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PasswordSecret *string `json:"passwordSecret,omitempty"`
+
+	// holdTimeSeconds is the requested BGP hold time in seconds, per RFC4271.
+	// Defaults to 180.
+	// +optional
+	HoldTimeSeconds *int64 `json:"holdTimeSeconds,omitempty"`
+
+	// keepaliveTimeSeconds is the requested BGP keepalive time in seconds, per RFC4271.
+	// Defaults to 60.
+	// +optional
+	KeepaliveTimeSeconds *int64 `json:"keepaliveTimeSeconds,omitempty"`
+
+	// connectTimeSeconds controls how long BGP waits between connection attempts to the host, in seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ConnectTimeSeconds *int64 `json:"connectTimeSeconds,omitempty"`
+
+	// bfd defines the BFD configuration for the BGP session with the host.
+	// +optional
+	BFD *BFDSettings `json:"bfd,omitempty"`
+
+	// gracefulRestart sets the graceful restart mode of the session.
+	// Enabled makes the router both restart gracefully and help the host
+	// do it, HelperOnly only preserves the routes of a restarting host and
+	// Disabled turns graceful restart off for the session.
+	// When not set, the router only acts as helper, as per the FRR default.
+	// +optional
+	GracefulRestart *GracefulRestartMode `json:"gracefulRestart,omitempty"`
+
+	// defaultOriginate advertises a default route to the host, in addition
+	// to the routes permitted by the export policy.
+	// +optional
+	DefaultOriginate *DefaultOriginate `json:"defaultOriginate,omitempty"`
+}
+
+// DefaultOriginate advertises a default route to the host.
+type DefaultOriginate struct {
+	// ipFamily is the address family of the default routes advertised.
+	// Defaults to DualStack.
+	// +optional
+	IPFamily *IPFamily `json:"ipFamily,omitempty"`
+
+	// conditionPrefixes makes the advertisement of the default route
+	// conditional: the default route of a family is advertised only while
+	// a route with one of the prefixes of that family is present in the
+	// BGP table. The default route is always advertised when omitted.
+	// +kubebuilder:validation:MaxItems:=16
+	// +kubebuilder:validation:items:MaxLength:=43
+	// +kubebuilder:validation:items:XValidation:rule="isCIDR(self)",message="conditionPrefixes must be valid CIDRs"
+	// +listType=set
+	// +optional
+	ConditionPrefixes []string `json:"conditionPrefixes,omitempty"`
+
+	// onlyDefaultRoute stops advertising the other routes to the host,
+	// which receives the default route only. Cannot be set together with
+	// the exportPolicy of the session.
+	// Defaults to false.
+	// +optional
+	OnlyDefaultRoute *bool `json:"onlyDefaultRoute,omitempty"`
+}
+
+// GracefulRestartMode is the graceful restart mode of a BGP session.
+// +kubebuilder:validation:Enum=Enabled;HelperOnly;Disabled
+type GracefulRestartMode string
+
+const (
+	// GracefulRestartModeEnabled enables graceful restart for the session,
+	// both as restarting router and as helper.
+	GracefulRestartModeEnabled GracefulRestartMode = "Enabled"
+
+	// GracefulRestartModeHelperOnly preserves the routes received on the
+	// session while the peer restarts, without restarting gracefully.
+	GracefulRestartModeHelperOnly GracefulRestartMode = "HelperOnly"
+
+	// GracefulRestartModeDisabled disables graceful restart for the session.
+	GracefulRestartModeDisabled GracefulRestartMode = "Disabled"
+)
+
+// PrefixPolicy is an ordered list of prefix rules. The first rule matching
+// a route decides whether the route is permitted or denied, routes not
+// matching any rule are denied.
+type PrefixPolicy struct {
+	// rules is the ordered list of prefix rules of the policy.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=atomic
+	// +required
+	Rules []PrefixRule `json:"rules,omitempty"`
+}
+
+// PrefixRuleAction is the action applied to the routes matching a PrefixRule.
+// +kubebuilder:validation:Enum=Permit;Deny
+type PrefixRuleAction string
+
+const (
+	// PrefixRuleActionPermit permits the routes matching the rule. This is
+	// the default when action is omitted.
+	PrefixRuleActionPermit PrefixRuleAction = "Permit"
+
+	// PrefixRuleActionDeny denies the routes matching the rule.
+	PrefixRuleActionDeny PrefixRuleAction = "Deny"
+)
+
+// PrefixRule matches the routes contained in prefix. When neither ge nor le
+// are set, only the routes with the exact prefix are matched.
+// +kubebuilder:validation:XValidation:rule="!has(self.ge) || !has(self.le) || self.ge <= self.le",message="ge must be lower than or equal to le"
+type PrefixRule struct {
+	// prefix is the CIDR the routes are matched against.
+	// +kubebuilder:validation:XValidation:rule="isCIDR(self)",message="prefix must be a valid CIDR"
+	// +kubebuilder:validation:MaxLength:=43
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Prefix string `json:"prefix,omitempty"`
+
+	// action is applied to the routes matching the rule.
+	// Defaults to Permit.
+	// +optional
+	Action *PrefixRuleAction `json:"action,omitempty"`
+
+	// ge matches the routes with a prefix length greater than or equal
+	// to the given value. Must be greater than the length of prefix.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	GE *int32 `json:"ge,omitempty"`
+
+	// le matches the routes with a prefix length lower than or equal
+	// to the given value. Must be greater than the length of prefix.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	LE *int32 `json:"le,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="has(self.ipv4) || has(self.ipv6)",message="at least one of ipv4 or ipv6 must be specified"
@@ -50,3 +213,15 @@ type LocalCIDRConfig struct {
 	// +optional
 	IPv6 *string `json:"ipv6,omitempty"`
 }
+
+// VRFHostSession is a host session of a VRF, identified by its name.
+type VRFHostSession struct {
+	// name identifies the host session within the VRF.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	// +required
+	Name string `json:"name,omitempty"`
+
+	HostSession `json:",inline"`
+}
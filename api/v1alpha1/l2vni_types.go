@@ -23,6 +23,7 @@ import (
 const (
 	LinuxBridge = "LinuxBridge"
 	OVSBridge   = "OVSBridge"
+	VLANTrunk   = "VLANTrunk"
 
 	// RoutingDomainTypeL3VNI selects an L3VNI as the routing domain provider.
 	RoutingDomainTypeL3VNI = "L3VNI"
@@ -33,6 +34,11 @@ const (
 
 // L2VNISpec defines the desired state of VNI.
 // +kubebuilder:validation:XValidation:rule="!has(self.gatewayIPs) || size(self.gatewayIPs) == 0 || has(self.routingDomain)",message="gatewayIPs cannot be set without routingDomain"
+// +kubebuilder:validation:XValidation:rule="!has(self.gatewayMAC) || (has(self.gatewayIPs) && size(self.gatewayIPs) > 0)",message="gatewayMAC cannot be set without gatewayIPs"
+// +kubebuilder:validation:XValidation:rule="!has(self.multicastGroup) || !has(self.underlayAddressFamily) || self.underlayAddressFamily == 'IPv4'",message="multicastGroup requires the IPv4 underlayAddressFamily"
+// +kubebuilder:validation:XValidation:rule="!has(self.routerAdvertisement) || (has(self.gatewayIPs) && self.gatewayIPs.exists(ip, ip.contains(':')))",message="routerAdvertisement requires an IPv6 address in gatewayIPs"
+// +kubebuilder:validation:XValidation:rule="!has(self.dhcpRelay) || (has(self.gatewayIPs) && self.gatewayIPs.exists(ip, !ip.contains(':')))",message="dhcpRelay requires an IPv4 address in gatewayIPs"
+// +kubebuilder:validation:XValidation:rule="!has(self.evpnAdvertisement) || (has(self.gatewayIPs) && size(self.gatewayIPs) > 0)",message="evpnAdvertisement requires gatewayIPs"
 type L2VNISpec struct {
 	// nodeSelector specifies which nodes this L2VNI applies to.
 	// If empty or not specified, applies to all nodes.
@@ -58,6 +64,21 @@ type L2VNISpec struct {
 	// +optional
 	VXLanPort *int32 `json:"vxlanPort,omitempty"`
 
+	// vxlanTunnel contains the properties of the outer headers of the
+	// packets encapsulated by the VXLan device of the VNI, each of them
+	// overriding the one of the vxlanTunnel of the underlay evpn settings.
+	// In VLANAware bridge mode, all the L2VNIs must set the same value.
+	// +optional
+	VXLanTunnel *VXLanTunnelConfig `json:"vxlanTunnel,omitempty"`
+
+	// underlay is the name of the Underlay the VNI rides on, for nodes
+	// with more than one underlay. When omitted, the VNI rides on the
+	// primary underlay of the node, the first one in name order.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Underlay *string `json:"underlay,omitempty"`
+
 	// underlayAddressFamily selects which VTEP address family to use for this VNI's
 	// VXLAN interface. When omitted, defaults to the available family in the underlay
 	// (IPv4 preferred in dual-stack).
@@ -65,6 +86,16 @@ type L2VNISpec struct {
 	// +optional
 	UnderlayAddressFamily *string `json:"underlayAddressFamily,omitempty"`
 
+	// vlan is the VLAN the L2VNI is mapped to on the VLAN-aware bridge of
+	// the routers whose underlay sets evpn.bridgeMode to VLANAware. It must
+	// be unique among the L2VNIs of a router. It is ignored in PerVNI mode.
+	// When omitted, the VNI is used as VLAN, which requires it to be a valid
+	// VLAN ID.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	// +optional
+	VLAN *int32 `json:"vlan,omitempty"`
+
 	// hostMaster is the interface on the host the veth should be attached to.
 	// If not set, the host veth will not be attached to any interface and it must be
 	// attached manually (or by some other means). This is useful if another controller
@@ -83,6 +114,64 @@ type L2VNISpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="GatewayIPs cannot be changed"
 	// +listType=atomic
 	GatewayIPs []string `json:"gatewayIPs,omitempty"`
+
+	// gatewayMAC is the MAC address of the distributed anycast gateway,
+	// set on the bridge holding the gatewayIPs on every node. Set it to
+	// the gateway MAC used by the other routers of the same subnets, so
+	// that it does not change when a workload moves between them.
+	// It must be a unicast MAC address.
+	// When omitted, the gatewayMAC of the underlay evpn settings is used,
+	// or a MAC address derived from the VNI.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	// +optional
+	GatewayMAC *string `json:"gatewayMAC,omitempty"`
+
+	// arpNDSuppression enables ARP and ND suppression on the VXLan port of
+	// the L2VNI bridge: the ARP requests and neighbor solicitations for the
+	// hosts known via EVPN are answered locally instead of being flooded
+	// to the fabric. Disable it for workloads relying on the requests
+	// reaching the other hosts, such as VRRP appliances.
+	// Defaults to true.
+	// +optional
+	ARPNDSuppression *bool `json:"arpNDSuppression,omitempty"`
+
+	// proxyARP enables proxy ARP on the VXLan port of the L2VNI bridge:
+	// the ARP requests received from the fabric for the hosts known to the
+	// bridge are answered locally.
+	// Defaults to false.
+	// +optional
+	ProxyARP *bool `json:"proxyARP,omitempty"`
+
+	// routerAdvertisement sends IPv6 router advertisements on the gateway
+	// interface of the L2VNI, so that the workloads can configure their
+	// addresses through SLAAC. It requires an IPv6 address in gatewayIPs.
+	// +optional
+	RouterAdvertisement *RouterAdvertisementConfig `json:"routerAdvertisement,omitempty"`
+
+	// dhcpRelay relays the DHCP requests received on the gateway interface
+	// of the L2VNI to the given servers, so that the workloads can get their
+	// addresses from a DHCP server outside of the L2 segment. It requires an
+	// IPv4 address in gatewayIPs. The relay failures are reported in the
+	// failedResources of the RouterNodeConfigurationStatus.
+	// +optional
+	DHCPRelay *DHCPRelayConfig `json:"dhcpRelay,omitempty"`
+
+	// evpnAdvertisement selects how the gateway interface of the L2VNI is
+	// advertised to the other VTEPs as EVPN MAC/IP routes. It requires
+	// gatewayIPs.
+	// +optional
+	EVPNAdvertisement *EVPNAdvertisementConfig `json:"evpnAdvertisement,omitempty"`
+
+	// multicastGroup is the IPv4 multicast group the BUM (broadcast,
+	// unknown unicast and multicast) traffic of the L2VNI is sent to on the
+	// underlay, instead of being replicated to each remote VTEP. It
+	// requires the underlay to set evpn.multicast, and either all or none
+	// of the L2VNIs of a router must set it. It is not supported in
+	// VLANAware bridge mode.
+	// +kubebuilder:validation:XValidation:rule="isIP(self) && ip(self).family() == 4",message="multicastGroup must be a valid IPv4 address"
+	// +kubebuilder:validation:MaxLength=15
+	// +optional
+	MulticastGroup *string `json:"multicastGroup,omitempty"`
 }
 
 // RoutingDomain is a discriminated union over the resource kinds that can
@@ -91,6 +180,7 @@ type L2VNISpec struct {
 // +union
 // +kubebuilder:validation:XValidation:rule="self.type != 'L3VNI' || (has(self.l3vni) && !has(self.l3vpn))",message="type L3VNI requires l3vni to be set and l3vpn to be unset"
 // +kubebuilder:validation:XValidation:rule="self.type != 'L3VPN' || (has(self.l3vpn) && !has(self.l3vni))",message="type L3VPN requires l3vpn to be set and l3vni to be unset"
+// +kubebuilder:validation:XValidation:rule="!has(self.irbMode) || self.irbMode == 'Symmetric' || self.type == 'L3VNI'",message="irbMode Asymmetric requires type L3VNI"
 type RoutingDomain struct {
 	// type selects the kind of resource that provides this routing domain.
 	// +kubebuilder:validation:Enum=L3VNI;L3VPN
@@ -107,6 +197,54 @@ type RoutingDomain struct {
 	// provides the routing domain for this L2VNI.
 	// +optional
 	L3VPN *L3VPNReference `json:"l3vpn,omitempty"`
+
+	// irbMode selects how the traffic between the L2VNIs of the routing
+	// domain is routed. In Symmetric mode, it is routed by both the ingress
+	// and the egress VTEPs through the L3VNI. In Asymmetric mode, it is
+	// routed by the ingress VTEP straight into the destination L2VNI and
+	// only bridged by the egress VTEP, as expected by the VTEPs not
+	// supporting symmetric IRB, while the L3VNI only carries the prefix
+	// routes. As the ingress VTEP must hold the destination L2VNI, an
+	// asymmetric L2VNI must be present on all the nodes of its L3VNI, and
+	// all the L2VNIs of an L3VNI must use the same mode.
+	// It requires type L3VNI. Defaults to Symmetric.
+	// +optional
+	IRBMode *IRBMode `json:"irbMode,omitempty"`
+}
+
+// IRBMode is the integrated routing and bridging model of a routing domain.
+// +kubebuilder:validation:Enum=Symmetric;Asymmetric
+type IRBMode string
+
+const (
+	// IRBModeSymmetric routes the traffic on both the ingress and the
+	// egress VTEPs, through the L3VNI.
+	IRBModeSymmetric IRBMode = "Symmetric"
+
+	// IRBModeAsymmetric routes the traffic on the ingress VTEP only, into
+	// the destination L2VNI.
+	IRBModeAsymmetric IRBMode = "Asymmetric"
+)
+
+// EVPNAdvertisementConfig holds the EVPN advertisement flags of the gateway
+// interface of an L2VNI. defaultGateway and sviIP are mutually exclusive.
+// +kubebuilder:validation:XValidation:rule="!(has(self.defaultGateway) && self.defaultGateway && has(self.sviIP) && self.sviIP)",message="defaultGateway and sviIP are mutually exclusive"
+type EVPNAdvertisementConfig struct {
+	// defaultGateway advertises the MAC and IP addresses of the gateway
+	// interface with the default gateway extended community, so that the
+	// VTEPs relying on a centralized gateway, or proxying ARP for it,
+	// learn it via EVPN. This maps to FRR's advertise-default-gw.
+	// Defaults to false.
+	// +optional
+	DefaultGateway *bool `json:"defaultGateway,omitempty"`
+
+	// sviIP advertises the MAC and IP addresses of the gateway interface
+	// as a regular host, so that the gateway is reachable from the other
+	// VTEPs, for instance to reach the node itself on the L2 segment.
+	// This maps to FRR's advertise-svi-ip.
+	// Defaults to false.
+	// +optional
+	SVIIP *bool `json:"sviIP,omitempty"`
 }
 
 // L3VNIReference references an L3VNI by name.
@@ -174,10 +312,32 @@ type OVSBridgeConfig struct {
 	Name *string `json:"name,omitempty"`
 }
 
-// +kubebuilder:validation:XValidation:rule="(self.type == 'LinuxBridge' && has(self.linuxBridge) && !has(self.ovsBridge)) || (self.type == 'OVSBridge' && has(self.ovsBridge) && !has(self.linuxBridge))",message="type/config mismatch: 'LinuxBridge' requires linuxBridge field, 'OVSBridge' requires ovsBridge field"
+// VLANTrunkConfig contains configuration for the VLAN trunk type.
+// The L2VNIs of a node of this type share a single veth pair, named
+// host-trunk on the host, carrying each of them tagged with its VLAN ID.
+type VLANTrunkConfig struct {
+	// vlanID is the 802.1Q VLAN ID the L2VNI is tagged with on the trunk.
+	// It must be unique among the L2VNIs of a node sharing the trunk.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	// +required
+	VLANID int32 `json:"vlanID,omitempty"`
+
+	// bridge is the name of an existing VLAN filtering Linux bridge on the
+	// host. When set, the trunk is attached to it as a tagged member of
+	// vlanID. When omitted, a VLAN sub-interface of the trunk named
+	// host-trunk.<vlanID> is created instead. It must be the same for all
+	// the L2VNIs of a node sharing the trunk.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	// +kubebuilder:validation:MaxLength=15
+	// +optional
+	Bridge *string `json:"bridge,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(self.type == 'LinuxBridge' && has(self.linuxBridge) && !has(self.ovsBridge) && !has(self.vlanTrunk)) || (self.type == 'OVSBridge' && has(self.ovsBridge) && !has(self.linuxBridge) && !has(self.vlanTrunk)) || (self.type == 'VLANTrunk' && has(self.vlanTrunk) && !has(self.linuxBridge) && !has(self.ovsBridge))",message="type/config mismatch: 'LinuxBridge' requires linuxBridge field, 'OVSBridge' requires ovsBridge field, 'VLANTrunk' requires vlanTrunk field"
 type HostMaster struct {
-	// type of the host interface. Supported values: "LinuxBridge", "OVSBridge".
-	// +kubebuilder:validation:Enum=LinuxBridge;OVSBridge
+	// type of the host interface. Supported values: "LinuxBridge", "OVSBridge", "VLANTrunk".
+	// +kubebuilder:validation:Enum=LinuxBridge;OVSBridge;VLANTrunk
 	// +required
 	Type string `json:"type,omitempty"`
 
@@ -188,16 +348,24 @@ type HostMaster struct {
 	// ovsBridge configuration. Must be set when Type is "OVSBridge".
 	// +optional
 	OVSBridge *OVSBridgeConfig `json:"ovsBridge,omitempty"`
+
+	// vlanTrunk configuration. Must be set when Type is "VLANTrunk".
+	// +optional
+	VLANTrunk *VLANTrunkConfig `json:"vlanTrunk,omitempty"`
 }
 
 // VNIStatus defines the observed state of VNI.
 type L2VNIStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ResourceStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,description=Degraded
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Nodes where the resource is applied
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Nodes where the resource failed
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l2vni,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l2vnis,versions=v1alpha1,name=l2vnivalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L2VNI represents a VXLan VNI to receive EVPN type 2 routes
@@ -22,6 +22,9 @@ import (
 
 // L3VNISpec defines the desired state of VNI.
 // +kubebuilder:validation:XValidation:rule="!has(self.hostSession) || !has(self.hostSession.hostASN) || self.hostSession.hostASN != self.hostSession.asn",message="hostASN must be different from asn"
+// +kubebuilder:validation:XValidation:rule="!has(self.hostSessions) || self.hostSessions.all(s, !has(s.hostASN) || s.hostASN != s.asn)",message="hostASN must be different from asn"
+// +kubebuilder:validation:XValidation:rule="!has(self.hostSession) || !has(self.hostSessions)",message="hostSession and hostSessions cannot be set together"
+// +kubebuilder:validation:XValidation:rule="!has(self.importVRFs) || self.importVRFs.all(i, i.vrf != self.vrf)",message="a VRF cannot import its own routes"
 type L3VNISpec struct {
 	// nodeSelector specifies which nodes this L3VNI applies to.
 	// If empty or not specified, applies to all nodes.
@@ -47,6 +50,20 @@ type L3VNISpec struct {
 	// +optional
 	VXLanPort *int32 `json:"vxlanPort,omitempty"`
 
+	// vxlanTunnel contains the properties of the outer headers of the
+	// packets encapsulated by the VXLan device of the VNI, each of them
+	// overriding the one of the vxlanTunnel of the underlay evpn settings.
+	// +optional
+	VXLanTunnel *VXLanTunnelConfig `json:"vxlanTunnel,omitempty"`
+
+	// underlay is the name of the Underlay the VNI rides on, for nodes
+	// with more than one underlay. When omitted, the VNI rides on the
+	// primary underlay of the node, the first one in name order.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Underlay *string `json:"underlay,omitempty"`
+
 	// underlayAddressFamily selects which VTEP address family to use for this VNI's
 	// VXLAN interface. When omitted, defaults to the available family in the underlay
 	// (IPv4 preferred in dual-stack).
@@ -58,6 +75,17 @@ type L3VNISpec struct {
 	// +optional
 	HostSession *HostSession `json:"hostSession,omitempty"`
 
+	// hostSessions are the configurations of the host sessions, for when more
+	// than one BGP speaking component running in the default network namespace
+	// must peer with the VRF. Each session is established over its own veth
+	// pair. Sessions with an asn different from the one of the first session
+	// use it as local AS. Can't be set together with hostSession.
+	// +kubebuilder:validation:MaxItems:=4
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	HostSessions []VRFHostSession `json:"hostSessions,omitempty"`
+
 	// exportRTs are the Route Targets to be used for exporting routes.
 	// RouteTarget defines a BGP Extended Community for route filtering.
 	// +optional
@@ -71,6 +99,41 @@ type L3VNISpec struct {
 	// +kubebuilder:validation:MaxItems:=100
 	// +listType=atomic
 	ImportRTs []RouteTarget `json:"importRTs,omitempty"`
+
+	// communities are the BGP communities added to the routes exported
+	// from the VRF, and the ones required to import routes into it.
+	// +optional
+	Communities *VRFCommunities `json:"communities,omitempty"`
+
+	// multipath configures BGP multipath for the routes of the VRF.
+	// +optional
+	Multipath *MultipathConfig `json:"multipath,omitempty"`
+
+	// staticRoutes are the static routes of the VRF.
+	// +kubebuilder:validation:MaxItems:=100
+	// +listType=atomic
+	// +optional
+	StaticRoutes []StaticRoute `json:"staticRoutes,omitempty"`
+
+	// aggregates are the summaries of the routes of the VRF advertised
+	// in place of, or in addition to, the routes they contain.
+	// +kubebuilder:validation:MaxItems:=100
+	// +listType=map
+	// +listMapKey=prefix
+	// +optional
+	Aggregates []Aggregate `json:"aggregates,omitempty"`
+
+	// importVRFs are the VRFs whose routes are leaked into the VRF.
+	// +kubebuilder:validation:MaxItems:=16
+	// +listType=map
+	// +listMapKey=vrf
+	// +optional
+	ImportVRFs []VRFImport `json:"importVRFs,omitempty"`
+
+	// exportToDefaultVRF leaks the routes of the VRF into the default VRF,
+	// where they are advertised to the underlay neighbors.
+	// +optional
+	ExportToDefaultVRF *DefaultVRFExport `json:"exportToDefaultVRF,omitempty"`
 }
 
 // RouteTarget defines a BGP Extended Community for route filtering.
@@ -79,12 +142,16 @@ type RouteTarget string
 
 // L3VNIStatus defines the observed state of L3VNI.
 type L3VNIStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ResourceStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,description=Degraded
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Nodes where the resource is applied
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Nodes where the resource failed
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l3vni,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l3vnis,versions=v1alpha1,name=l3vnivalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L3VNI represents a VXLan L3VNI to receive EVPN type 5 routes
@@ -22,6 +22,8 @@ import (
 
 // L3VPNSpec defines the desired state of L3VPN.
 // +kubebuilder:validation:XValidation:rule="!has(self.hostSession) || self.hostSession.hostASN != self.hostSession.asn",message="hostASN must be different from asn"
+// +kubebuilder:validation:XValidation:rule="!has(self.hostSessions) || self.hostSessions.all(s, !has(s.hostASN) || s.hostASN != s.asn)",message="hostASN must be different from asn"
+// +kubebuilder:validation:XValidation:rule="!has(self.hostSession) || !has(self.hostSessions)",message="hostSession and hostSessions cannot be set together"
 type L3VPNSpec struct {
 	// nodeSelector specifies which nodes this L3VPN applies to.
 	// If empty or not specified, applies to all nodes.
@@ -64,16 +66,54 @@ type L3VPNSpec struct {
 	// hostSession is the configuration for the host session.
 	// +optional
 	HostSession *HostSession `json:"hostSession,omitempty"`
+
+	// hostSessions are the configurations of the host sessions, for when more
+	// than one BGP speaking component running in the default network namespace
+	// must peer with the VRF. Each session is established over its own veth
+	// pair. Sessions with an asn different from the one of the first session
+	// use it as local AS. Can't be set together with hostSession.
+	// +kubebuilder:validation:MaxItems:=4
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	HostSessions []VRFHostSession `json:"hostSessions,omitempty"`
+
+	// communities are the BGP communities added to the routes exported
+	// from the VRF, and the ones required to import routes into it.
+	// +optional
+	Communities *VRFCommunities `json:"communities,omitempty"`
+
+	// multipath configures BGP multipath for the routes of the VRF.
+	// +optional
+	Multipath *MultipathConfig `json:"multipath,omitempty"`
+
+	// staticRoutes are the static routes of the VRF.
+	// +kubebuilder:validation:MaxItems:=100
+	// +listType=atomic
+	// +optional
+	StaticRoutes []StaticRoute `json:"staticRoutes,omitempty"`
+
+	// aggregates are the summaries of the routes of the VRF advertised
+	// in place of, or in addition to, the routes they contain.
+	// +kubebuilder:validation:MaxItems:=100
+	// +listType=map
+	// +listMapKey=prefix
+	// +optional
+	Aggregates []Aggregate `json:"aggregates,omitempty"`
 }
 
 // L3VPNStatus defines the observed state of L3VPN.
 type L3VPNStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ResourceStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,description=Degraded
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Nodes where the resource is applied
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Nodes where the resource failed
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l3vpn,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l3vpns,versions=v1alpha1,name=l3vpnsvalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L3VPN represents an SRv6 IP VPN.
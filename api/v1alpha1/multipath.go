@@ -0,0 +1,62 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// MultipathConfig configures BGP multipath, installing the equal cost paths
+// towards a destination learned from different neighbors as a single ECMP
+// route.
+type MultipathConfig struct {
+	// ebgpPaths is the maximum number of paths learned from eBGP neighbors
+	// installed for a destination.
+	// Defaults to 64.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// +optional
+	EBGPPaths *int32 `json:"ebgpPaths,omitempty"`
+
+	// ibgpPaths is the maximum number of paths learned from iBGP neighbors
+	// installed for a destination.
+	// Defaults to 64.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// +optional
+	IBGPPaths *int32 `json:"ibgpPaths,omitempty"`
+
+	// asPathRelax allows paths with different AS paths of the same length
+	// to be used for multipath, as when a node is dual-homed to two ToRs
+	// with different AS numbers.
+	// Defaults to false.
+	// +optional
+	ASPathRelax *bool `json:"asPathRelax,omitempty"`
+}
+
+// UnderlayMultipathConfig configures BGP multipath for the underlay, and how
+// the kernel of the router balances the traffic across the paths.
+type UnderlayMultipathConfig struct {
+	MultipathConfig `json:",inline"`
+
+	// hashPolicy selects the fields of the packets hashed by the kernel to
+	// pick a path of an ECMP route.
+	// Layer3 hashes the source and destination addresses, Layer4 adds the
+	// protocol and ports, Layer3Inner uses the addresses of the inner
+	// packet of encapsulated traffic.
+	// Defaults to Layer3.
+	// +optional
+	HashPolicy *MultipathHashPolicy `json:"hashPolicy,omitempty"`
+}
+
+// MultipathHashPolicy is the multipath hash policy of the kernel.
+// +kubebuilder:validation:Enum=Layer3;Layer4;Layer3Inner
+type MultipathHashPolicy string
+
+const (
+	// MultipathHashPolicyLayer3 hashes the source and destination addresses.
+	MultipathHashPolicyLayer3 MultipathHashPolicy = "Layer3"
+
+	// MultipathHashPolicyLayer4 hashes the addresses, the protocol and the ports.
+	MultipathHashPolicyLayer4 MultipathHashPolicy = "Layer4"
+
+	// MultipathHashPolicyLayer3Inner hashes the addresses of the inner packet
+	// of encapsulated traffic, or the outer ones for the other packets.
+	MultipathHashPolicyLayer3Inner MultipathHashPolicy = "Layer3Inner"
+)
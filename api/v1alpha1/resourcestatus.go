@@ -0,0 +1,57 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceStatus is the status of a router API resource, aggregated
+// from the RouterNodeConfigurationStatus of every node it is applied to.
+type ResourceStatus struct {
+	// conditions list of conditions.
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"` // nolint:kubeapilinter // suggested additional tags are not needed
+
+	// appliedNodes number of selected nodes where the resource was applied successfully.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AppliedNodes *int32 `json:"appliedNodes,omitempty"`
+
+	// failedNodes number of selected nodes where the resource failed to be applied.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedNodes *int32 `json:"failedNodes,omitempty"`
+
+	// nodeFailures list of nodes where the resource failed to be applied.
+	// +listType=map
+	// +listMapKey=node
+	// +kubebuilder:validation:MaxItems=5000
+	// +optional
+	NodeFailures []NodeFailure `json:"nodeFailures,omitempty"`
+}
+
+// NodeFailure describes why a resource failed to be applied on a node.
+type NodeFailure struct {
+	// node name of the failing node.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Node string `json:"node"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// reason machine-readable failure reason, as reported by the node.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=100
+	Reason string `json:"reason"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// message human-readable failure description, as reported by the node.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=500
+	Message string `json:"message"` // nolint:kubeapilinter // required filed should not set omitempty
+}
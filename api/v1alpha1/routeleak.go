@@ -0,0 +1,33 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// DefaultVRFName is the name referring to the default VRF, where the
+// underlay routes live, in the route leaking configurations.
+const DefaultVRFName = "default"
+
+// VRFImport leaks the routes of another VRF into the VRF of the resource.
+type VRFImport struct {
+	// vrf is the name of the VRF the routes are imported from. It must be
+	// the VRF of another L3VNI configured on the same node, or "default"
+	// for the default VRF.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	VRF string `json:"vrf,omitempty"`
+
+	// policy filters the imported routes. All the routes of the VRF are
+	// imported when omitted.
+	// +optional
+	Policy *PrefixPolicy `json:"policy,omitempty"`
+}
+
+// DefaultVRFExport leaks the routes of the VRF of the resource into the
+// default VRF.
+type DefaultVRFExport struct {
+	// policy filters the exported routes. All the routes of the VRF are
+	// exported when omitted.
+	// +optional
+	Policy *PrefixPolicy `json:"policy,omitempty"`
+}
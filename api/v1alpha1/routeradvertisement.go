@@ -0,0 +1,70 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// RouterAdvertisementConfig contains the IPv6 router advertisements sent on
+// the gateway interface of an L2VNI.
+// +kubebuilder:validation:XValidation:rule="!has(self.lifetimeSeconds) || self.lifetimeSeconds == 0 || self.lifetimeSeconds >= (has(self.intervalSeconds) ? self.intervalSeconds : 600)",message="lifetimeSeconds must be 0 or not lower than intervalSeconds"
+type RouterAdvertisementConfig struct {
+	// enabled tells whether the router advertisements are sent.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// intervalSeconds is the maximum time between two unsolicited router
+	// advertisements.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1800
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// lifetimeSeconds is the router lifetime advertised, the time the
+	// workloads use the gateway as their default router for. 0 advertises
+	// that the gateway is not a default router.
+	// Defaults to 1800.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=9000
+	// +optional
+	LifetimeSeconds *int32 `json:"lifetimeSeconds,omitempty"`
+
+	// prefixes overrides the flags of the advertised prefixes.
+	// The IPv6 prefixes of the gatewayIPs are always advertised, as
+	// autonomous and on-link when not listed here.
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=prefix
+	// +optional
+	Prefixes []RouterAdvertisementPrefix `json:"prefixes,omitempty"`
+
+	// dnsServers are the IPv6 addresses of the recursive DNS servers
+	// advertised through the RDNSS option.
+	// +kubebuilder:validation:MaxItems=3
+	// +kubebuilder:validation:items:XValidation:rule="isIP(self) && ip(self).family() == 6",message="dnsServers must be valid IPv6 addresses"
+	// +kubebuilder:validation:items:MaxLength=39
+	// +listType=set
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+}
+
+// RouterAdvertisementPrefix is an IPv6 prefix advertised in the router
+// advertisements.
+type RouterAdvertisementPrefix struct {
+	// prefix is the IPv6 prefix, in CIDR notation.
+	// +kubebuilder:validation:XValidation:rule="isCIDR(self) && cidr(self).ip().family() == 6",message="prefix must be a valid IPv6 CIDR"
+	// +kubebuilder:validation:MaxLength=43
+	// +required
+	Prefix string `json:"prefix,omitempty"`
+
+	// autonomous lets the workloads configure their addresses from the
+	// prefix through SLAAC.
+	// Defaults to true.
+	// +optional
+	Autonomous *bool `json:"autonomous,omitempty"`
+
+	// onLink tells the workloads that the addresses of the prefix are
+	// reachable without going through the gateway.
+	// Defaults to true.
+	// +optional
+	OnLink *bool `json:"onLink,omitempty"`
+}
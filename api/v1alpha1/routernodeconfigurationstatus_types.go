@@ -39,6 +39,7 @@ const (
 	ConditionReasonConfigSuccessful = "ConfigurationSuccessful"
 	ConditionReasonConfigFailed     = "ConfigurationFailed"
 	ConditionReasonUnderlayFailed   = "UnderlayFailed"
+	ConditionReasonConfigPending    = "ConfigurationPending"
 )
 
 type RouterNodeConfigurationStatusStatus struct {
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// StaticRoute is a static route of a VRF. Exactly one of nextHop, interface
+// or blackhole must be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.nextHop) ? 1 : 0) + (has(self.interface) ? 1 : 0) + (has(self.blackhole) && self.blackhole ? 1 : 0) == 1",message="exactly one of nextHop, interface or blackhole must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.nextHop) || !isCIDR(self.prefix) || !isIP(self.nextHop) || ip(self.nextHop).family() == cidr(self.prefix).ip().family()",message="nextHop must be of the same IP family as prefix"
+type StaticRoute struct {
+	// prefix is the destination CIDR of the route.
+	// +kubebuilder:validation:XValidation:rule="isCIDR(self)",message="prefix must be a valid CIDR"
+	// +kubebuilder:validation:MaxLength:=43
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Prefix string `json:"prefix,omitempty"`
+
+	// nextHop is the IP address the traffic matching the route is forwarded
+	// to. It must be reachable from the VRF.
+	// +kubebuilder:validation:XValidation:rule="isIP(self)",message="nextHop must be a valid IPv4 or IPv6 address"
+	// +kubebuilder:validation:MaxLength:=39
+	// +kubebuilder:validation:MinLength:=1
+	// +optional
+	NextHop *string `json:"nextHop,omitempty"`
+
+	// interface is the name of the interface of the VRF the traffic matching
+	// the route is forwarded through.
+	// +kubebuilder:validation:XValidation:rule=`self.matches('^[^\\/:\\s]+$')`,message="Interface must not contain /, :, or whitespace"
+	// +kubebuilder:validation:XValidation:rule=`self != '.' && self != '..'`,message="Interface cannot be . or .."
+	// +kubebuilder:validation:MaxLength:=15
+	// +kubebuilder:validation:MinLength:=1
+	// +optional
+	Interface *string `json:"interface,omitempty"`
+
+	// blackhole discards the traffic matching the route.
+	// +optional
+	Blackhole *bool `json:"blackhole,omitempty"`
+
+	// distance is the administrative distance of the route.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	Distance *int32 `json:"distance,omitempty"`
+
+	// advertise redistributes the route into the BGP instance of the VRF,
+	// exporting it to the fabric together with the other routes of the VRF.
+	// Defaults to false.
+	// +optional
+	Advertise *bool `json:"advertise,omitempty"`
+}
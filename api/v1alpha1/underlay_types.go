@@ -24,6 +24,7 @@ import (
 // UnderlaySpec defines the desired state of Underlay.
 // +kubebuilder:validation:XValidation:rule="!has(self.srv6) || has(self.isis)",message="SRv6 can only be configured if isis is set"
 // +kubebuilder:validation:XValidation:rule="!has(self.srv6) || (has(self.tunnelEndpoint) && has(self.tunnelEndpoint.cidrs) && self.tunnelEndpoint.cidrs.exists(c, cidr(c).ip().family() == 6))",message="SRv6 requires at least one IPv6 CIDR in tunnelEndpoint.cidrs"
+// +kubebuilder:validation:XValidation:rule="!has(self.isis) || !has(self.ospf)",message="isis and ospf are mutually exclusive"
 // +kubebuilder:validation:XValidation:rule="!has(self.routeReflector) || !has(self.routeReflector.clusterID) || !isIP(self.routeReflector.clusterID) || !has(self.routerIDCIDR) || !isCIDR(self.routerIDCIDR) || !cidr(self.routerIDCIDR).containsIP(self.routeReflector.clusterID)",message="routeReflector.clusterID must be outside the routerIDCIDR range"
 type UnderlaySpec struct {
 	// nodeSelector specifies which nodes this Underlay applies to.
@@ -60,10 +61,10 @@ type UnderlaySpec struct {
 	// interfaces is the list of interfaces the router uses for underlay
 	// connectivity. Each entry is a discriminated union describing how the
 	// interface is obtained. At least one interface is required. All the
-	// entries must be of the same type: mixing NetworkDevice and CNIDevice
-	// interfaces is not supported.
+	// entries must be of the same type: mixing NetworkDevice, Bond, VLAN
+	// and CNIDevice interfaces is not supported.
 	// +kubebuilder:validation:MinItems=1
-	// +kubebuilder:validation:XValidation:rule="self.all(i, i.type == self[0].type)",message="all interfaces must be of the same type, mixing NetworkDevice and CNIDevice is not supported"
+	// +kubebuilder:validation:XValidation:rule="self.all(i, i.type == self[0].type)",message="all interfaces must be of the same type, mixing NetworkDevice, Bond, VLAN and CNIDevice is not supported"
 	// +required
 	// +listType=atomic
 	Interfaces []UnderlayInterface `json:"interfaces,omitempty"`
@@ -83,6 +84,11 @@ type UnderlaySpec struct {
 	// +optional
 	ISIS *ISISConfig `json:"isis,omitempty"`
 
+	// ospf holds the OSPF configuration for the underlay, as an alternative
+	// to isis.
+	// +optional
+	OSPF *OSPFConfig `json:"ospf,omitempty"`
+
 	// srv6 holds the SRv6 configuration. Requires ISIS or Neighbors configuration.
 	// +optional
 	SRV6 *SRV6Config `json:"srv6,omitempty"`
@@ -94,12 +100,142 @@ type UnderlaySpec struct {
 	// Omit to run as a standard router without route reflection.
 	// +optional
 	RouteReflector *RouteReflectorConfig `json:"routeReflector,omitempty"`
+
+	// multipath configures BGP multipath for the routes learned from the
+	// neighbors, so that the traffic is balanced across all the uplinks.
+	// +optional
+	Multipath *UnderlayMultipathConfig `json:"multipath,omitempty"`
+
+	// evpn holds the EVPN settings shared by the overlays of the underlay.
+	// +optional
+	EVPN *EVPNConfig `json:"evpn,omitempty"`
 }
 
+// EVPNConfig holds the EVPN settings shared by the overlays of an underlay.
+type EVPNConfig struct {
+	// gatewayMAC is the default MAC address of the distributed anycast
+	// gateway of the L2VNIs with gatewayIPs, used when the L2VNI does not
+	// set its own. It must be a unicast MAC address.
+	// When omitted, the MAC address is derived from the VNI.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	// +optional
+	GatewayMAC *string `json:"gatewayMAC,omitempty"`
+
+	// bridgeMode selects how the L2VNIs are laid out in the router.
+	// PerVNI gives each L2VNI its own bridge and VXLan device.
+	// VLANAware maps each L2VNI to a VLAN of a single VLAN-aware bridge,
+	// attached to a single VXLan device in external mode, which keeps the
+	// number of interfaces constant when many L2VNIs are configured.
+	// L3VNIs always get their own bridge and VXLan device.
+	// Defaults to PerVNI.
+	// +optional
+	BridgeMode *EVPNBridgeMode `json:"bridgeMode,omitempty"`
+
+	// multicast enables the replication of the BUM (broadcast, unknown
+	// unicast and multicast) traffic of the L2VNIs through the underlay
+	// multicast groups they set, running PIM sparse mode on the underlay
+	// interfaces. It requires an IPv4 VTEP.
+	// +optional
+	Multicast *EVPNMulticastConfig `json:"multicast,omitempty"`
+
+	// vxlanTunnel contains the default properties of the outer headers of
+	// the packets encapsulated by the VXLan devices of the L2VNIs and the
+	// L3VNIs, used for the ones they do not set.
+	// +optional
+	VXLanTunnel *VXLanTunnelConfig `json:"vxlanTunnel,omitempty"`
+
+	// duplicateAddressDetection tunes the detection of the MAC and IP
+	// addresses of the L2VNIs moving too often between VTEPs, which are
+	// then reported as duplicate. It applies to all the L2VNIs.
+	// +optional
+	DuplicateAddressDetection *EVPNDuplicateAddressDetection `json:"duplicateAddressDetection,omitempty"`
+}
+
+// EVPNDuplicateAddressDetection contains the settings of the EVPN duplicate
+// address detection. An address is detected as duplicate when it moves
+// maxMoves times within timeSeconds.
+// +kubebuilder:validation:XValidation:rule="!has(self.enabled) || self.enabled || (!has(self.maxMoves) && !has(self.timeSeconds) && !has(self.freeze))",message="maxMoves, timeSeconds and freeze can't be set when the detection is disabled"
+type EVPNDuplicateAddressDetection struct {
+	// enabled tells whether the duplicate addresses are detected.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// maxMoves is the number of moves after which an address is detected
+	// as duplicate.
+	// Defaults to 5.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	MaxMoves *int32 `json:"maxMoves,omitempty"`
+
+	// timeSeconds is the time window, in seconds, the moves are counted in.
+	// Defaults to 180.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=1800
+	// +optional
+	TimeSeconds *int32 `json:"timeSeconds,omitempty"`
+
+	// freeze, when set, freezes the duplicate addresses, ignoring their
+	// updates from the other VTEPs until they are unfrozen.
+	// When omitted, the duplicate addresses are only reported.
+	// +optional
+	Freeze *EVPNDuplicateAddressFreeze `json:"freeze,omitempty"`
+
+	// clearFrozenToken is an opaque value, setting it to a new value
+	// clears the duplicate addresses on all the nodes, including the
+	// permanently frozen ones.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	ClearFrozenToken *string `json:"clearFrozenToken,omitempty"`
+}
+
+// EVPNDuplicateAddressFreeze selects for how long the duplicate addresses are
+// frozen. Exactly one of permanent or timeSeconds must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.permanent) && self.permanent ? !has(self.timeSeconds) : has(self.timeSeconds)",message="exactly one of permanent or timeSeconds must be set"
+type EVPNDuplicateAddressFreeze struct {
+	// permanent freezes the duplicate addresses until they are cleared
+	// through clearFrozenToken.
+	// +optional
+	Permanent *bool `json:"permanent,omitempty"`
+
+	// timeSeconds is the time, in seconds, after which the duplicate
+	// addresses are unfrozen.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	TimeSeconds *int32 `json:"timeSeconds,omitempty"`
+}
+
+// EVPNMulticastConfig contains the PIM configuration of the underlay used
+// to replicate the BUM traffic of the L2VNIs.
+type EVPNMulticastConfig struct {
+	// rendezvousPoint is the IPv4 address of the PIM rendezvous point of the
+	// multicast groups of the L2VNIs.
+	// +kubebuilder:validation:XValidation:rule="isIP(self) && ip(self).family() == 4",message="rendezvousPoint must be a valid IPv4 address"
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	RendezvousPoint string `json:"rendezvousPoint,omitempty"`
+}
+
+// EVPNBridgeMode selects how the L2VNIs are laid out in the router.
+// +kubebuilder:validation:Enum=PerVNI;VLANAware
+type EVPNBridgeMode string
+
+const (
+	// EVPNBridgeModePerVNI gives each L2VNI its own bridge and VXLan device.
+	EVPNBridgeModePerVNI EVPNBridgeMode = "PerVNI"
+
+	// EVPNBridgeModeVLANAware maps each L2VNI to a VLAN of a single
+	// VLAN-aware bridge, sharing a single VXLan device.
+	EVPNBridgeModeVLANAware EVPNBridgeMode = "VLANAware"
+)
+
 // UnderlayInterfaceType selects how the router obtains an underlay link.
 // It is the discriminator of the UnderlayInterface union and is designed to be
 // extended with future modes.
-// +kubebuilder:validation:Enum=NetworkDevice;CNIDevice
+// +kubebuilder:validation:Enum=NetworkDevice;CNIDevice;Bond;VLAN
 type UnderlayInterfaceType string
 
 const (
@@ -110,6 +246,14 @@ const (
 	// UnderlayInterfaceTypeCNIDevice invokes a CNI plugin to provision an interface
 	// in the router netns.
 	UnderlayInterfaceTypeCNIDevice UnderlayInterfaceType = "CNIDevice"
+
+	// UnderlayInterfaceTypeBond creates a bond in the router netns,
+	// enslaving host network devices moved into it.
+	UnderlayInterfaceTypeBond UnderlayInterfaceType = "Bond"
+
+	// UnderlayInterfaceTypeVLAN creates a VLAN sub-interface of a host
+	// network device and moves it into the router netns.
+	UnderlayInterfaceTypeVLAN UnderlayInterfaceType = "VLAN"
 )
 
 // UnderlayInterface defines how the router obtains a single underlay link.
@@ -120,6 +264,8 @@ const (
 // +union
 // +kubebuilder:validation:XValidation:rule="has(self.networkDevice) == (self.type == 'NetworkDevice')",message="type/config mismatch: networkDevice must be set if and only if type is 'NetworkDevice'"
 // +kubebuilder:validation:XValidation:rule="has(self.cniDevice) == (self.type == 'CNIDevice')",message="type/config mismatch: cniDevice must be set if and only if type is 'CNIDevice'"
+// +kubebuilder:validation:XValidation:rule="has(self.bond) == (self.type == 'Bond')",message="type/config mismatch: bond must be set if and only if type is 'Bond'"
+// +kubebuilder:validation:XValidation:rule="has(self.vlan) == (self.type == 'VLAN')",message="type/config mismatch: vlan must be set if and only if type is 'VLAN'"
 type UnderlayInterface struct {
 	// type selects how the router obtains this underlay link.
 	// +required
@@ -137,6 +283,17 @@ type UnderlayInterface struct {
 	// "CNIDevice".
 	// +optional
 	CNIDevice *CNIDevice `json:"cniDevice,omitempty"`
+
+	// bond creates a bond in the router netns, enslaving the given host
+	// network devices moved into it. Must be set when type is "Bond".
+	// +optional
+	Bond *BondDevice `json:"bond,omitempty"`
+
+	// vlan creates a VLAN sub-interface of a host network device and moves
+	// it into the router netns, leaving the parent device on the host. Must
+	// be set when type is "VLAN".
+	// +optional
+	VLAN *VLANDevice `json:"vlan,omitempty"`
 }
 
 // NetworkDevice moves an existing host network device into the router netns.
@@ -150,6 +307,135 @@ type NetworkDevice struct {
 	InterfaceName string `json:"interfaceName,omitempty"`
 }
 
+// BondDevice creates a bond of host network devices in the router netns.
+// The members are moved back to the host when the bond is removed.
+// +kubebuilder:validation:XValidation:rule="!has(self.lacpRate) || (has(self.mode) ? self.mode : 'LACP') == 'LACP'",message="lacpRate can only be set with the LACP mode"
+// +kubebuilder:validation:XValidation:rule="!has(self.transmitHashPolicy) || (has(self.mode) ? self.mode : 'LACP') in ['LACP', 'BalanceXOR', 'BalanceTLB']",message="transmitHashPolicy can only be set with the LACP, BalanceXOR and BalanceTLB modes"
+type BondDevice struct {
+	// interfaceName is the name of the bond created in the router netns.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9._-]*$`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	InterfaceName string `json:"interfaceName,omitempty"`
+
+	// members are the names of the host network devices moved into the
+	// router netns and enslaved to the bond.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z][a-zA-Z0-9._-]*$`
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=15
+	// +listType=set
+	// +required
+	Members []string `json:"members,omitempty"`
+
+	// mode is the bonding mode. Defaults to LACP (802.3ad).
+	// +optional
+	Mode *BondMode `json:"mode,omitempty"`
+
+	// miimonInterval is the interval the link state of the members is
+	// checked at, in milliseconds. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60000
+	// +optional
+	MIIMonInterval *int32 `json:"miimonInterval,omitempty"`
+
+	// lacpRate is the rate the LACPDUs are requested to be sent by the
+	// partner at. Only valid with the LACP mode. Defaults to Slow.
+	// +optional
+	LACPRate *BondLACPRate `json:"lacpRate,omitempty"`
+
+	// transmitHashPolicy selects the fields of the packets hashed to pick
+	// the member to transmit them on. Only valid with the LACP, BalanceXOR
+	// and BalanceTLB modes. Defaults to Layer2.
+	// +optional
+	TransmitHashPolicy *BondTransmitHashPolicy `json:"transmitHashPolicy,omitempty"`
+
+	// addresses are the IP addresses, in CIDR notation, assigned to the
+	// bond in the router netns, for the neighbors to be reached over it.
+	// The addresses of the members are not moved to the bond. They are
+	// assigned as they are on every node the Underlay applies to. When
+	// omitted, only unnumbered neighbors can be reached.
+	// +kubebuilder:validation:MaxItems:=16
+	// +kubebuilder:validation:items:MaxLength:=43
+	// +kubebuilder:validation:items:XValidation:rule="isCIDR(self)",message="addresses must be valid CIDRs"
+	// +listType=set
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// BondMode is the bonding mode of a bond.
+// +kubebuilder:validation:Enum=BalanceRR;ActiveBackup;BalanceXOR;Broadcast;LACP;BalanceTLB;BalanceALB
+type BondMode string
+
+const (
+	BondModeBalanceRR    BondMode = "BalanceRR"
+	BondModeActiveBackup BondMode = "ActiveBackup"
+	BondModeBalanceXOR   BondMode = "BalanceXOR"
+	BondModeBroadcast    BondMode = "Broadcast"
+	// BondModeLACP is the IEEE 802.3ad dynamic link aggregation.
+	BondModeLACP       BondMode = "LACP"
+	BondModeBalanceTLB BondMode = "BalanceTLB"
+	BondModeBalanceALB BondMode = "BalanceALB"
+)
+
+// BondLACPRate is the rate the LACPDUs are sent at.
+// +kubebuilder:validation:Enum=Slow;Fast
+type BondLACPRate string
+
+const (
+	// BondLACPRateSlow requests the LACPDUs every 30 seconds.
+	BondLACPRateSlow BondLACPRate = "Slow"
+	// BondLACPRateFast requests the LACPDUs every second.
+	BondLACPRateFast BondLACPRate = "Fast"
+)
+
+// BondTransmitHashPolicy selects the fields of the packets hashed to pick
+// the member of a bond to transmit them on.
+// +kubebuilder:validation:Enum=Layer2;Layer23;Layer34;Encap23;Encap34
+type BondTransmitHashPolicy string
+
+const (
+	BondTransmitHashPolicyLayer2  BondTransmitHashPolicy = "Layer2"
+	BondTransmitHashPolicyLayer23 BondTransmitHashPolicy = "Layer23"
+	BondTransmitHashPolicyLayer34 BondTransmitHashPolicy = "Layer34"
+	BondTransmitHashPolicyEncap23 BondTransmitHashPolicy = "Encap23"
+	BondTransmitHashPolicyEncap34 BondTransmitHashPolicy = "Encap34"
+)
+
+// VLANDevice creates the VLAN sub-interface <parent>.<vlanID> of a host
+// network device and moves it into the router netns. The parent device stays
+// on the host, carrying the host traffic. The sub-interface is deleted when
+// it is removed from the underlay.
+// +kubebuilder:validation:XValidation:rule="size(self.parent) + size(string(self.vlanID)) < 15",message="the name of the VLAN sub-interface, <parent>.<vlanID>, must be at most 15 characters long"
+type VLANDevice struct {
+	// parent is the name of the host network device the VLAN sub-interface
+	// is created on.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9._-]*$`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	Parent string `json:"parent,omitempty"`
+
+	// vlanID is the 802.1Q VLAN ID of the sub-interface.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	// +required
+	VLANID int32 `json:"vlanID,omitempty"`
+
+	// addresses are the IP addresses, in CIDR notation, assigned to the
+	// sub-interface in the router netns, for the neighbors to be reached
+	// over it. They are assigned as they are on every node the Underlay
+	// applies to. When omitted, only unnumbered neighbors can be reached.
+	// +kubebuilder:validation:MaxItems:=16
+	// +kubebuilder:validation:items:MaxLength:=43
+	// +kubebuilder:validation:items:XValidation:rule="isCIDR(self)",message="addresses must be valid CIDRs"
+	// +listType=set
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+}
+
 // CNIConfigType selects the source of the CNI configuration.
 // It is the discriminator of the CNIDevice union and is designed to be
 // extended with future config sources (e.g. a NetworkAttachmentDefinition
@@ -291,8 +577,44 @@ type ISISConfig struct {
 	// +kubebuilder:validation:Enum:=1;2
 	// +optional
 	Level *int32 `json:"level,omitempty"`
+	// areaAuthentication authenticates the level-1 LSPs and SNPs.
+	// +optional
+	AreaAuthentication *ISISAuthentication `json:"areaAuthentication,omitempty"`
+	// domainAuthentication authenticates the level-2 LSPs and SNPs.
+	// +optional
+	DomainAuthentication *ISISAuthentication `json:"domainAuthentication,omitempty"`
+	// overload sets the overload bit in the LSPs of the node, so that the
+	// other routers stop using it for transit traffic, i.e. during
+	// maintenance.
+	// +optional
+	Overload *bool `json:"overload,omitempty"`
 }
 
+// ISISAuthentication configures the authentication of ISIS PDUs.
+type ISISAuthentication struct {
+	// type is the authentication type: Clear sends the password in clear
+	// text, HMACMD5 signs the PDUs with HMAC-MD5.
+	// +required
+	Type ISISAuthenticationType `json:"type,omitempty"`
+	// passwordSecret is the name of the secret holding the password. The
+	// secret must be of type "kubernetes.io/basic-auth", and created in the
+	// same namespace as the perouter daemon. The password is stored in the
+	// secret as the key "password".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	PasswordSecret string `json:"passwordSecret,omitempty"`
+}
+
+// ISISAuthenticationType is the authentication type of ISIS PDUs.
+// +kubebuilder:validation:Enum=Clear;HMACMD5
+type ISISAuthenticationType string
+
+const (
+	ISISAuthenticationClear   ISISAuthenticationType = "Clear"
+	ISISAuthenticationHMACMD5 ISISAuthenticationType = "HMACMD5"
+)
+
 // ISISNet represents a single ISIS NET address.
 // Only accepts the simplified NSAP format with a fixed AreaID length of 3 bytes and a 6 byte SystemID in compliance
 // with the U.S. GOSIP version 2.0 for a total of 10 bytes.
@@ -326,6 +648,31 @@ type ISISInterface struct {
 	// +listType=atomic
 	// +optional
 	Features []ISISInterfaceFeature `json:"features,omitempty"`
+	// metric is the ISIS metric of the interface. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16777215
+	// +optional
+	Metric *int32 `json:"metric,omitempty"`
+	// helloInterval is the interval between the hello PDUs sent on the
+	// interface, in seconds. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=600
+	// +optional
+	HelloInterval *int32 `json:"helloInterval,omitempty"`
+	// helloMultiplier is the number of hello PDUs that can be missed before
+	// the adjacency is declared down. Defaults to 10.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HelloMultiplier *int32 `json:"helloMultiplier,omitempty"`
+	// authentication authenticates the hello PDUs sent and received on the
+	// interface.
+	// +optional
+	Authentication *ISISAuthentication `json:"authentication,omitempty"`
+	// bfd enables BFD on the adjacencies of the interface, so that their
+	// failure is detected faster than with the hello PDUs.
+	// +optional
+	BFD *BFDSettings `json:"bfd,omitempty"`
 }
 
 // ISISInterfaceFeature represents a single ISIS feature of an ISIS interface.
@@ -334,6 +681,103 @@ type ISISInterface struct {
 // +kubebuilder:validation:Enum:=passive
 type ISISInterfaceFeature string
 
+// OSPFConfig contains OSPF configuration for the underlay. OSPFv2 runs for
+// IPv4 and OSPFv3 for IPv6, on the underlay interfaces and passively on the
+// loopback carrying the tunnel endpoint addresses.
+type OSPFConfig struct {
+	// area is the OSPF area the interfaces belong to, in dotted decimal or
+	// decimal notation. Defaults to the backbone area 0.0.0.0.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+$') || (isIP(self) && ip(self).family() == 4)",message="area must be a decimal number or a dotted decimal IPv4 address"
+	// +optional
+	Area *string `json:"area,omitempty"`
+	// ipFamily selects the OSPF versions to run: OSPFv2 for IPv4, OSPFv3 for
+	// IPv6 or both for DualStack. Defaults to the families of the tunnel
+	// endpoint, IPv4 when the tunnel endpoint is not set.
+	// +optional
+	IPFamily *IPFamily `json:"ipFamily,omitempty"`
+	// authentication configures the authentication of the OSPF packets sent
+	// on the non passive interfaces.
+	// +optional
+	Authentication *OSPFAuthentication `json:"authentication,omitempty"`
+	// interfaces holds additional OSPF interface level configuration and / or
+	// per interface overrides of the underlay interfaces and of the loopback.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems:=128
+	// +optional
+	Interfaces []OSPFInterface `json:"interfaces,omitempty"`
+}
+
+// OSPFInterface holds OSPF interface level configuration.
+type OSPFInterface struct {
+	// name of the interface that these settings shall apply to.
+	// +kubebuilder:validation:XValidation:rule=`self.matches('^[^\\/:\\s]+$')`,message="Interface must not contain /, :, or whitespace"
+	// +kubebuilder:validation:XValidation:rule=`self != '.' && self != '..'`,message="Interface cannot be . or .."
+	// +kubebuilder:validation:MaxLength:=15
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Name string `json:"name,omitempty"`
+	// networkType is the OSPF network type of the interface. Defaults to
+	// Broadcast.
+	// +optional
+	NetworkType *OSPFNetworkType `json:"networkType,omitempty"`
+	// cost is the OSPF cost of the interface. Defaults to the one derived
+	// from the interface bandwidth.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Cost *int32 `json:"cost,omitempty"`
+	// passive advertises the addresses of the interface without forming
+	// adjacencies on it. Defaults to true for the loopback and false for the
+	// other interfaces.
+	// +optional
+	Passive *bool `json:"passive,omitempty"`
+}
+
+// OSPFNetworkType is the OSPF network type of an interface.
+// +kubebuilder:validation:Enum=PointToPoint;Broadcast
+type OSPFNetworkType string
+
+const (
+	OSPFNetworkTypePointToPoint OSPFNetworkType = "PointToPoint"
+	OSPFNetworkTypeBroadcast    OSPFNetworkType = "Broadcast"
+)
+
+// OSPFAuthentication configures the authentication of the OSPF packets.
+type OSPFAuthentication struct {
+	// type is the authentication type: Simple sends the key in clear text
+	// and is only supported by OSPFv2, MessageDigest signs the packets with
+	// HMAC-MD5.
+	// +required
+	Type OSPFAuthenticationType `json:"type,omitempty"`
+	// keyID identifies the key of the MessageDigest authentication. Defaults
+	// to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	KeyID *int32 `json:"keyID,omitempty"`
+	// keySecret is the name of the secret holding the key. The secret must be
+	// of type "kubernetes.io/basic-auth", and created in the same namespace as
+	// the perouter daemon. The key is stored in the secret as the key
+	// "password", and is at most 8 characters long with Simple
+	// authentication, 16 with MessageDigest.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	KeySecret string `json:"keySecret,omitempty"`
+}
+
+// OSPFAuthenticationType is the authentication type of the OSPF packets.
+// +kubebuilder:validation:Enum=Simple;MessageDigest
+type OSPFAuthenticationType string
+
+const (
+	OSPFAuthenticationSimple        OSPFAuthenticationType = "Simple"
+	OSPFAuthenticationMessageDigest OSPFAuthenticationType = "MessageDigest"
+)
+
 // IPFamily specifies which address families are enabled.
 // +kubebuilder:validation:Enum=IPv4;IPv6;DualStack
 type IPFamily string
@@ -395,12 +839,16 @@ type SRV6Locator struct {
 
 // UnderlayStatus defines the observed state of Underlay.
 type UnderlayStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	ResourceStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`,description=Degraded
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Nodes where the resource is applied
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Nodes where the resource failed
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-underlay,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=underlays,versions=v1alpha1,name=underlayvalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // Underlay is the Schema for the underlays API.
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// VXLanTunnelConfig contains the properties of the outer headers of the
+// packets encapsulated by the VXLan device of a VNI.
+type VXLanTunnelConfig struct {
+	// ttl is the TTL of the encapsulated packets.
+	// When omitted, the kernel default is used, or 64 for the L2VNIs with
+	// a multicastGroup.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	TTL *int32 `json:"ttl,omitempty"`
+
+	// dscp is the DSCP value set on the encapsulated packets.
+	// Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=63
+	// +optional
+	DSCP *int32 `json:"dscp,omitempty"`
+
+	// dontFragment selects how the DF flag of the encapsulated IPv4 packets
+	// is set. Unset leaves it cleared, Set always sets it, so that the
+	// packets exceeding the MTU of the underlay are rejected instead of
+	// being fragmented, and Inherit copies it from the inner packet.
+	// Defaults to Unset.
+	// +optional
+	DontFragment *VXLanDontFragment `json:"dontFragment,omitempty"`
+
+	// sourcePortRange is the range the UDP source port of the encapsulated
+	// packets, which is derived from a hash of the inner packet, is picked
+	// from. Set it to match the ports hashed by the fabric. As with
+	// iproute2, max is not part of the range.
+	// When omitted, the local port range of the kernel is used.
+	// +optional
+	SourcePortRange *PortRange `json:"sourcePortRange,omitempty"`
+
+	// udpChecksum computes the UDP checksum of the encapsulated packets
+	// when true, and skips it when false.
+	// When omitted, the kernel default is used: the checksum is skipped
+	// with IPv4 VTEPs and computed with IPv6 VTEPs.
+	// +optional
+	UDPChecksum *bool `json:"udpChecksum,omitempty"`
+}
+
+// VXLanDontFragment is the handling of the DF flag of the encapsulated packets.
+// +kubebuilder:validation:Enum=Unset;Set;Inherit
+type VXLanDontFragment string
+
+const (
+	// VXLanDontFragmentUnset leaves the DF flag cleared.
+	VXLanDontFragmentUnset VXLanDontFragment = "Unset"
+
+	// VXLanDontFragmentSet always sets the DF flag.
+	VXLanDontFragmentSet VXLanDontFragment = "Set"
+
+	// VXLanDontFragmentInherit copies the DF flag of the inner packet.
+	VXLanDontFragmentInherit VXLanDontFragment = "Inherit"
+)
+
+// PortRange is a range of UDP ports, from min up to max excluded.
+// +kubebuilder:validation:XValidation:rule="self.min < self.max",message="min must be less than max"
+type PortRange struct {
+	// min is the first port of the range.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +required
+	Min int32 `json:"min,omitempty"`
+
+	// max is the port following the last one of the range.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +required
+	Max int32 `json:"max,omitempty"`
+}
@@ -41,6 +41,31 @@ func (in *AddressFamilyProperty) DeepCopy() *AddressFamilyProperty {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Aggregate) DeepCopyInto(out *Aggregate) {
+	*out = *in
+	if in.SummaryOnly != nil {
+		in, out := &in.SummaryOnly, &out.SummaryOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ASSet != nil {
+		in, out := &in.ASSet, &out.ASSet
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Aggregate.
+func (in *Aggregate) DeepCopy() *Aggregate {
+	if in == nil {
+		return nil
+	}
+	out := new(Aggregate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BFDSettings) DeepCopyInto(out *BFDSettings) {
 	*out = *in
@@ -81,6 +106,51 @@ func (in *BFDSettings) DeepCopy() *BFDSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BondDevice) DeepCopyInto(out *BondDevice) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(BondMode)
+		**out = **in
+	}
+	if in.MIIMonInterval != nil {
+		in, out := &in.MIIMonInterval, &out.MIIMonInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LACPRate != nil {
+		in, out := &in.LACPRate, &out.LACPRate
+		*out = new(BondLACPRate)
+		**out = **in
+	}
+	if in.TransmitHashPolicy != nil {
+		in, out := &in.TransmitHashPolicy, &out.TransmitHashPolicy
+		*out = new(BondTransmitHashPolicy)
+		**out = **in
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BondDevice.
+func (in *BondDevice) DeepCopy() *BondDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(BondDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNIDevice) DeepCopyInto(out *CNIDevice) {
 	*out = *in
@@ -111,6 +181,141 @@ func (in *CNIDevice) DeepCopy() *CNIDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Communities) DeepCopyInto(out *Communities) {
+	*out = *in
+	if in.Standard != nil {
+		in, out := &in.Standard, &out.Standard
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Large != nil {
+		in, out := &in.Large, &out.Large
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extended != nil {
+		in, out := &in.Extended, &out.Extended
+		*out = make([]ExtendedCommunity, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Communities.
+func (in *Communities) DeepCopy() *Communities {
+	if in == nil {
+		return nil
+	}
+	out := new(Communities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPRelayConfig) DeepCopyInto(out *DHCPRelayConfig) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Option82 != nil {
+		in, out := &in.Option82, &out.Option82
+		*out = new(DHCPRelayOption82)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPRelayConfig.
+func (in *DHCPRelayConfig) DeepCopy() *DHCPRelayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPRelayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPRelayOption82) DeepCopyInto(out *DHCPRelayOption82) {
+	*out = *in
+	if in.CircuitID != nil {
+		in, out := &in.CircuitID, &out.CircuitID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RemoteID != nil {
+		in, out := &in.RemoteID, &out.RemoteID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(DHCPRelayOption82Policy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPRelayOption82.
+func (in *DHCPRelayOption82) DeepCopy() *DHCPRelayOption82 {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPRelayOption82)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultOriginate) DeepCopyInto(out *DefaultOriginate) {
+	*out = *in
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(IPFamily)
+		**out = **in
+	}
+	if in.ConditionPrefixes != nil {
+		in, out := &in.ConditionPrefixes, &out.ConditionPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnlyDefaultRoute != nil {
+		in, out := &in.OnlyDefaultRoute, &out.OnlyDefaultRoute
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultOriginate.
+func (in *DefaultOriginate) DeepCopy() *DefaultOriginate {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultOriginate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultVRFExport) DeepCopyInto(out *DefaultVRFExport) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PrefixPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultVRFExport.
+func (in *DefaultVRFExport) DeepCopy() *DefaultVRFExport {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultVRFExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EBGPMultiHopProperties) DeepCopyInto(out *EBGPMultiHopProperties) {
 	*out = *in
@@ -131,6 +336,166 @@ func (in *EBGPMultiHopProperties) DeepCopy() *EBGPMultiHopProperties {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNAdvertisementConfig) DeepCopyInto(out *EVPNAdvertisementConfig) {
+	*out = *in
+	if in.DefaultGateway != nil {
+		in, out := &in.DefaultGateway, &out.DefaultGateway
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SVIIP != nil {
+		in, out := &in.SVIIP, &out.SVIIP
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNAdvertisementConfig.
+func (in *EVPNAdvertisementConfig) DeepCopy() *EVPNAdvertisementConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNAdvertisementConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNConfig) DeepCopyInto(out *EVPNConfig) {
+	*out = *in
+	if in.GatewayMAC != nil {
+		in, out := &in.GatewayMAC, &out.GatewayMAC
+		*out = new(string)
+		**out = **in
+	}
+	if in.BridgeMode != nil {
+		in, out := &in.BridgeMode, &out.BridgeMode
+		*out = new(EVPNBridgeMode)
+		**out = **in
+	}
+	if in.Multicast != nil {
+		in, out := &in.Multicast, &out.Multicast
+		*out = new(EVPNMulticastConfig)
+		**out = **in
+	}
+	if in.VXLanTunnel != nil {
+		in, out := &in.VXLanTunnel, &out.VXLanTunnel
+		*out = new(VXLanTunnelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DuplicateAddressDetection != nil {
+		in, out := &in.DuplicateAddressDetection, &out.DuplicateAddressDetection
+		*out = new(EVPNDuplicateAddressDetection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNConfig.
+func (in *EVPNConfig) DeepCopy() *EVPNConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNDuplicateAddressDetection) DeepCopyInto(out *EVPNDuplicateAddressDetection) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxMoves != nil {
+		in, out := &in.MaxMoves, &out.MaxMoves
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeSeconds != nil {
+		in, out := &in.TimeSeconds, &out.TimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Freeze != nil {
+		in, out := &in.Freeze, &out.Freeze
+		*out = new(EVPNDuplicateAddressFreeze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClearFrozenToken != nil {
+		in, out := &in.ClearFrozenToken, &out.ClearFrozenToken
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNDuplicateAddressDetection.
+func (in *EVPNDuplicateAddressDetection) DeepCopy() *EVPNDuplicateAddressDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNDuplicateAddressDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNDuplicateAddressFreeze) DeepCopyInto(out *EVPNDuplicateAddressFreeze) {
+	*out = *in
+	if in.Permanent != nil {
+		in, out := &in.Permanent, &out.Permanent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeSeconds != nil {
+		in, out := &in.TimeSeconds, &out.TimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNDuplicateAddressFreeze.
+func (in *EVPNDuplicateAddressFreeze) DeepCopy() *EVPNDuplicateAddressFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNDuplicateAddressFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNMulticastConfig) DeepCopyInto(out *EVPNMulticastConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNMulticastConfig.
+func (in *EVPNMulticastConfig) DeepCopy() *EVPNMulticastConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNMulticastConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtendedCommunity) DeepCopyInto(out *ExtendedCommunity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtendedCommunity.
+func (in *ExtendedCommunity) DeepCopy() *ExtendedCommunity {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtendedCommunity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedResource) DeepCopyInto(out *FailedResource) {
 	*out = *in
@@ -184,6 +549,11 @@ func (in *HostMaster) DeepCopyInto(out *HostMaster) {
 		*out = new(OVSBridgeConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VLANTrunk != nil {
+		in, out := &in.VLANTrunk, &out.VLANTrunk
+		*out = new(VLANTrunkConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostMaster.
@@ -210,6 +580,56 @@ func (in *HostSession) DeepCopyInto(out *HostSession) {
 		**out = **in
 	}
 	in.LocalCIDR.DeepCopyInto(&out.LocalCIDR)
+	if in.ImportPolicy != nil {
+		in, out := &in.ImportPolicy, &out.ImportPolicy
+		*out = new(PrefixPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportPolicy != nil {
+		in, out := &in.ExportPolicy, &out.ExportPolicy
+		*out = new(PrefixPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.HoldTimeSeconds != nil {
+		in, out := &in.HoldTimeSeconds, &out.HoldTimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.KeepaliveTimeSeconds != nil {
+		in, out := &in.KeepaliveTimeSeconds, &out.KeepaliveTimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConnectTimeSeconds != nil {
+		in, out := &in.ConnectTimeSeconds, &out.ConnectTimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulRestart != nil {
+		in, out := &in.GracefulRestart, &out.GracefulRestart
+		*out = new(GracefulRestartMode)
+		**out = **in
+	}
+	if in.DefaultOriginate != nil {
+		in, out := &in.DefaultOriginate, &out.DefaultOriginate
+		*out = new(DefaultOriginate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSession.
@@ -222,6 +642,21 @@ func (in *HostSession) DeepCopy() *HostSession {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISISAuthentication) DeepCopyInto(out *ISISAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISISAuthentication.
+func (in *ISISAuthentication) DeepCopy() *ISISAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ISISAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ISISConfig) DeepCopyInto(out *ISISConfig) {
 	*out = *in
@@ -242,6 +677,21 @@ func (in *ISISConfig) DeepCopyInto(out *ISISConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AreaAuthentication != nil {
+		in, out := &in.AreaAuthentication, &out.AreaAuthentication
+		*out = new(ISISAuthentication)
+		**out = **in
+	}
+	if in.DomainAuthentication != nil {
+		in, out := &in.DomainAuthentication, &out.DomainAuthentication
+		*out = new(ISISAuthentication)
+		**out = **in
+	}
+	if in.Overload != nil {
+		in, out := &in.Overload, &out.Overload
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISISConfig.
@@ -267,6 +717,31 @@ func (in *ISISInterface) DeepCopyInto(out *ISISInterface) {
 		*out = make([]ISISInterfaceFeature, len(*in))
 		copy(*out, *in)
 	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HelloInterval != nil {
+		in, out := &in.HelloInterval, &out.HelloInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HelloMultiplier != nil {
+		in, out := &in.HelloMultiplier, &out.HelloMultiplier
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ISISAuthentication)
+		**out = **in
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISISInterface.
@@ -288,7 +763,7 @@ func (in *L2VNI) DeepCopyInto(out *L2VNI) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L2VNIStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -360,11 +835,26 @@ func (in *L2VNISpec) DeepCopyInto(out *L2VNISpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.VXLanTunnel != nil {
+		in, out := &in.VXLanTunnel, &out.VXLanTunnel
+		*out = new(VXLanTunnelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Underlay != nil {
+		in, out := &in.Underlay, &out.Underlay
+		*out = new(string)
+		**out = **in
+	}
 	if in.UnderlayAddressFamily != nil {
 		in, out := &in.UnderlayAddressFamily, &out.UnderlayAddressFamily
 		*out = new(string)
 		**out = **in
 	}
+	if in.VLAN != nil {
+		in, out := &in.VLAN, &out.VLAN
+		*out = new(int32)
+		**out = **in
+	}
 	if in.HostMaster != nil {
 		in, out := &in.HostMaster, &out.HostMaster
 		*out = new(HostMaster)
@@ -375,6 +865,41 @@ func (in *L2VNISpec) DeepCopyInto(out *L2VNISpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GatewayMAC != nil {
+		in, out := &in.GatewayMAC, &out.GatewayMAC
+		*out = new(string)
+		**out = **in
+	}
+	if in.ARPNDSuppression != nil {
+		in, out := &in.ARPNDSuppression, &out.ARPNDSuppression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProxyARP != nil {
+		in, out := &in.ProxyARP, &out.ProxyARP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RouterAdvertisement != nil {
+		in, out := &in.RouterAdvertisement, &out.RouterAdvertisement
+		*out = new(RouterAdvertisementConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DHCPRelay != nil {
+		in, out := &in.DHCPRelay, &out.DHCPRelay
+		*out = new(DHCPRelayConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EVPNAdvertisement != nil {
+		in, out := &in.EVPNAdvertisement, &out.EVPNAdvertisement
+		*out = new(EVPNAdvertisementConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MulticastGroup != nil {
+		in, out := &in.MulticastGroup, &out.MulticastGroup
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L2VNISpec.
@@ -390,6 +915,7 @@ func (in *L2VNISpec) DeepCopy() *L2VNISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L2VNIStatus) DeepCopyInto(out *L2VNIStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L2VNIStatus.
@@ -411,7 +937,7 @@ func (in *L3Passthrough) DeepCopyInto(out *L3Passthrough) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L3PassthroughStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -489,6 +1015,7 @@ func (in *L3PassthroughSpec) DeepCopy() *L3PassthroughSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L3PassthroughStatus) DeepCopyInto(out *L3PassthroughStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3PassthroughStatus.
@@ -510,7 +1037,7 @@ func (in *L3VNI) DeepCopyInto(out *L3VNI) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L3VNIStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -592,6 +1119,16 @@ func (in *L3VNISpec) DeepCopyInto(out *L3VNISpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.VXLanTunnel != nil {
+		in, out := &in.VXLanTunnel, &out.VXLanTunnel
+		*out = new(VXLanTunnelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Underlay != nil {
+		in, out := &in.Underlay, &out.Underlay
+		*out = new(string)
+		**out = **in
+	}
 	if in.UnderlayAddressFamily != nil {
 		in, out := &in.UnderlayAddressFamily, &out.UnderlayAddressFamily
 		*out = new(string)
@@ -602,6 +1139,13 @@ func (in *L3VNISpec) DeepCopyInto(out *L3VNISpec) {
 		*out = new(HostSession)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostSessions != nil {
+		in, out := &in.HostSessions, &out.HostSessions
+		*out = make([]VRFHostSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ExportRTs != nil {
 		in, out := &in.ExportRTs, &out.ExportRTs
 		*out = make([]RouteTarget, len(*in))
@@ -612,6 +1156,42 @@ func (in *L3VNISpec) DeepCopyInto(out *L3VNISpec) {
 		*out = make([]RouteTarget, len(*in))
 		copy(*out, *in)
 	}
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = new(VRFCommunities)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multipath != nil {
+		in, out := &in.Multipath, &out.Multipath
+		*out = new(MultipathConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticRoutes != nil {
+		in, out := &in.StaticRoutes, &out.StaticRoutes
+		*out = make([]StaticRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Aggregates != nil {
+		in, out := &in.Aggregates, &out.Aggregates
+		*out = make([]Aggregate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImportVRFs != nil {
+		in, out := &in.ImportVRFs, &out.ImportVRFs
+		*out = make([]VRFImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExportToDefaultVRF != nil {
+		in, out := &in.ExportToDefaultVRF, &out.ExportToDefaultVRF
+		*out = new(DefaultVRFExport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VNISpec.
@@ -627,6 +1207,7 @@ func (in *L3VNISpec) DeepCopy() *L3VNISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L3VNIStatus) DeepCopyInto(out *L3VNIStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VNIStatus.
@@ -648,7 +1229,7 @@ func (in *L3VPN) DeepCopyInto(out *L3VPN) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L3VPNStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -740,6 +1321,37 @@ func (in *L3VPNSpec) DeepCopyInto(out *L3VPNSpec) {
 		*out = new(HostSession)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostSessions != nil {
+		in, out := &in.HostSessions, &out.HostSessions
+		*out = make([]VRFHostSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = new(VRFCommunities)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multipath != nil {
+		in, out := &in.Multipath, &out.Multipath
+		*out = new(MultipathConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticRoutes != nil {
+		in, out := &in.StaticRoutes, &out.StaticRoutes
+		*out = make([]StaticRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Aggregates != nil {
+		in, out := &in.Aggregates, &out.Aggregates
+		*out = make([]Aggregate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VPNSpec.
@@ -755,6 +1367,7 @@ func (in *L3VPNSpec) DeepCopy() *L3VPNSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L3VPNStatus) DeepCopyInto(out *L3VPNStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VPNStatus.
@@ -812,6 +1425,36 @@ func (in *LocalCIDRConfig) DeepCopy() *LocalCIDRConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipathConfig) DeepCopyInto(out *MultipathConfig) {
+	*out = *in
+	if in.EBGPPaths != nil {
+		in, out := &in.EBGPPaths, &out.EBGPPaths
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IBGPPaths != nil {
+		in, out := &in.IBGPPaths, &out.IBGPPaths
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ASPathRelax != nil {
+		in, out := &in.ASPathRelax, &out.ASPathRelax
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipathConfig.
+func (in *MultipathConfig) DeepCopy() *MultipathConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipathConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Neighbor) DeepCopyInto(out *Neighbor) {
 	*out = *in
@@ -902,76 +1545,261 @@ func (in *Neighbor) DeepCopy() *Neighbor {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NeighborAddressFamily) DeepCopyInto(out *NeighborAddressFamily) {
+func (in *NeighborAddressFamily) DeepCopyInto(out *NeighborAddressFamily) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]AddressFamilyProperty, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NeighborAddressFamily.
+func (in *NeighborAddressFamily) DeepCopy() *NeighborAddressFamily {
+	if in == nil {
+		return nil
+	}
+	out := new(NeighborAddressFamily)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NeighborProperty) DeepCopyInto(out *NeighborProperty) {
+	*out = *in
+	if in.EBGPMultiHop != nil {
+		in, out := &in.EBGPMultiHop, &out.EBGPMultiHop
+		*out = new(EBGPMultiHopProperties)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NeighborProperty.
+func (in *NeighborProperty) DeepCopy() *NeighborProperty {
+	if in == nil {
+		return nil
+	}
+	out := new(NeighborProperty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDevice) DeepCopyInto(out *NetworkDevice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDevice.
+func (in *NetworkDevice) DeepCopy() *NetworkDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailure) DeepCopyInto(out *NodeFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailure.
+func (in *NodeFailure) DeepCopy() *NodeFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSPFAuthentication) DeepCopyInto(out *OSPFAuthentication) {
+	*out = *in
+	if in.KeyID != nil {
+		in, out := &in.KeyID, &out.KeyID
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSPFAuthentication.
+func (in *OSPFAuthentication) DeepCopy() *OSPFAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(OSPFAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSPFConfig) DeepCopyInto(out *OSPFConfig) {
+	*out = *in
+	if in.Area != nil {
+		in, out := &in.Area, &out.Area
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(IPFamily)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(OSPFAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]OSPFInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSPFConfig.
+func (in *OSPFConfig) DeepCopy() *OSPFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OSPFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSPFInterface) DeepCopyInto(out *OSPFInterface) {
+	*out = *in
+	if in.NetworkType != nil {
+		in, out := &in.NetworkType, &out.NetworkType
+		*out = new(OSPFNetworkType)
+		**out = **in
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Passive != nil {
+		in, out := &in.Passive, &out.Passive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSPFInterface.
+func (in *OSPFInterface) DeepCopy() *OSPFInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(OSPFInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSBridgeConfig.
+func (in *OVSBridgeConfig) DeepCopy() *OVSBridgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSBridgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortRange) DeepCopyInto(out *PortRange) {
 	*out = *in
-	if in.Properties != nil {
-		in, out := &in.Properties, &out.Properties
-		*out = make([]AddressFamilyProperty, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NeighborAddressFamily.
-func (in *NeighborAddressFamily) DeepCopy() *NeighborAddressFamily {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortRange.
+func (in *PortRange) DeepCopy() *PortRange {
 	if in == nil {
 		return nil
 	}
-	out := new(NeighborAddressFamily)
+	out := new(PortRange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NeighborProperty) DeepCopyInto(out *NeighborProperty) {
+func (in *PrefixCommunities) DeepCopyInto(out *PrefixCommunities) {
 	*out = *in
-	if in.EBGPMultiHop != nil {
-		in, out := &in.EBGPMultiHop, &out.EBGPMultiHop
-		*out = new(EBGPMultiHopProperties)
-		(*in).DeepCopyInto(*out)
-	}
+	in.Communities.DeepCopyInto(&out.Communities)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NeighborProperty.
-func (in *NeighborProperty) DeepCopy() *NeighborProperty {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixCommunities.
+func (in *PrefixCommunities) DeepCopy() *PrefixCommunities {
 	if in == nil {
 		return nil
 	}
-	out := new(NeighborProperty)
+	out := new(PrefixCommunities)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkDevice) DeepCopyInto(out *NetworkDevice) {
+func (in *PrefixPolicy) DeepCopyInto(out *PrefixPolicy) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PrefixRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDevice.
-func (in *NetworkDevice) DeepCopy() *NetworkDevice {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixPolicy.
+func (in *PrefixPolicy) DeepCopy() *PrefixPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkDevice)
+	out := new(PrefixPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
+func (in *PrefixRule) DeepCopyInto(out *PrefixRule) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(PrefixRuleAction)
+		**out = **in
+	}
+	if in.GE != nil {
+		in, out := &in.GE, &out.GE
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LE != nil {
+		in, out := &in.LE, &out.LE
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSBridgeConfig.
-func (in *OVSBridgeConfig) DeepCopy() *OVSBridgeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixRule.
+func (in *PrefixRule) DeepCopy() *PrefixRule {
 	if in == nil {
 		return nil
 	}
-	out := new(OVSBridgeConfig)
+	out := new(PrefixRule)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1079,6 +1907,43 @@ func (in *RawFRRConfigStatus) DeepCopy() *RawFRRConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceStatus) DeepCopyInto(out *ResourceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppliedNodes != nil {
+		in, out := &in.AppliedNodes, &out.AppliedNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedNodes != nil {
+		in, out := &in.FailedNodes, &out.FailedNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeFailures != nil {
+		in, out := &in.NodeFailures, &out.NodeFailures
+		*out = make([]NodeFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceStatus.
+func (in *ResourceStatus) DeepCopy() *ResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteReflectorConfig) DeepCopyInto(out *RouteReflectorConfig) {
 	*out = *in
@@ -1099,6 +1964,73 @@ func (in *RouteReflectorConfig) DeepCopy() *RouteReflectorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterAdvertisementConfig) DeepCopyInto(out *RouterAdvertisementConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LifetimeSeconds != nil {
+		in, out := &in.LifetimeSeconds, &out.LifetimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]RouterAdvertisementPrefix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterAdvertisementConfig.
+func (in *RouterAdvertisementConfig) DeepCopy() *RouterAdvertisementConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterAdvertisementConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterAdvertisementPrefix) DeepCopyInto(out *RouterAdvertisementPrefix) {
+	*out = *in
+	if in.Autonomous != nil {
+		in, out := &in.Autonomous, &out.Autonomous
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnLink != nil {
+		in, out := &in.OnLink, &out.OnLink
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterAdvertisementPrefix.
+func (in *RouterAdvertisementPrefix) DeepCopy() *RouterAdvertisementPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterAdvertisementPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouterNodeConfigurationStatus) DeepCopyInto(out *RouterNodeConfigurationStatus) {
 	*out = *in
@@ -1201,6 +2133,11 @@ func (in *RoutingDomain) DeepCopyInto(out *RoutingDomain) {
 		*out = new(L3VPNReference)
 		**out = **in
 	}
+	if in.IRBMode != nil {
+		in, out := &in.IRBMode, &out.IRBMode
+		*out = new(IRBMode)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingDomain.
@@ -1249,6 +2186,46 @@ func (in *SRV6Locator) DeepCopy() *SRV6Locator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticRoute) DeepCopyInto(out *StaticRoute) {
+	*out = *in
+	if in.NextHop != nil {
+		in, out := &in.NextHop, &out.NextHop
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(string)
+		**out = **in
+	}
+	if in.Blackhole != nil {
+		in, out := &in.Blackhole, &out.Blackhole
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Distance != nil {
+		in, out := &in.Distance, &out.Distance
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Advertise != nil {
+		in, out := &in.Advertise, &out.Advertise
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticRoute.
+func (in *StaticRoute) DeepCopy() *StaticRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TunnelEndpointConfig) DeepCopyInto(out *TunnelEndpointConfig) {
 	*out = *in
@@ -1278,7 +2255,7 @@ func (in *Underlay) DeepCopyInto(out *Underlay) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(UnderlayStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1313,6 +2290,16 @@ func (in *UnderlayInterface) DeepCopyInto(out *UnderlayInterface) {
 		*out = new(CNIDevice)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bond != nil {
+		in, out := &in.Bond, &out.Bond
+		*out = new(BondDevice)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLAN != nil {
+		in, out := &in.VLAN, &out.VLAN
+		*out = new(VLANDevice)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnderlayInterface.
@@ -1357,6 +2344,27 @@ func (in *UnderlayList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnderlayMultipathConfig) DeepCopyInto(out *UnderlayMultipathConfig) {
+	*out = *in
+	in.MultipathConfig.DeepCopyInto(&out.MultipathConfig)
+	if in.HashPolicy != nil {
+		in, out := &in.HashPolicy, &out.HashPolicy
+		*out = new(MultipathHashPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnderlayMultipathConfig.
+func (in *UnderlayMultipathConfig) DeepCopy() *UnderlayMultipathConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UnderlayMultipathConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnderlaySpec) DeepCopyInto(out *UnderlaySpec) {
 	*out = *in
@@ -1399,6 +2407,11 @@ func (in *UnderlaySpec) DeepCopyInto(out *UnderlaySpec) {
 		*out = new(ISISConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OSPF != nil {
+		in, out := &in.OSPF, &out.OSPF
+		*out = new(OSPFConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SRV6 != nil {
 		in, out := &in.SRV6, &out.SRV6
 		*out = new(SRV6Config)
@@ -1409,6 +2422,16 @@ func (in *UnderlaySpec) DeepCopyInto(out *UnderlaySpec) {
 		*out = new(RouteReflectorConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Multipath != nil {
+		in, out := &in.Multipath, &out.Multipath
+		*out = new(UnderlayMultipathConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EVPN != nil {
+		in, out := &in.EVPN, &out.EVPN
+		*out = new(EVPNConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnderlaySpec.
@@ -1424,6 +2447,7 @@ func (in *UnderlaySpec) DeepCopy() *UnderlaySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnderlayStatus) DeepCopyInto(out *UnderlayStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnderlayStatus.
@@ -1435,3 +2459,151 @@ func (in *UnderlayStatus) DeepCopy() *UnderlayStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLANDevice) DeepCopyInto(out *VLANDevice) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLANDevice.
+func (in *VLANDevice) DeepCopy() *VLANDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLANTrunkConfig) DeepCopyInto(out *VLANTrunkConfig) {
+	*out = *in
+	if in.Bridge != nil {
+		in, out := &in.Bridge, &out.Bridge
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLANTrunkConfig.
+func (in *VLANTrunkConfig) DeepCopy() *VLANTrunkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANTrunkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRFCommunities) DeepCopyInto(out *VRFCommunities) {
+	*out = *in
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(Communities)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportPrefixes != nil {
+		in, out := &in.ExportPrefixes, &out.ExportPrefixes
+		*out = make([]PrefixCommunities, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImportMatch != nil {
+		in, out := &in.ImportMatch, &out.ImportMatch
+		*out = new(Communities)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRFCommunities.
+func (in *VRFCommunities) DeepCopy() *VRFCommunities {
+	if in == nil {
+		return nil
+	}
+	out := new(VRFCommunities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRFHostSession) DeepCopyInto(out *VRFHostSession) {
+	*out = *in
+	in.HostSession.DeepCopyInto(&out.HostSession)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRFHostSession.
+func (in *VRFHostSession) DeepCopy() *VRFHostSession {
+	if in == nil {
+		return nil
+	}
+	out := new(VRFHostSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRFImport) DeepCopyInto(out *VRFImport) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PrefixPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRFImport.
+func (in *VRFImport) DeepCopy() *VRFImport {
+	if in == nil {
+		return nil
+	}
+	out := new(VRFImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VXLanTunnelConfig) DeepCopyInto(out *VXLanTunnelConfig) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DontFragment != nil {
+		in, out := &in.DontFragment, &out.DontFragment
+		*out = new(VXLanDontFragment)
+		**out = **in
+	}
+	if in.SourcePortRange != nil {
+		in, out := &in.SourcePortRange, &out.SourcePortRange
+		*out = new(PortRange)
+		**out = **in
+	}
+	if in.UDPChecksum != nil {
+		in, out := &in.UDPChecksum, &out.UDPChecksum
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VXLanTunnelConfig.
+func (in *VXLanTunnelConfig) DeepCopy() *VXLanTunnelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VXLanTunnelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
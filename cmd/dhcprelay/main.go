@@ -0,0 +1,33 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/openperouter/openperouter/internal/buildversion"
+	"github.com/openperouter/openperouter/internal/dhcprelay"
+)
+
+func main() {
+	relay, err := dhcprelay.ParseArgs(os.Args[1:])
+	if err != nil {
+		slog.Error("invalid arguments", "error", err)
+		os.Exit(1)
+	}
+
+	logger := slog.Default().With("relay", relay.Name)
+	logger.Info("version", "version", buildversion.Version())
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := dhcprelay.Run(ctx, relay, logger); err != nil {
+		logger.Error("dhcp relay failed", "error", err)
+		os.Exit(1)
+	}
+}
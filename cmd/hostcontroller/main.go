@@ -55,6 +55,7 @@ import (
 	"github.com/openperouter/openperouter/internal/controller/routerconfiguration"
 	"github.com/openperouter/openperouter/internal/conversion"
 	"github.com/openperouter/openperouter/internal/dhcp"
+	"github.com/openperouter/openperouter/internal/dhcprelay"
 	"github.com/openperouter/openperouter/internal/filewatcher"
 	"github.com/openperouter/openperouter/internal/frr"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
@@ -66,11 +67,12 @@ import (
 )
 
 const (
-	datapathKernel   = "kernel"
-	datapathGrout    = "grout"
-	modeK8s          = "k8s"
-	modeHost         = "host"
-	restartDHCPEvent = "dhcp-restart-trigger"
+	datapathKernel       = "kernel"
+	datapathGrout        = "grout"
+	modeK8s              = "k8s"
+	modeHost             = "host"
+	restartDHCPEvent     = "dhcp-restart-trigger"
+	dhcpRelayStatusEvent = "dhcp-relay-status-trigger"
 )
 
 var (
@@ -364,7 +366,10 @@ func runK8sConfigReconcilerHostMode(ctx context.Context,
 	triggerChan := make(chan event.GenericEvent, 1)
 	mirrorTriggerChan := make(chan event.GenericEvent, 1)
 
-	var datapathConfigurator routerconfiguration.DatapathConfigurator = &routerconfiguration.KernelDatapathConfigurator{}
+	dhcpRelaySupervisor := dhcprelay.NewSupervisor(logger)
+	var datapathConfigurator routerconfiguration.DatapathConfigurator = &routerconfiguration.KernelDatapathConfigurator{
+		DHCPRelays: dhcpRelaySupervisor,
+	}
 	if args.datapath == datapathGrout {
 		datapathConfigurator = routerconfiguration.NewGroutConfigurator(args.groutSocketPath)
 	}
@@ -378,6 +383,15 @@ func runK8sConfigReconcilerHostMode(ctx context.Context,
 		return fmt.Errorf("unable to add DHCP supervisor: %w", err)
 	}
 
+	dhcpRelaySupervisor.OnStatusChange = triggerKubernetesReconcile(triggerChan, types.NamespacedName{
+		Namespace: dhcpRelayStatusEvent,
+		Name:      args.namespace,
+	})
+
+	if err := mgr.Add(dhcpRelaySupervisor); err != nil {
+		return fmt.Errorf("unable to add DHCP relay supervisor: %w", err)
+	}
+
 	apiReconciler := &routerconfiguration.PERouterReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
@@ -471,7 +485,10 @@ func runK8sConfigReconciler(ctx context.Context,
 		Node:            args.nodeName,
 	}
 
-	var datapathConfigurator routerconfiguration.DatapathConfigurator = &routerconfiguration.KernelDatapathConfigurator{}
+	dhcpRelaySupervisor := dhcprelay.NewSupervisor(logger)
+	var datapathConfigurator routerconfiguration.DatapathConfigurator = &routerconfiguration.KernelDatapathConfigurator{
+		DHCPRelays: dhcpRelaySupervisor,
+	}
 	if args.datapath == datapathGrout {
 		datapathConfigurator = routerconfiguration.NewGroutConfigurator(args.groutSocketPath)
 	}
@@ -486,6 +503,15 @@ func runK8sConfigReconciler(ctx context.Context,
 		return fmt.Errorf("unable to add DHCP supervisor: %w", err)
 	}
 
+	dhcpRelaySupervisor.OnStatusChange = triggerKubernetesReconcile(triggerChan, types.NamespacedName{
+		Namespace: args.namespace,
+		Name:      dhcpRelayStatusEvent,
+	})
+
+	if err := mgr.Add(dhcpRelaySupervisor); err != nil {
+		return fmt.Errorf("unable to add DHCP relay supervisor: %w", err)
+	}
+
 	apiReconciler := &routerconfiguration.PERouterReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
@@ -538,7 +564,10 @@ func runStaticConfigReconciler(ctx context.Context,
 		RouterHealthCheckPort: hostModeParams.routerHealthCheckPort,
 	}
 
-	var datapathConfigurator routerconfiguration.DatapathConfigurator = &routerconfiguration.KernelDatapathConfigurator{}
+	dhcpRelaySupervisor := dhcprelay.NewSupervisor(logger)
+	var datapathConfigurator routerconfiguration.DatapathConfigurator = &routerconfiguration.KernelDatapathConfigurator{
+		DHCPRelays: dhcpRelaySupervisor,
+	}
 	if args.datapath == datapathGrout {
 		datapathConfigurator = routerconfiguration.NewGroutConfigurator(args.groutSocketPath)
 	}
@@ -569,6 +598,15 @@ func runStaticConfigReconciler(ctx context.Context,
 		return fmt.Errorf("unable to add DHCP supervisor: %w", err)
 	}
 
+	dhcpRelaySupervisor.OnStatusChange = func() {
+		slog.Info("triggered reconciliation after DHCP relay status change")
+		staticReconciler.TriggerReconcile()
+	}
+
+	if err := mgr.Add(dhcpRelaySupervisor); err != nil {
+		return fmt.Errorf("unable to add DHCP relay supervisor: %w", err)
+	}
+
 	if err := staticRouterProvider.StartFRRRestartWatcher(ctx, func() {
 		staticReconciler.TriggerReconcile()
 	}); err != nil {
@@ -625,6 +663,11 @@ func createK8sManager(
 						"metadata.namespace": namespace,
 					}.AsSelector(),
 				},
+				// Only the secrets holding the BGP passwords, stored in the
+				// namespace of the daemon, are relevant.
+				&corev1.Secret{}: {
+					Field: fields.Set{"metadata.namespace": namespace}.AsSelector(),
+				},
 				&periov1alpha1.RouterNodeConfigurationStatus{}: {
 					Field: fields.Set{
 						"metadata.name":      nodeName,
@@ -767,9 +810,9 @@ func triggerKubernetesReconcile(
 				},
 			},
 		}:
-			slog.Info("triggered reconciliation after DHCP daemon restart")
+			slog.Info("triggered reconciliation", "trigger", name)
 		default:
-			slog.Debug("reconciliation already queued, skipping DHCP restart trigger")
+			slog.Debug("reconciliation already queued, skipping trigger", "trigger", name)
 		}
 	}
 }
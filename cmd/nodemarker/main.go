@@ -41,6 +41,7 @@ import (
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/buildversion"
 	"github.com/openperouter/openperouter/internal/controller/nodeindex"
+	"github.com/openperouter/openperouter/internal/controller/resourcestatus"
 	"github.com/openperouter/openperouter/internal/conversion"
 	"github.com/openperouter/openperouter/internal/logging"
 	"github.com/openperouter/openperouter/internal/tlsconfig"
@@ -161,6 +162,11 @@ func main() {
 		close(startListeners)
 	}
 
+	operatorNS := args.namespace
+	if operatorNS == "" {
+		operatorNS = os.Getenv("POD_NAMESPACE")
+	}
+
 	signalHandlerContext := ctrl.SetupSignalHandler()
 	go func() {
 		<-startListeners
@@ -176,6 +182,15 @@ func main() {
 				setupLog.Error(err, "unable to create controller", "controller", "NodeReconciler")
 				os.Exit(1)
 			}
+			if err = (&resourcestatus.ResourceStatusReconciler{
+				Client:    mgr.GetClient(),
+				Scheme:    mgr.GetScheme(),
+				Namespace: operatorNS,
+				Logger:    logger,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "ResourceStatusReconciler")
+				os.Exit(1)
+			}
 			// +kubebuilder:scaffold:builder
 		}
 
@@ -184,11 +199,6 @@ func main() {
 			datapathConfigValidator = &conversion.GroutDatapathConfigValidator{}
 		}
 
-		operatorNS := args.namespace
-		if operatorNS == "" {
-			operatorNS = os.Getenv("POD_NAMESPACE")
-		}
-
 		if args.webhookMode == WebhookModeEnabled || args.webhookMode == WebhookModeWebhookOnly {
 			setupLog.Info("Starting webhooks")
 			if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -23,24 +23,35 @@ func TestHandler(t *testing.T) {
 	tests := []struct {
 		name       string
 		reloadMock func(string) error
+		clearMock  func(string) error
 		method     string
 		httpStatus int
 	}{
 		{
 			"succeeds",
 			reloadSucceeds,
+			reloadSucceeds,
 			http.MethodPost,
 			200,
 		},
 		{
 			"wrong method",
 			reloadSucceeds,
+			reloadSucceeds,
 			http.MethodGet,
 			http.StatusBadRequest,
 		},
 		{
 			"reload fails",
 			reloadFails,
+			reloadSucceeds,
+			http.MethodPost,
+			http.StatusInternalServerError,
+		},
+		{
+			"clearing duplicate addresses fails",
+			reloadSucceeds,
+			reloadFails,
 			http.MethodPost,
 			http.StatusInternalServerError,
 		},
@@ -54,7 +65,7 @@ func TestHandler(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(tc.method, "/", nil)
-			handler := http.HandlerFunc(reloadHandler("/etc/frr/frr.conf"))
+			handler := http.HandlerFunc(reloadHandler("/etc/frr/frr.conf", tc.clearMock))
 
 			handler.ServeHTTP(w, req)
 			res := w.Result()
@@ -86,11 +86,13 @@ func serveReload(args Args) error {
 		return fmt.Errorf("failed to listen on unix socket %s: %w", args.unixSocket, err)
 	}
 
+	frrCli := vtysh.NewCLIWithTimeout(args.vtyshTimeout)
+	dupAddrClearer := frrconfig.NewDupAddrClearer(frrCli)
 	unixServer := newServer(
-		[]handlerConfig{{pattern: "/", handler: reloadHandler(args.frrConfigPath)}},
+		[]handlerConfig{{pattern: "/", handler: reloadHandler(args.frrConfigPath, dupAddrClearer.ClearOnTokenChange)}},
 	)
 
-	healthHandler := health(vtysh.NewCLIWithTimeout(args.vtyshTimeout))
+	healthHandler := health(frrCli)
 	healthServer := newServer(
 		[]handlerConfig{
 			{pattern: "/healthz", handler: healthHandler},
@@ -144,7 +146,9 @@ func serveReload(args Args) error {
 
 var updateConfig = frrconfig.Update
 
-func reloadHandler(frrConfigPath string) func(w http.ResponseWriter, req *http.Request) {
+// reloadHandler reloads the frr configuration, and then clears the EVPN
+// duplicate addresses if the configuration requests it.
+func reloadHandler(frrConfigPath string, clearDupAddrs func(string) error) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		if req.Method != http.MethodPost {
 			http.Error(w, "invalid method", http.StatusBadRequest)
@@ -156,6 +160,11 @@ func reloadHandler(frrConfigPath string) func(w http.ResponseWriter, req *http.R
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		err = clearDupAddrs(frrConfigPath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		slog.Info("reload handler", "event", "reload successful")
 	}
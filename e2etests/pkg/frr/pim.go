@@ -0,0 +1,34 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frr
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openperouter/openperouter/e2etests/pkg/executor"
+)
+
+// PIMInterface is a PIM enabled interface as reported by pimd.
+type PIMInterface struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Address string `json:"address"`
+}
+
+// PIMInterfaces returns the interfaces PIM is enabled on, indexed by name.
+func PIMInterfaces(exec executor.Executor) (map[string]PIMInterface, error) {
+	output, err := exec.Exec("vtysh", "-c", "show ip pim interface json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pim interfaces: %w", err)
+	}
+	return parsePIMInterfaces(output)
+}
+
+func parsePIMInterfaces(vtyshRes string) (map[string]PIMInterface, error) {
+	res := map[string]PIMInterface{}
+	if err := json.Unmarshal([]byte(vtyshRes), &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pim interfaces: %w, raw: %s", err, vtyshRes)
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frr
+
+import (
+	"testing"
+)
+
+func TestParsePIMInterfaces(t *testing.T) {
+	jsonData := `{
+		"lo": {
+			"name": "lo",
+			"state": "up",
+			"address": "100.65.0.1",
+			"pimDesignatedRouter": "100.65.0.1",
+			"firstHopRouter": 0,
+			"pimIfChannelNumber": 0
+		},
+		"toswitch1": {
+			"name": "toswitch1",
+			"state": "up",
+			"address": "192.168.11.3",
+			"pimDesignatedRouter": "192.168.11.3",
+			"firstHopRouter": 0,
+			"pimIfChannelNumber": 0
+		}
+	}`
+
+	interfaces, err := parsePIMInterfaces(jsonData)
+	if err != nil {
+		t.Fatalf("Failed to parse pim interfaces: %v", err)
+	}
+
+	if len(interfaces) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(interfaces))
+	}
+
+	if interfaces["lo"].State != "up" {
+		t.Errorf("Expected lo to be up, got %s", interfaces["lo"].State)
+	}
+
+	if interfaces["toswitch1"].Address != "192.168.11.3" {
+		t.Errorf("Expected toswitch1 address to be 192.168.11.3, got %s", interfaces["toswitch1"].Address)
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package tests
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/e2etests/pkg/config"
+	"github.com/openperouter/openperouter/e2etests/pkg/frr"
+	"github.com/openperouter/openperouter/e2etests/pkg/infra"
+	"github.com/openperouter/openperouter/e2etests/pkg/k8s"
+	"github.com/openperouter/openperouter/e2etests/pkg/k8sclient"
+	"github.com/openperouter/openperouter/e2etests/pkg/openperouter"
+	corev1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+var _ = Describe("Underlay multicast", Ordered, func() {
+	var (
+		cs    clientset.Interface
+		nodes []corev1.Node
+	)
+
+	BeforeAll(func() {
+		cs = k8sclient.New()
+
+		var err error
+		nodes, err = k8s.GetNodes(cs)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(Updater.CleanAll()).To(Succeed())
+
+		underlay := *infra.Underlay.DeepCopy()
+		underlay.Spec.EVPN = &v1alpha1.EVPNConfig{
+			Multicast: &v1alpha1.EVPNMulticastConfig{
+				RendezvousPoint: "192.168.11.2",
+			},
+		}
+		Expect(Updater.Update(config.Resources{
+			Underlays: []v1alpha1.Underlay{underlay},
+		})).To(Succeed())
+	})
+
+	AfterAll(func() {
+		Expect(Updater.CleanAll()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		dumpIfFails(cs)
+	})
+
+	It("brings up pim on the loopback and on the underlay interfaces", func() {
+		routers, err := openperouter.Get(cs, HostMode)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, node := range nodes {
+			exec, err := routers.ExecutorForNode(node.Name)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() error {
+				interfaces, err := frr.PIMInterfaces(exec)
+				if err != nil {
+					return err
+				}
+				for _, name := range []string{"lo", "toswitch1", "toswitch2"} {
+					iface, ok := interfaces[name]
+					if !ok {
+						return fmt.Errorf("pim is not enabled on %s of node %s", name, node.Name)
+					}
+					if iface.State != "up" {
+						return fmt.Errorf("pim interface %s of node %s is %s", name, node.Name, iface.State)
+					}
+				}
+				return nil
+			}, time.Minute, time.Second).ShouldNot(HaveOccurred())
+		}
+	})
+})
@@ -522,7 +522,7 @@ var _ = Describe("Mirror static config to Kubernetes", Label("systemdmode"), Ord
 		By("waiting for mirrored underlay to exist")
 		Eventually(validateUnderlays, "60s", "2s").Should(Succeed())
 
-		By("attempting to create a K8s-managed underlay (conflicts: can't have >1 underlay per node)")
+		By("attempting to create a K8s-managed underlay sharing the interface of the mirrored one")
 		err := Updater.Update(config.Resources{
 			Underlays: []v1alpha1.Underlay{
 				{
@@ -541,7 +541,7 @@ var _ = Describe("Mirror static config to Kubernetes", Label("systemdmode"), Ord
 						Interfaces: []v1alpha1.UnderlayInterface{
 							{
 								Type:          "NetworkDevice",
-								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "toswitch"},
 							},
 						},
 						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
@@ -552,6 +552,6 @@ var _ = Describe("Mirror static config to Kubernetes", Label("systemdmode"), Ord
 			},
 		})
 		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(ContainSubstring("can't have more than one underlay per node"))
+		Expect(err.Error()).To(ContainSubstring("interface toswitch already used by underlay"))
 	})
 })
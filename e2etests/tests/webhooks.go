@@ -724,7 +724,7 @@ var _ = Describe("Webhooks", func() {
 				})
 				Expect(err).To(MatchError(ContainSubstring(expectedError)))
 			},
-			Entry("when trying to create a second different underlay (should fail)",
+			Entry("when trying to create a second underlay sharing the interface of the first one (should fail)",
 				[]v1alpha1.Underlay{
 					{
 						ObjectMeta: metav1.ObjectMeta{
@@ -733,7 +733,7 @@ var _ = Describe("Webhooks", func() {
 						},
 						Spec: v1alpha1.UnderlaySpec{
 							ASN:        65001,
-							Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "nic2"}}},
+							Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "nic1"}}},
 							TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
 								CIDRs: []string{"192.168.2.0/24"},
 							},
@@ -743,7 +743,7 @@ var _ = Describe("Webhooks", func() {
 						},
 					},
 				},
-				"can't have more than one underlay",
+				"underlay underlay2 has interface nic1 already used by underlay underlay1",
 			),
 			Entry("when updating the existing underlay with an invalid CIDR (should fail)",
 				[]v1alpha1.Underlay{
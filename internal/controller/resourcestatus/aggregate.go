@@ -0,0 +1,186 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+const (
+	// maxNodesInMessage caps the number of failing nodes listed in the
+	// condition message, the full list is available in status.nodeFailures.
+	maxNodesInMessage = 5
+	// maxFailureMessageLength matches the validation of NodeFailure.Message.
+	maxFailureMessageLength = 500
+)
+
+// nodeReport is what a node reported in its RouterNodeConfigurationStatus
+// after its last reconciliation.
+type nodeReport struct {
+	// failures are the failed resources reported by the node, keyed by kind/name.
+	failures map[string]v1alpha1.FailedResource
+	// nodeFailure is set when the node failed as a whole, so no resource
+	// other than the ones listed in failures can be considered applied.
+	nodeFailure *v1alpha1.NodeFailure
+	// underlayFailed is true when the node reported a failing underlay, in
+	// which case none of the overlays were applied.
+	underlayFailed bool
+}
+
+// nodeReports indexes the reports by node name. Nodes that did not report
+// a Ready condition yet are not part of the result.
+func nodeReports(statuses []v1alpha1.RouterNodeConfigurationStatus) map[string]nodeReport {
+	res := map[string]nodeReport{}
+	for _, s := range statuses {
+		if s.Status == nil {
+			continue
+		}
+		ready := apimeta.FindStatusCondition(s.Status.Conditions, v1alpha1.ConditionTypeReady)
+		if ready == nil {
+			continue
+		}
+
+		report := nodeReport{failures: map[string]v1alpha1.FailedResource{}}
+		for _, f := range s.Status.FailedResources {
+			report.failures[failureKey(f.Kind, f.Name)] = f
+		}
+		switch {
+		case ready.Status == metav1.ConditionTrue:
+		case ready.Reason == v1alpha1.ConditionReasonUnderlayFailed:
+			report.underlayFailed = true
+		case len(s.Status.FailedResources) == 0:
+			report.nodeFailure = &v1alpha1.NodeFailure{
+				Node:    s.Name,
+				Reason:  ready.Reason,
+				Message: truncate(ready.Message, maxFailureMessageLength),
+			}
+		}
+		res[s.Name] = report
+	}
+	return res
+}
+
+// failureFor returns the failure affecting the given resource on the node,
+// or nil if the resource was applied successfully.
+func (r nodeReport) failureFor(node string, kind v1alpha1.FailedResourceKind, name string) *v1alpha1.NodeFailure {
+	if f, ok := r.failures[failureKey(kind, name)]; ok {
+		return &v1alpha1.NodeFailure{
+			Node:    node,
+			Reason:  string(f.Reason),
+			Message: f.Message,
+		}
+	}
+	if r.underlayFailed && kind != openpeerrors.KindUnderlay {
+		return &v1alpha1.NodeFailure{
+			Node:    node,
+			Reason:  string(v1alpha1.FailedResourceReasonDependencyFailed),
+			Message: "the underlay failed on the node",
+		}
+	}
+	if r.nodeFailure != nil {
+		f := *r.nodeFailure
+		f.Node = node
+		return &f
+	}
+	return nil
+}
+
+// aggregateStatus folds the reports of the nodes selected by a resource into
+// its status. Existing conditions are carried over so that their transition
+// time is preserved when nothing changed.
+func aggregateStatus(kind v1alpha1.FailedResourceKind, obj metav1.Object, selectedNodes []string,
+	reports map[string]nodeReport, existing *v1alpha1.ResourceStatus) v1alpha1.ResourceStatus {
+	var res v1alpha1.ResourceStatus
+	if existing != nil && len(existing.Conditions) > 0 {
+		res.Conditions = make([]metav1.Condition, len(existing.Conditions))
+		copy(res.Conditions, existing.Conditions)
+	}
+
+	applied, pending := int32(0), 0
+	for _, node := range selectedNodes {
+		report, ok := reports[node]
+		if !ok {
+			pending++
+			continue
+		}
+		failure := report.failureFor(node, kind, obj.GetName())
+		if failure == nil {
+			applied++
+			continue
+		}
+		res.NodeFailures = append(res.NodeFailures, *failure)
+	}
+	slices.SortFunc(res.NodeFailures, func(a, b v1alpha1.NodeFailure) int {
+		return strings.Compare(a.Node, b.Node)
+	})
+	failed := int32(len(res.NodeFailures))
+	res.AppliedNodes = &applied
+	res.FailedNodes = &failed
+
+	switch {
+	case failed > 0:
+		setConditions(&res, obj.GetGeneration(), metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed,
+			failedMessage(res.NodeFailures, len(selectedNodes)))
+	case pending > 0:
+		setConditions(&res, obj.GetGeneration(), metav1.ConditionFalse, v1alpha1.ConditionReasonConfigPending,
+			fmt.Sprintf("Waiting for %d of %d nodes to report their status", pending, len(selectedNodes)))
+	default:
+		setConditions(&res, obj.GetGeneration(), metav1.ConditionTrue, v1alpha1.ConditionReasonConfigSuccessful,
+			fmt.Sprintf("Applied successfully on %d nodes", applied))
+	}
+	return res
+}
+
+func failedMessage(failures []v1alpha1.NodeFailure, selected int) string {
+	nodes := make([]string, 0, maxNodesInMessage)
+	for _, f := range failures[:min(len(failures), maxNodesInMessage)] {
+		nodes = append(nodes, f.Node)
+	}
+	msg := fmt.Sprintf("Failed on %d of %d nodes: %s", len(failures), selected, strings.Join(nodes, ", "))
+	if len(failures) > maxNodesInMessage {
+		msg += fmt.Sprintf(" and %d more, see status.nodeFailures for details", len(failures)-maxNodesInMessage)
+	}
+	return msg
+}
+
+// setConditions sets the Ready condition to the given status, and Degraded
+// only when the resource failed on at least one node.
+func setConditions(s *v1alpha1.ResourceStatus, generation int64, ready metav1.ConditionStatus, reason, message string) {
+	degraded := metav1.ConditionFalse
+	if reason == v1alpha1.ConditionReasonConfigFailed {
+		degraded = metav1.ConditionTrue
+	}
+	apimeta.SetStatusCondition(&s.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReady,
+		Status:             ready,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	apimeta.SetStatusCondition(&s.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeDegraded,
+		Status:             degraded,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func failureKey(kind v1alpha1.FailedResourceKind, name string) string {
+	return string(kind) + "/" + name
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length-3] + "..."
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+func nodeStatus(name string, ready metav1.ConditionStatus, reason, message string,
+	failures ...v1alpha1.FailedResource) v1alpha1.RouterNodeConfigurationStatus {
+	return v1alpha1.RouterNodeConfigurationStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: &v1alpha1.RouterNodeConfigurationStatusStatus{
+			FailedResources: failures,
+			Conditions: []metav1.Condition{
+				{Type: v1alpha1.ConditionTypeReady, Status: ready, Reason: reason, Message: message},
+			},
+		},
+	}
+}
+
+func TestAggregateStatus(t *testing.T) {
+	badVNI := v1alpha1.FailedResource{
+		Kind: openpeerrors.KindL3VNI, Name: "red",
+		Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "invalid vrf",
+	}
+	badUnderlay := v1alpha1.FailedResource{
+		Kind: openpeerrors.KindUnderlay, Name: "underlay",
+		Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "nic not found",
+	}
+
+	tests := []struct {
+		name             string
+		kind             v1alpha1.FailedResourceKind
+		resource         string
+		selected         []string
+		statuses         []v1alpha1.RouterNodeConfigurationStatus
+		expectedReady    metav1.ConditionStatus
+		expectedDegraded metav1.ConditionStatus
+		expectedReason   string
+		expectedMessage  string
+		expectedApplied  int32
+		expectedFailures []v1alpha1.NodeFailure
+	}{
+		{
+			name:     "applied on all nodes",
+			kind:     openpeerrors.KindL3VNI,
+			resource: "red",
+			selected: []string{"node-a", "node-b"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("node-a", metav1.ConditionTrue, v1alpha1.ConditionReasonConfigSuccessful, "ok"),
+				nodeStatus("node-b", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "some failed",
+					v1alpha1.FailedResource{Kind: openpeerrors.KindL3VNI, Name: "blue",
+						Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "invalid vrf"}),
+			},
+			expectedReady:    metav1.ConditionTrue,
+			expectedDegraded: metav1.ConditionFalse,
+			expectedReason:   v1alpha1.ConditionReasonConfigSuccessful,
+			expectedMessage:  "Applied successfully on 2 nodes",
+			expectedApplied:  2,
+		},
+		{
+			name:     "failed on one node",
+			kind:     openpeerrors.KindL3VNI,
+			resource: "red",
+			selected: []string{"node-a", "node-b"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("node-b", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "some failed", badVNI),
+				nodeStatus("node-a", metav1.ConditionTrue, v1alpha1.ConditionReasonConfigSuccessful, "ok"),
+			},
+			expectedReady:    metav1.ConditionFalse,
+			expectedDegraded: metav1.ConditionTrue,
+			expectedReason:   v1alpha1.ConditionReasonConfigFailed,
+			expectedMessage:  "Failed on 1 of 2 nodes: node-b",
+			expectedApplied:  1,
+			expectedFailures: []v1alpha1.NodeFailure{
+				{Node: "node-b", Reason: string(v1alpha1.FailedResourceReasonValidationFailed), Message: "invalid vrf"},
+			},
+		},
+		{
+			name:     "failed underlay fails the overlays as dependency",
+			kind:     openpeerrors.KindL3VNI,
+			resource: "red",
+			selected: []string{"node-a"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("node-a", metav1.ConditionFalse, v1alpha1.ConditionReasonUnderlayFailed, "underlay failed", badUnderlay),
+			},
+			expectedReady:    metav1.ConditionFalse,
+			expectedDegraded: metav1.ConditionTrue,
+			expectedReason:   v1alpha1.ConditionReasonConfigFailed,
+			expectedMessage:  "Failed on 1 of 1 nodes: node-a",
+			expectedFailures: []v1alpha1.NodeFailure{
+				{Node: "node-a", Reason: string(v1alpha1.FailedResourceReasonDependencyFailed), Message: "the underlay failed on the node"},
+			},
+		},
+		{
+			name:     "failed underlay",
+			kind:     openpeerrors.KindUnderlay,
+			resource: "underlay",
+			selected: []string{"node-a"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("node-a", metav1.ConditionFalse, v1alpha1.ConditionReasonUnderlayFailed, "underlay failed", badUnderlay),
+			},
+			expectedReady:    metav1.ConditionFalse,
+			expectedDegraded: metav1.ConditionTrue,
+			expectedReason:   v1alpha1.ConditionReasonConfigFailed,
+			expectedMessage:  "Failed on 1 of 1 nodes: node-a",
+			expectedFailures: []v1alpha1.NodeFailure{
+				{Node: "node-a", Reason: string(v1alpha1.FailedResourceReasonValidationFailed), Message: "nic not found"},
+			},
+		},
+		{
+			name:     "node wide failure",
+			kind:     openpeerrors.KindL2VNI,
+			resource: "l2",
+			selected: []string{"node-a"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("node-a", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "failed to reload frr"),
+			},
+			expectedReady:    metav1.ConditionFalse,
+			expectedDegraded: metav1.ConditionTrue,
+			expectedReason:   v1alpha1.ConditionReasonConfigFailed,
+			expectedMessage:  "Failed on 1 of 1 nodes: node-a",
+			expectedFailures: []v1alpha1.NodeFailure{
+				{Node: "node-a", Reason: v1alpha1.ConditionReasonConfigFailed, Message: "failed to reload frr"},
+			},
+		},
+		{
+			name:     "node not reporting yet",
+			kind:     openpeerrors.KindL3VNI,
+			resource: "red",
+			selected: []string{"node-a", "node-b"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("node-a", metav1.ConditionTrue, v1alpha1.ConditionReasonConfigSuccessful, "ok"),
+			},
+			expectedReady:    metav1.ConditionFalse,
+			expectedDegraded: metav1.ConditionFalse,
+			expectedReason:   v1alpha1.ConditionReasonConfigPending,
+			expectedMessage:  "Waiting for 1 of 2 nodes to report their status",
+			expectedApplied:  1,
+		},
+		{
+			name:     "more failing nodes than listed in the message",
+			kind:     openpeerrors.KindL3VNI,
+			resource: "red",
+			selected: []string{"n1", "n2", "n3", "n4", "n5", "n6", "n7"},
+			statuses: []v1alpha1.RouterNodeConfigurationStatus{
+				nodeStatus("n1", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "x", badVNI),
+				nodeStatus("n2", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "x", badVNI),
+				nodeStatus("n3", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "x", badVNI),
+				nodeStatus("n4", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "x", badVNI),
+				nodeStatus("n5", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "x", badVNI),
+				nodeStatus("n6", metav1.ConditionFalse, v1alpha1.ConditionReasonConfigFailed, "x", badVNI),
+				nodeStatus("n7", metav1.ConditionTrue, v1alpha1.ConditionReasonConfigSuccessful, "ok"),
+			},
+			expectedReady:    metav1.ConditionFalse,
+			expectedDegraded: metav1.ConditionTrue,
+			expectedReason:   v1alpha1.ConditionReasonConfigFailed,
+			expectedMessage:  "Failed on 6 of 7 nodes: n1, n2, n3, n4, n5 and 1 more, see status.nodeFailures for details",
+			expectedApplied:  1,
+			expectedFailures: []v1alpha1.NodeFailure{
+				{Node: "n1", Reason: "ValidationFailed", Message: "invalid vrf"},
+				{Node: "n2", Reason: "ValidationFailed", Message: "invalid vrf"},
+				{Node: "n3", Reason: "ValidationFailed", Message: "invalid vrf"},
+				{Node: "n4", Reason: "ValidationFailed", Message: "invalid vrf"},
+				{Node: "n5", Reason: "ValidationFailed", Message: "invalid vrf"},
+				{Node: "n6", Reason: "ValidationFailed", Message: "invalid vrf"},
+			},
+		},
+		{
+			name:             "no selected nodes",
+			kind:             openpeerrors.KindL3Passthrough,
+			resource:         "passthrough",
+			expectedReady:    metav1.ConditionTrue,
+			expectedDegraded: metav1.ConditionFalse,
+			expectedReason:   v1alpha1.ConditionReasonConfigSuccessful,
+			expectedMessage:  "Applied successfully on 0 nodes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Name: tc.resource, Generation: 3}
+			got := aggregateStatus(tc.kind, obj, tc.selected, nodeReports(tc.statuses), nil)
+
+			ready := apimeta.FindStatusCondition(got.Conditions, v1alpha1.ConditionTypeReady)
+			if ready == nil {
+				t.Fatal("expected Ready condition")
+			}
+			if ready.Status != tc.expectedReady || ready.Reason != tc.expectedReason || ready.Message != tc.expectedMessage {
+				t.Errorf("unexpected Ready condition: got %s/%s/%q, expected %s/%s/%q",
+					ready.Status, ready.Reason, ready.Message, tc.expectedReady, tc.expectedReason, tc.expectedMessage)
+			}
+			if ready.ObservedGeneration != 3 {
+				t.Errorf("expected observed generation 3, got %d", ready.ObservedGeneration)
+			}
+			degraded := apimeta.FindStatusCondition(got.Conditions, v1alpha1.ConditionTypeDegraded)
+			if degraded == nil || degraded.Status != tc.expectedDegraded {
+				t.Errorf("expected Degraded=%s, got %v", tc.expectedDegraded, degraded)
+			}
+			if *got.AppliedNodes != tc.expectedApplied {
+				t.Errorf("expected %d applied nodes, got %d", tc.expectedApplied, *got.AppliedNodes)
+			}
+			if int(*got.FailedNodes) != len(tc.expectedFailures) {
+				t.Errorf("expected %d failed nodes, got %d", len(tc.expectedFailures), *got.FailedNodes)
+			}
+			if diff := cmp.Diff(tc.expectedFailures, got.NodeFailures); diff != "" {
+				t.Errorf("unexpected node failures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAggregateStatusPreservesTransitionTime(t *testing.T) {
+	statuses := []v1alpha1.RouterNodeConfigurationStatus{
+		nodeStatus("node-a", metav1.ConditionTrue, v1alpha1.ConditionReasonConfigSuccessful, "ok"),
+	}
+	obj := &metav1.ObjectMeta{Name: "red"}
+
+	first := aggregateStatus(openpeerrors.KindL3VNI, obj, []string{"node-a"}, nodeReports(statuses), nil)
+	past := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	for i := range first.Conditions {
+		first.Conditions[i].LastTransitionTime = past
+	}
+
+	second := aggregateStatus(openpeerrors.KindL3VNI, obj, []string{"node-a"}, nodeReports(statuses), &first)
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("expected status to be unchanged (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+	"github.com/openperouter/openperouter/internal/filter"
+)
+
+// aggregateRequest is the single request all the events are mapped to, as
+// every reconciliation recomputes the status of all the resources.
+var aggregateRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "resourcestatus"}}
+
+// ResourceStatusReconciler folds the failures reported by each node in its
+// RouterNodeConfigurationStatus into the status of the owning resources.
+type ResourceStatusReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Namespace is the namespace where the RouterNodeConfigurationStatus
+	// resources are created.
+	Namespace string
+	Logger    *slog.Logger
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=routernodeconfigurationstatuses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=l2vnis/status;l3passthroughs/status;l3vnis/status;l3vpns/status;underlays/status,verbs=get;update;patch
+
+func (r *ResourceStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.With("controller", "ResourceStatus", "request", req.String())
+	logger.Info("start reconcile")
+	defer logger.Info("end reconcile")
+
+	var nodes v1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		logger.Error("failed to list nodes", "error", err)
+		return ctrl.Result{}, err
+	}
+
+	var statuses v1alpha1.RouterNodeConfigurationStatusList
+	if err := r.List(ctx, &statuses, client.InNamespace(r.Namespace)); err != nil {
+		logger.Error("failed to list node statuses", "error", err)
+		return ctrl.Result{}, err
+	}
+
+	u := statusUpdater{
+		Client:  r.Client,
+		nodes:   nodes.Items,
+		reports: nodeReports(statuses.Items),
+	}
+	err := errors.Join(
+		r.updateUnderlays(ctx, u),
+		r.updateL3VNIs(ctx, u),
+		r.updateL2VNIs(ctx, u),
+		r.updateL3VPNs(ctx, u),
+		r.updateL3Passthroughs(ctx, u),
+	)
+	if err != nil {
+		logger.Error("failed to update resource statuses", "error", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *ResourceStatusReconciler) updateUnderlays(ctx context.Context, u statusUpdater) error {
+	var list v1alpha1.UnderlayList
+	if err := r.List(ctx, &list); err != nil {
+		return fmt.Errorf("failed to list underlays: %w", err)
+	}
+	return updateStatuses(ctx, u, openpeerrors.KindUnderlay, list.Items,
+		func(o *v1alpha1.Underlay) *metav1.LabelSelector { return o.Spec.NodeSelector },
+		func(o *v1alpha1.Underlay) *v1alpha1.ResourceStatus {
+			if o.Status == nil {
+				return nil
+			}
+			return &o.Status.ResourceStatus
+		},
+		func(o *v1alpha1.Underlay, s v1alpha1.ResourceStatus) {
+			o.Status = &v1alpha1.UnderlayStatus{ResourceStatus: s}
+		})
+}
+
+func (r *ResourceStatusReconciler) updateL3VNIs(ctx context.Context, u statusUpdater) error {
+	var list v1alpha1.L3VNIList
+	if err := r.List(ctx, &list); err != nil {
+		return fmt.Errorf("failed to list l3vnis: %w", err)
+	}
+	return updateStatuses(ctx, u, openpeerrors.KindL3VNI, list.Items,
+		func(o *v1alpha1.L3VNI) *metav1.LabelSelector { return o.Spec.NodeSelector },
+		func(o *v1alpha1.L3VNI) *v1alpha1.ResourceStatus {
+			if o.Status == nil {
+				return nil
+			}
+			return &o.Status.ResourceStatus
+		},
+		func(o *v1alpha1.L3VNI, s v1alpha1.ResourceStatus) {
+			o.Status = &v1alpha1.L3VNIStatus{ResourceStatus: s}
+		})
+}
+
+func (r *ResourceStatusReconciler) updateL2VNIs(ctx context.Context, u statusUpdater) error {
+	var list v1alpha1.L2VNIList
+	if err := r.List(ctx, &list); err != nil {
+		return fmt.Errorf("failed to list l2vnis: %w", err)
+	}
+	return updateStatuses(ctx, u, openpeerrors.KindL2VNI, list.Items,
+		func(o *v1alpha1.L2VNI) *metav1.LabelSelector { return o.Spec.NodeSelector },
+		func(o *v1alpha1.L2VNI) *v1alpha1.ResourceStatus {
+			if o.Status == nil {
+				return nil
+			}
+			return &o.Status.ResourceStatus
+		},
+		func(o *v1alpha1.L2VNI, s v1alpha1.ResourceStatus) {
+			o.Status = &v1alpha1.L2VNIStatus{ResourceStatus: s}
+		})
+}
+
+func (r *ResourceStatusReconciler) updateL3VPNs(ctx context.Context, u statusUpdater) error {
+	var list v1alpha1.L3VPNList
+	if err := r.List(ctx, &list); err != nil {
+		return fmt.Errorf("failed to list l3vpns: %w", err)
+	}
+	return updateStatuses(ctx, u, openpeerrors.KindL3VPN, list.Items,
+		func(o *v1alpha1.L3VPN) *metav1.LabelSelector { return o.Spec.NodeSelector },
+		func(o *v1alpha1.L3VPN) *v1alpha1.ResourceStatus {
+			if o.Status == nil {
+				return nil
+			}
+			return &o.Status.ResourceStatus
+		},
+		func(o *v1alpha1.L3VPN, s v1alpha1.ResourceStatus) {
+			o.Status = &v1alpha1.L3VPNStatus{ResourceStatus: s}
+		})
+}
+
+func (r *ResourceStatusReconciler) updateL3Passthroughs(ctx context.Context, u statusUpdater) error {
+	var list v1alpha1.L3PassthroughList
+	if err := r.List(ctx, &list); err != nil {
+		return fmt.Errorf("failed to list l3passthroughs: %w", err)
+	}
+	return updateStatuses(ctx, u, openpeerrors.KindL3Passthrough, list.Items,
+		func(o *v1alpha1.L3Passthrough) *metav1.LabelSelector { return o.Spec.NodeSelector },
+		func(o *v1alpha1.L3Passthrough) *v1alpha1.ResourceStatus {
+			if o.Status == nil {
+				return nil
+			}
+			return &o.Status.ResourceStatus
+		},
+		func(o *v1alpha1.L3Passthrough, s v1alpha1.ResourceStatus) {
+			o.Status = &v1alpha1.L3PassthroughStatus{ResourceStatus: s}
+		})
+}
+
+// statusUpdater holds what is needed to compute the status of a resource.
+type statusUpdater struct {
+	client.Client
+	nodes   []v1.Node
+	reports map[string]nodeReport
+}
+
+// objectPtr constrains PT to be a pointer to T implementing client.Object,
+// so that the items of a typed list can be patched in place.
+type objectPtr[T any] interface {
+	*T
+	client.Object
+}
+
+func updateStatuses[T any, PT objectPtr[T]](ctx context.Context, u statusUpdater, kind v1alpha1.FailedResourceKind, items []T,
+	nodeSelector func(PT) *metav1.LabelSelector,
+	currentStatus func(PT) *v1alpha1.ResourceStatus,
+	setStatus func(PT, v1alpha1.ResourceStatus)) error {
+	var errs []error
+	for i := range items {
+		obj := PT(&items[i])
+
+		selected, err := filter.NodesForSelector(u.nodes, nodeSelector(obj))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to select nodes for %s %s: %w", kind, obj.GetName(), err))
+			continue
+		}
+		nodeNames := make([]string, 0, len(selected))
+		for _, n := range selected {
+			nodeNames = append(nodeNames, n.Name)
+		}
+
+		current := currentStatus(obj)
+		newStatus := aggregateStatus(kind, obj, nodeNames, u.reports, current)
+		if equality.Semantic.DeepEqual(current, &newStatus) {
+			continue
+		}
+
+		updated := obj.DeepCopyObject().(PT)
+		setStatus(updated, newStatus)
+		if err := u.Status().Patch(ctx, updated, client.MergeFrom(obj)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to patch status of %s %s: %w", kind, obj.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *ResourceStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueAggregate := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{aggregateRequest}
+	})
+
+	inNamespace := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetNamespace() == r.Namespace
+	})
+
+	nodeLabelsChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	}
+
+	// The status of the resources is written by this controller, so only
+	// spec changes (which bump the generation) are relevant.
+	specChanged := predicate.GenerationChangedPredicate{}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("resourcestatuscontroller").
+		Watches(&v1alpha1.RouterNodeConfigurationStatus{}, enqueueAggregate, builder.WithPredicates(inNamespace)).
+		Watches(&v1.Node{}, enqueueAggregate, builder.WithPredicates(nodeLabelsChanged)).
+		Watches(&v1alpha1.Underlay{}, enqueueAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L3VNI{}, enqueueAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L2VNI{}, enqueueAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L3VPN{}, enqueueAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L3Passthrough{}, enqueueAggregate, builder.WithPredicates(specChanged)).
+		Complete(r)
+}
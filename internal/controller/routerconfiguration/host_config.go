@@ -7,11 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/conversion"
+	"github.com/openperouter/openperouter/internal/dhcprelay"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
 	"github.com/openperouter/openperouter/internal/hostnetwork/bridgerefresh"
@@ -28,6 +32,9 @@ type interfacesConfiguration struct {
 // It supports the full API surface.
 type KernelDatapathConfigurator struct {
 	conversion.KernelDatapathConfigValidator
+	// DHCPRelays runs the DHCP relays of the L2VNIs. When nil, the DHCP
+	// relays are not run.
+	DHCPRelays *dhcprelay.Supervisor
 }
 
 func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config interfacesConfiguration) error { // nolint:gocognit
@@ -36,11 +43,13 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 		return fmt.Errorf("failed to check if target namespace %s has underlay: %w", config.targetNamespace, err)
 	}
 	if len(currentUnderlayIfaces) > 0 && len(config.Underlays) == 0 {
+		k.ensureDHCPRelays(ctx, nil)
 		restoreUnderlay(ctx, config.targetNamespace, currentUnderlayIfaces)
 		return nil
 	}
 
 	if len(config.Underlays) == 0 {
+		k.ensureDHCPRelays(ctx, nil)
 		return nil // nothing to do
 	}
 
@@ -78,8 +87,16 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 
 	slog.InfoContext(ctx, "setting up underlay")
 
-	if err := hostnetwork.SetupUnderlay(ctx, hostConfig.Underlay); err != nil {
-		return fmt.Errorf("failed to setup underlay: %w", err)
+	underlays := append([]hostnetwork.UnderlayParams{hostConfig.Underlay}, hostConfig.AdditionalUnderlays...)
+	for _, underlay := range underlays {
+		if err := hostnetwork.SetupUnderlay(ctx, underlay); err != nil {
+			return fmt.Errorf("failed to setup underlay %s: %w", underlay.Name, err)
+		}
+	}
+
+	slog.InfoContext(ctx, "removing deleted underlays")
+	if err := hostnetwork.RemoveNonConfiguredUnderlays(ctx, config.targetNamespace, underlays); err != nil {
+		return fmt.Errorf("failed to remove deleted underlays: %w", err)
 	}
 
 	var resourceErrors []error
@@ -147,6 +164,28 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 		configuredL2VNIs = append(configuredL2VNIs, vni)
 	}
 
+	// The relays listen on the gateway interfaces, so they are run only for
+	// the L2VNIs configured successfully.
+	configuredL2VNINames := sets.New[string]()
+	for _, vni := range configuredL2VNIs {
+		configuredL2VNINames.Insert(vni.Name)
+	}
+	var dhcpRelays []dhcprelay.Relay
+	for _, relay := range hostConfig.DHCPRelays {
+		if configuredL2VNINames.Has(relay.Name) {
+			dhcpRelays = append(dhcpRelays, relay)
+		}
+	}
+	relayFailures := k.ensureDHCPRelays(ctx, dhcpRelays)
+	for _, name := range slices.Sorted(maps.Keys(relayFailures)) {
+		resourceErrors = append(resourceErrors, &openpeerrors.ResourceError{
+			Obj: v1alpha1.FailedResource{
+				Kind: openpeerrors.KindL2VNI, Name: name, Reason: reason,
+				Message: fmt.Sprintf("dhcp relay failed: %v", relayFailures[name]),
+			},
+		})
+	}
+
 	slog.InfoContext(ctx, "setting up passthrough")
 	if hostConfig.L3Passthrough != nil {
 		if err := hostnetwork.SetupPassthrough(ctx, *hostConfig.L3Passthrough); err != nil {
@@ -178,6 +217,11 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 	}
 	bridgerefresh.StopForRemovedVNIs(configuredL2VNIs)
 
+	slog.InfoContext(ctx, "removing deleted trunk vlans")
+	if err := hostnetwork.RemoveNonConfiguredTrunkVLANs(config.targetNamespace, configuredL2VNIs); err != nil {
+		return fmt.Errorf("failed to remove deleted trunk vlans: %w", err)
+	}
+
 	slog.InfoContext(ctx, "removing deleted l3vpns")
 	if err := hostnetwork.RemoveNonConfiguredL3VPNs(config.targetNamespace,
 		configuredL3VPNs); err != nil {
@@ -198,6 +242,19 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 	return errors.Join(resourceErrors...)
 }
 
+// ensureDHCPRelays runs the given DHCP relays, stopping the others, and
+// returns the failures of the relays by L2VNI name.
+func (k *KernelDatapathConfigurator) ensureDHCPRelays(ctx context.Context, relays []dhcprelay.Relay) map[string]error {
+	if k.DHCPRelays == nil {
+		if len(relays) > 0 {
+			slog.WarnContext(ctx, "dhcp relays not supported, skipping", "relays", len(relays))
+		}
+		return nil
+	}
+	slog.InfoContext(ctx, "setting up dhcp relays", "relays", len(relays))
+	return k.DHCPRelays.Ensure(relays)
+}
+
 func restoreUnderlay(
 	ctx context.Context,
 	targetNamespace string,
@@ -226,6 +283,7 @@ func restoreUnderlay(
 
 func ensureSysctlsForConfig(ctx context.Context, config interfacesConfiguration) error {
 	slog.InfoContext(ctx, "ensuring sysctls")
+	primary := conversion.PrimaryUnderlay(config.Underlays)
 	sysctls := []sysctl.Sysctl{
 		sysctl.IPv4Forwarding(),
 		sysctl.IPv6Forwarding(),
@@ -233,8 +291,10 @@ func ensureSysctlsForConfig(ctx context.Context, config interfacesConfiguration)
 		sysctl.ArpAcceptDefault(),
 		sysctl.AcceptUntrackedNADefault(),
 		sysctl.AcceptUntrackedNAAll(),
+		sysctl.IPv4MultipathHashPolicy(multipathHashPolicy(primary)),
+		sysctl.IPv6MultipathHashPolicy(multipathHashPolicy(primary)),
 	}
-	if isSRV6(config.Underlays[0]) {
+	if isSRV6(primary) {
 		sysctls = append(sysctls,
 			sysctl.Seg6MakeFlowLabel(),
 			sysctl.EnableSeg6All(),
@@ -253,6 +313,24 @@ func isSRV6(underlay v1alpha1.Underlay) bool {
 	return underlay.Spec.SRV6 != nil
 }
 
+// multipathHashPolicy returns the value of the fib_multipath_hash_policy
+// sysctls for the underlay. The kernel default (layer 3) is enforced when
+// no policy is set, so that removing it reverts a previous one.
+func multipathHashPolicy(underlay v1alpha1.Underlay) string {
+	policy := v1alpha1.MultipathHashPolicyLayer3
+	if underlay.Spec.Multipath != nil {
+		policy = ptr.Deref(underlay.Spec.Multipath.HashPolicy, v1alpha1.MultipathHashPolicyLayer3)
+	}
+	switch policy {
+	case v1alpha1.MultipathHashPolicyLayer4:
+		return "1"
+	case v1alpha1.MultipathHashPolicyLayer3Inner:
+		return "2"
+	default:
+		return "0"
+	}
+}
+
 // areAllUnderlayInterfacesToBeRemoved tells whether every underlay interface
 // currently in the namespace is being replaced, considering both the host
 // network devices and the CNI-provisioned interfaces.
@@ -266,12 +344,16 @@ func areAllUnderlayInterfacesToBeRemoved(
 		return false, fmt.Errorf("failed to list existing underlay interfaces: %w", err)
 	}
 
-	toRemove := hostnetwork.UnderlayInterfacesToRemove(existing, hostConfig.Underlay.UnderlayInterfaces)
+	requested := slices.Clone(hostConfig.Underlay.UnderlayInterfaces)
+	for _, underlay := range hostConfig.AdditionalUnderlays {
+		requested = append(requested, underlay.UnderlayInterfaces...)
+	}
+	toRemove := hostnetwork.UnderlayInterfacesToRemove(existing, requested)
 	allRemoved := len(toRemove) > 0 && len(toRemove) == len(existing)
 	if allRemoved {
 		slog.InfoContext(ctx, "all underlay interfaces removed, cleaning up VNIs before interface swap",
 			"removed", toRemove,
-			"requested", hostConfig.Underlay.UnderlayInterfaces,
+			"requested", requested,
 		)
 	}
 	return allRemoved, nil
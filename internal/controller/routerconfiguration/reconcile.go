@@ -45,6 +45,9 @@ func Reconcile(ctx context.Context, apiConfig conversion.APIConfigData, nodeInde
 	validL3VNIs, err = conversion.FilterValidL3VNIs(apiConfig.L3VNIs)
 	resourceErrors = append(resourceErrors, err)
 
+	validL3VNIs, err = conversion.FilterValidUnderlayL3VNIs(apiConfig.Underlays, validL3VNIs)
+	resourceErrors = append(resourceErrors, err)
+
 	var validL3VPNs []v1alpha1.L3VPN
 	validL3VPNs, err = conversion.FilterValidL3VPNs(apiConfig.L3VPNs)
 	resourceErrors = append(resourceErrors, err)
@@ -81,20 +84,45 @@ func Reconcile(ctx context.Context, apiConfig conversion.APIConfigData, nodeInde
 	validL2VNIs, err = conversion.FilterUniqueL2VNIs(validL2VNIs, vnis)
 	resourceErrors = append(resourceErrors, err)
 
+	validL2VNIs, err = conversion.FilterValidUnderlayL2VNIs(apiConfig.Underlays, validL3VNIs, validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
+	validL2VNIs, err = conversion.FilterValidVLANAwareL2VNIs(apiConfig.Underlays, validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
+	validL2VNIs, err = conversion.FilterValidVLANTrunkL2VNIs(validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
+	validL2VNIs, err = conversion.FilterValidMulticastL2VNIs(apiConfig.Underlays, validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
+	validL2VNIs, err = conversion.FilterValidIRBModeL2VNIs(validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
 	validL3VNIs, err = conversion.FilterUniqueVRFsForL3VNIs(validL3VNIs)
 	resourceErrors = append(resourceErrors, err)
 
 	validL3VPNs, err = conversion.FilterUniqueVRFsForL3VPNs(validL3VPNs)
 	resourceErrors = append(resourceErrors, err)
 
+	validL3VNIs, err = conversion.FilterValidVRFImports(validL3VNIs)
+	resourceErrors = append(resourceErrors, err)
+
+	var validPassthrough []v1alpha1.L3Passthrough
+	validPassthrough, err = conversion.FilterValidPassthroughs(apiConfig.L3Passthrough)
+	resourceErrors = append(resourceErrors, err)
+
+	validL3VNIs, validL3VPNs, validPassthrough, err = conversion.FilterValidHostSessionPasswords(
+		validL3VNIs, validL3VPNs, validPassthrough, apiConfig.PasswordSecrets)
+	resourceErrors = append(resourceErrors, err)
+
 	validL2VNIs, err = filterL2VNIsWithInvalidRoutingDomain(validL2VNIs, validL3VNIs, validL3VPNs)
 	resourceErrors = append(resourceErrors, err)
 
 	validL3VNIs, validL3VPNs, validL2VNIs, err = conversion.FilterValidVRFSubnets(validL3VNIs, validL3VPNs, validL2VNIs)
 	resourceErrors = append(resourceErrors, err)
 
-	var validPassthrough []v1alpha1.L3Passthrough
-	validPassthrough, err = conversion.FilterValidPassthroughs(apiConfig.L3Passthrough)
+	validL3VNIs, validL3VPNs, err = conversion.FilterValidAggregates(validL3VNIs, validL3VPNs, validL2VNIs)
 	resourceErrors = append(resourceErrors, err)
 
 	if err := conversion.ValidateHostSessions(validL3VNIs, validPassthrough); err != nil {
@@ -102,12 +130,13 @@ func Reconcile(ctx context.Context, apiConfig conversion.APIConfigData, nodeInde
 	}
 
 	config := conversion.APIConfigData{
-		Underlays:     apiConfig.Underlays,
-		L3VNIs:        validL3VNIs,
-		L3VPNs:        validL3VPNs,
-		L2VNIs:        validL2VNIs,
-		L3Passthrough: validPassthrough,
-		RawFRRConfigs: apiConfig.RawFRRConfigs,
+		Underlays:       apiConfig.Underlays,
+		L3VNIs:          validL3VNIs,
+		L3VPNs:          validL3VPNs,
+		L2VNIs:          validL2VNIs,
+		L3Passthrough:   validPassthrough,
+		RawFRRConfigs:   apiConfig.RawFRRConfigs,
+		PasswordSecrets: apiConfig.PasswordSecrets,
 	}
 
 	// The FRR configuration must be applied before the datapath creates the kernel
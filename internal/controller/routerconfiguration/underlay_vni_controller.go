@@ -229,6 +229,16 @@ func (r *PERouterReconciler) getConfigFromAPI(ctx context.Context, logger *slog.
 		return conversion.APIConfigData{}, err
 	}
 
+	var secrets v1.SecretList
+	if err := r.List(ctx, &secrets, client.InNamespace(r.MyNamespace)); err != nil {
+		slog.Error("failed to list secrets", "error", err)
+		return conversion.APIConfigData{}, err
+	}
+	passwordSecrets := map[string]v1.Secret{}
+	for _, s := range secrets.Items {
+		passwordSecrets[s.Name] = s
+	}
+
 	node := &v1.Node{}
 	if err := r.Get(ctx, client.ObjectKey{Name: r.MyNode}, node); err != nil {
 		slog.Error("failed to get node", "node", r.MyNode, "error", err)
@@ -279,12 +289,13 @@ func (r *PERouterReconciler) getConfigFromAPI(ctx context.Context, logger *slog.
 	logger.Debug("using config", "l3vnis", l3vnis.Items, "l2vnis", l2vnis.Items, "underlays", underlays.Items, "l3passthrough", l3passthrough.Items, "rawfrrconfigs", rawFRRConfigs.Items)
 
 	apiConfig := conversion.APIConfigData{
-		Underlays:     filteredUnderlays,
-		L3VNIs:        filteredL3VNIs,
-		L2VNIs:        filteredL2VNIs,
-		L3VPNs:        filteredL3VPNs,
-		L3Passthrough: filteredL3Passthrough,
-		RawFRRConfigs: filteredRawFRRConfigs,
+		Underlays:       filteredUnderlays,
+		L3VNIs:          filteredL3VNIs,
+		L2VNIs:          filteredL2VNIs,
+		L3VPNs:          filteredL3VPNs,
+		L3Passthrough:   filteredL3Passthrough,
+		RawFRRConfigs:   filteredRawFRRConfigs,
+		PasswordSecrets: passwordSecrets,
 	}
 
 	return apiConfig, nil
@@ -342,6 +353,9 @@ func (r *PERouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					return true
 				}
 				return false
+			case *v1alpha1.Underlay, *v1alpha1.L3VNI, *v1alpha1.L2VNI, *v1alpha1.L3VPN, *v1alpha1.L3Passthrough:
+				// status is aggregated by the nodemarker, only spec changes matter here
+				return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
 			}
 			return true
 		},
@@ -360,6 +374,7 @@ func (r *PERouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&v1alpha1.L3VPN{}, &handler.EnqueueRequestForObject{}).
 		Watches(&v1alpha1.L3Passthrough{}, &handler.EnqueueRequestForObject{}).
 		Watches(&v1alpha1.RawFRRConfig{}, &handler.EnqueueRequestForObject{}).
+		Watches(&v1.Secret{}, &handler.EnqueueRequestForObject{}).
 		Watches(&v1alpha1.RouterNodeConfigurationStatus{}, &handler.EnqueueRequestForObject{}).
 		WithEventFilter(filterNonRouterPods).
 		WithEventFilter(filterLocalNodeStatus).
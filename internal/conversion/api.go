@@ -4,9 +4,12 @@ package conversion
 
 import (
 	"errors"
+	"maps"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/dhcprelay"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type APIConfigData struct {
@@ -16,6 +19,9 @@ type APIConfigData struct {
 	L3VPNs        []v1alpha1.L3VPN
 	L3Passthrough []v1alpha1.L3Passthrough
 	RawFRRConfigs []v1alpha1.RawFRRConfig
+	// PasswordSecrets are the secrets the BGP session passwords are read
+	// from, keyed by name.
+	PasswordSecrets map[string]corev1.Secret
 }
 
 type HostConfigData struct {
@@ -24,6 +30,11 @@ type HostConfigData struct {
 	L2VNIs        []hostnetwork.L2VNIParams
 	L3VPNs        []hostnetwork.L3VPNParams
 	L3Passthrough *hostnetwork.PassthroughParams
+	DHCPRelays    []dhcprelay.Relay
+
+	// AdditionalUnderlays are the underlays of the node other than the
+	// primary one, set up alongside Underlay.
+	AdditionalUnderlays []hostnetwork.UnderlayParams
 }
 
 func MergeAPIConfigs(configs ...APIConfigData) (APIConfigData, error) {
@@ -45,6 +56,12 @@ func MergeAPIConfigs(configs ...APIConfigData) (APIConfigData, error) {
 		merged.L3VPNs = append(merged.L3VPNs, config.L3VPNs...)
 		merged.L3Passthrough = append(merged.L3Passthrough, config.L3Passthrough...)
 		merged.RawFRRConfigs = append(merged.RawFRRConfigs, config.RawFRRConfigs...)
+		if len(config.PasswordSecrets) > 0 {
+			if merged.PasswordSecrets == nil {
+				merged.PasswordSecrets = map[string]corev1.Secret{}
+			}
+			maps.Copy(merged.PasswordSecrets, config.PasswordSecrets)
+		}
 	}
 
 	return merged, nil
@@ -62,10 +79,6 @@ func validateAPIConfigData(config APIConfigData) error {
 		return errors.New("cannot specify L3 VNI configuration and VPN configuration at the same time")
 	}
 
-	if len(config.Underlays) > 1 {
-		return errors.New("multiple underlays defined")
-	}
-
 	if len(config.Underlays) == 0 {
 		return NoUnderlaysError("no underlays provided")
 	}
@@ -3,7 +3,6 @@
 package conversion
 
 import (
-	"cmp"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -14,9 +13,11 @@ import (
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/hostnetwork"
 	"github.com/openperouter/openperouter/internal/ipam"
 	"github.com/openperouter/openperouter/internal/ipfamily"
 	"github.com/openperouter/openperouter/internal/networklayerprotocol"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -106,7 +107,8 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 		return frr.Config{}, err
 	}
 
-	underlay := config.Underlays[0]
+	underlays := sortUnderlays(config.Underlays)
+	underlay := underlays.primary()
 
 	routerID, err := routerIDFromUnderlay(underlay, nodeIndex)
 	if err != nil {
@@ -123,11 +125,17 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 		return frr.Config{}, err
 	}
 
-	underlayConfigISIS, err := underlayISISToFRR(underlay.Spec.ISIS, underlayInterfaces, nodeIndex)
+	underlayConfigISIS, err := underlayISISToFRR(underlay.Spec.ISIS, underlayInterfaces, nodeIndex, config.PasswordSecrets)
 	if err != nil {
 		return frr.Config{}, fmt.Errorf("failed to translate ISIS settings, err: %w", err)
 	}
 
+	underlayConfigOSPF, err := underlayOSPFToFRR(underlay.Spec.OSPF, underlayInterfaces, underlay.Spec.TunnelEndpoint,
+		config.PasswordSecrets)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate OSPF settings, err: %w", err)
+	}
+
 	underlayConfigSegmentRouting, err := underlaySegmentRoutingToFRR(underlay.Spec.SRV6, nodeIndex, tunnelEndpoint)
 	if err != nil {
 		return frr.Config{}, fmt.Errorf("failed to translate segment routing settings, err: %w", err)
@@ -136,8 +144,8 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 	neighbors, err := neighborsToFRR(
 		underlay.Spec.Neighbors,
 		underlayConfigSegmentRouting,
-		config.L2VNIs,
-		config.L3VNIs,
+		underlays.l2vnisOn(underlay, config.L2VNIs),
+		underlays.l3vnisOn(underlay, config.L3VNIs),
 		config.L3VPNs,
 		config.L3Passthrough,
 		underlay.Spec.TunnelEndpoint,
@@ -145,6 +153,13 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 	if err != nil {
 		return frr.Config{}, err
 	}
+	underlays.setExportRouteMaps(underlay, neighbors)
+
+	additionalNeighbors, additionalTunnelEndpoints, err := additionalUnderlaysToFRR(underlays, config, nodeIndex)
+	if err != nil {
+		return frr.Config{}, err
+	}
+	neighbors = append(neighbors, additionalNeighbors...)
 
 	underlayConfig := frr.UnderlayConfig{
 		MyASN:          underlay.Spec.ASN,
@@ -152,15 +167,28 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 		Neighbors:      neighbors,
 		TunnelEndpoint: tunnelEndpoint,
 		ISIS:           underlayConfigISIS,
+		OSPF:           underlayConfigOSPF,
 		SegmentRouting: underlayConfigSegmentRouting,
 		RouteReflector: routeReflectorToFRR(underlay.Spec.RouteReflector),
 		ListenLimit:    BGPListenLimit,
+
+		AdditionalTunnelEndpoints: additionalTunnelEndpoints,
 	}
 
+	underlayConfig.PIM, err = underlayPIMToFRR(underlay.Spec.EVPN, underlay.Spec.Interfaces)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate PIM settings, err: %w", err)
+	}
+	underlayConfig.DisableEVPNFlooding = underlayConfig.PIM != nil && slices.ContainsFunc(config.L2VNIs, isMulticastL2VNI)
+	underlayConfig.DupAddrDetection, underlayConfig.DupAddrClearToken = dupAddrDetectionToFRR(underlay.Spec.EVPN)
+
 	applyGracefulRestart(&underlayConfig, underlay.Spec.GracefulRestart)
+	if underlay.Spec.Multipath != nil {
+		underlayConfig.Multipath = multipathToFRR(&underlay.Spec.Multipath.MultipathConfig)
+	}
 
 	vrfMap := createVRFMap(config.L3VNIs, config.L3VPNs)
-	vrfsWithL2Gateway, err := vrfsWithL2Gateways(config.L2VNIs, vrfMap)
+	vrfsWithL2Gateway, err := vrfsWithL2Gateways(config.L2VNIs, vrfMap, nodeIndex)
 	if err != nil {
 		return frr.Config{}, err
 	}
@@ -168,15 +196,17 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 	vniConfigs, err := vniConfigsToFRR(
 		config.L3VNIs,
 		routerID,
-		underlay.Spec.ASN,
+		underlays,
 		nodeIndex,
 		vrfsWithL2Gateway,
+		asymmetricIRBVRFs(config.L2VNIs, vrfMap),
+		config.PasswordSecrets,
 	)
 	if err != nil {
 		return frr.Config{}, err
 	}
 
-	passthroughConfig, err := passthroughToFRR(config.L3Passthrough, nodeIndex)
+	passthroughConfig, err := passthroughToFRR(config.L3Passthrough, nodeIndex, config.PasswordSecrets)
 	if err != nil {
 		return frr.Config{}, fmt.Errorf("failed to translate passthrough to frr: %w", err)
 	}
@@ -187,19 +217,47 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 		underlay.Spec.ASN,
 		nodeIndex,
 		vrfsWithL2Gateway,
+		config.PasswordSecrets,
 	)
 	if err != nil {
 		return frr.Config{}, err
 	}
 
+	policies, err := policiesToFRR(config.L3VNIs, config.L3VPNs, config.L3Passthrough)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate policies to frr: %w", err)
+	}
+	if err := policies.addUnderlayExportPolicies(underlays, config, nodeIndex, passthroughConfig); err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate underlay policies to frr: %w", err)
+	}
+	underlayConfig.EVPNImportRouteMap = policies.evpnImportRouteMap
+	underlayConfig.ImportVRFs = vrfImportsToFRR(v1alpha1.DefaultVRFName, defaultVRFLeakSources(config.L3VNIs))
+
+	staticRoutes, err := staticRoutesToFRR(config.L3VNIs, config.L3VPNs)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate static routes to frr: %w", err)
+	}
+
+	routerAdvertisements, err := routerAdvertisementsToFRR(config.L2VNIs, underlay.Spec.EVPN)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate router advertisements to frr: %w", err)
+	}
+
 	return frr.Config{
-		Underlay:    underlayConfig,
-		VNIs:        vniConfigs,
-		Passthrough: passthroughConfig,
-		BFDProfiles: bfdProfilesFromNeighbors(underlay.Spec.Neighbors),
-		VPNs:        vpnConfigs,
-		Loglevel:    logLevel,
-		RawConfig:   rawSnippets,
+		Underlay:       underlayConfig,
+		VNIs:           vniConfigs,
+		Passthrough:    passthroughConfig,
+		BFDProfiles:    bfdProfiles(underlays.neighbors(), underlay.Spec.ISIS, config.L3VNIs, config.L3VPNs, config.L3Passthrough),
+		VPNs:           vpnConfigs,
+		Loglevel:       logLevel,
+		PrefixLists:    policies.prefixLists,
+		CommunityLists: policies.communityLists,
+		RouteMaps:      policies.routeMaps,
+		StaticRoutes:   staticRoutes,
+		RawConfig:      rawSnippets,
+
+		RouterAdvertisements: routerAdvertisements,
+		L2VNIs:               l2vnisToFRR(config.L2VNIs),
 	}, nil
 }
 
@@ -226,13 +284,82 @@ func neighborsToFRR(apiNeighbors []v1alpha1.Neighbor, segmentRouting *frr.Underl
 	return neighbors, nil
 }
 
-func bfdProfilesFromNeighbors(apiNeighbors []v1alpha1.Neighbor) []frr.BFDProfile {
+// additionalUnderlaysToFRR returns the neighbors and the tunnel endpoints of
+// the underlays other than the primary one. They share the BGP instance of
+// the primary underlay, the neighbors of an underlay with a different AS
+// number establishing the sessions with it as local AS.
+func additionalUnderlaysToFRR(underlays nodeUnderlays, config APIConfigData,
+	nodeIndex int) ([]frr.NeighborConfig, []frr.TunnelEndpoint, error) {
+	var neighbors []frr.NeighborConfig
+	var tunnelEndpoints []frr.TunnelEndpoint
+	for _, underlay := range underlays.additional() {
+		tunnelEndpoint, err := tunnelEndpointToFRR(underlay.Spec.TunnelEndpoint, nodeIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to translate tunnel endpoint settings of underlay %s, err: %w",
+				underlay.Name, err)
+		}
+		if tunnelEndpoint != nil {
+			tunnelEndpoints = append(tunnelEndpoints, *tunnelEndpoint)
+		}
+
+		underlayNeighbors, err := neighborsToFRR(
+			underlay.Spec.Neighbors,
+			nil,
+			underlays.l2vnisOn(underlay, config.L2VNIs),
+			underlays.l3vnisOn(underlay, config.L3VNIs),
+			nil,
+			nil,
+			underlay.Spec.TunnelEndpoint,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("underlay %s: %w", underlay.Name, err)
+		}
+		if underlay.Spec.ASN != underlays.primary().Spec.ASN {
+			for i := range underlayNeighbors {
+				underlayNeighbors[i].LocalASN = underlay.Spec.ASN
+			}
+		}
+		underlays.setExportRouteMaps(underlay, underlayNeighbors)
+		neighbors = append(neighbors, underlayNeighbors...)
+	}
+	return neighbors, tunnelEndpoints, nil
+}
+
+// bfdProfiles returns the BFD profiles of the underlay neighbors and of the
+// ISIS interfaces, followed by the ones of the host sessions.
+func bfdProfiles(apiNeighbors []v1alpha1.Neighbor, isisConfig *v1alpha1.ISISConfig, l3vnis []v1alpha1.L3VNI,
+	l3vpns []v1alpha1.L3VPN, l3passthroughs []v1alpha1.L3Passthrough) []frr.BFDProfile {
 	profiles := []frr.BFDProfile{}
 	for _, n := range apiNeighbors {
 		if p := bfdProfileForNeighbor(n); p != nil {
 			profiles = append(profiles, *p)
 		}
 	}
+	if isisConfig != nil {
+		for _, intf := range isisConfig.Interfaces {
+			if p := bfdProfile(bfdProfileNameForISISInterface(intf), intf.BFD); p != nil {
+				profiles = append(profiles, *p)
+			}
+		}
+	}
+	addSessions := func(vrf string, sessions []v1alpha1.VRFHostSession) {
+		for _, s := range sessions {
+			if p := bfdProfile(hostSessionRouteMapPrefix(vrf, s), s.BFD); p != nil {
+				profiles = append(profiles, *p)
+			}
+		}
+	}
+	for _, vni := range l3vnis {
+		addSessions(vni.Spec.VRF, vrfHostSessions(vni.Spec.HostSession, vni.Spec.HostSessions))
+	}
+	for _, vpn := range l3vpns {
+		addSessions(vpn.Spec.VRF, vrfHostSessions(vpn.Spec.HostSession, vpn.Spec.HostSessions))
+	}
+	for _, p := range l3passthroughs {
+		if profile := bfdProfile(passthroughRouteMapPrefix, p.Spec.HostSession.BFD); profile != nil {
+			profiles = append(profiles, *profile)
+		}
+	}
 	return profiles
 }
 
@@ -252,6 +379,44 @@ func applyGracefulRestart(config *frr.UnderlayConfig, gr *v1alpha1.GracefulResta
 	}
 }
 
+// defaultMaximumPaths mirrors the FRR default for the number of eBGP and
+// iBGP paths installed for a destination.
+const defaultMaximumPaths = int32(64)
+
+// aggregatesToFRR splits the aggregates by address family. Prefixes are
+// rendered as network addresses, the way FRR shows them.
+func aggregatesToFRR(aggregates []v1alpha1.Aggregate) ([]frr.Aggregate, []frr.Aggregate) {
+	var ipv4, ipv6 []frr.Aggregate
+	for _, a := range aggregates {
+		_, ipnet, err := net.ParseCIDR(a.Prefix)
+		if err != nil { // already validated
+			continue
+		}
+		aggregate := frr.Aggregate{
+			Prefix:      ipnet.String(),
+			SummaryOnly: ptr.Deref(a.SummaryOnly, false),
+			ASSet:       ptr.Deref(a.ASSet, false),
+		}
+		if ipfamily.ForCIDR(ipnet) == ipfamily.IPv6 {
+			ipv6 = append(ipv6, aggregate)
+			continue
+		}
+		ipv4 = append(ipv4, aggregate)
+	}
+	return ipv4, ipv6
+}
+
+func multipathToFRR(m *v1alpha1.MultipathConfig) *frr.Multipath {
+	if m == nil {
+		return nil
+	}
+	return &frr.Multipath{
+		EBGPPaths:   ptr.Deref(m.EBGPPaths, defaultMaximumPaths),
+		IBGPPaths:   ptr.Deref(m.IBGPPaths, defaultMaximumPaths),
+		ASPathRelax: ptr.Deref(m.ASPathRelax, false),
+	}
+}
+
 // defaultClusterID mirrors the CRD schema default of
 // UnderlaySpec.routeReflector.clusterID for configurations that bypass
 // schema defaulting (e.g. static files).
@@ -300,9 +465,11 @@ func tunnelEndpointToFRR(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, no
 func vniConfigsToFRR(
 	l3vnis []v1alpha1.L3VNI,
 	routerID string,
-	underlayASN int64,
+	underlays nodeUnderlays,
 	nodeIndex int,
 	vrfsWithL2Gateway map[string][]string,
+	asymmetricVRFs map[string]bool,
+	secrets map[string]corev1.Secret,
 ) ([]frr.L3VNIConfig, error) {
 	configs := []frr.L3VNIConfig{}
 	for _, vni := range l3vnis {
@@ -310,10 +477,17 @@ func vniConfigsToFRR(
 		if gatewayCIDRs, ok := vrfsWithL2Gateway[vni.Spec.VRF]; ok {
 			opts = []L3VNIOption{WithGatewayIPs(gatewayCIDRs)}
 		}
-		frrVNI, err := l3vniToFRR(vni, routerID, underlayASN, nodeIndex, opts...)
+		underlay, err := underlays.byName(vni.Spec.Underlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate vni to frr: %w, vni %v", err, vni)
+		}
+		frrVNI, err := l3vniToFRR(vni, routerID, underlay.Spec.ASN, nodeIndex, secrets, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate vni to frr: %w, vni %v", err, vni)
 		}
+		for i := range frrVNI {
+			frrVNI[i].PrefixRoutesOnly = asymmetricVRFs[vni.Spec.VRF]
+		}
 		configs = append(configs, frrVNI...)
 	}
 	return configs, nil
@@ -359,7 +533,117 @@ func underlaySegmentRoutingToFRR(srv6Config *v1alpha1.SRV6Config, nodeIndex int,
 	}, nil
 }
 
-func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nodeIndex int) (*frr.UnderlayISIS, error) {
+// underlayPIMToFRR enables PIM on the loopback holding the VTEP address, on
+// the device the multicast VXLan interfaces are bound to and on the underlay
+// interfaces.
+func underlayPIMToFRR(evpn *v1alpha1.EVPNConfig, interfaces []v1alpha1.UnderlayInterface) (*frr.UnderlayPIM, error) {
+	if evpn == nil || evpn.Multicast == nil {
+		return nil, nil
+	}
+	if ip := net.ParseIP(evpn.Multicast.RendezvousPoint); ip == nil || ip.To4() == nil {
+		return nil, fmt.Errorf("invalid rendezvousPoint %q, must be an IPv4 address", evpn.Multicast.RendezvousPoint)
+	}
+
+	pimInterfaces := []string{loopbackName, hostnetwork.MulticastDeviceName}
+	for _, iface := range interfaces {
+		hostIface, err := underlayInterfaceToHost(iface)
+		if err != nil {
+			return nil, err
+		}
+		pimInterfaces = append(pimInterfaces, hostIface.InterfaceName)
+	}
+	return &frr.UnderlayPIM{
+		RendezvousPoint: evpn.Multicast.RendezvousPoint,
+		Interfaces:      pimInterfaces,
+	}, nil
+}
+
+// dupAddrDetectionToFRR returns the duplicate address detection parameters
+// and the token requesting to clear the duplicate addresses.
+func dupAddrDetectionToFRR(evpn *v1alpha1.EVPNConfig) (*frr.DupAddrDetection, string) {
+	if evpn == nil || evpn.DuplicateAddressDetection == nil {
+		return nil, ""
+	}
+	detection := evpn.DuplicateAddressDetection
+	token := ptr.Deref(detection.ClearFrozenToken, "")
+	if !ptr.Deref(detection.Enabled, true) {
+		return &frr.DupAddrDetection{Disabled: true}, token
+	}
+
+	res := &frr.DupAddrDetection{
+		MaxMoves: ptr.Deref(detection.MaxMoves, 5),
+		Time:     ptr.Deref(detection.TimeSeconds, 180),
+	}
+	if detection.Freeze != nil {
+		res.FreezePermanent = ptr.Deref(detection.Freeze.Permanent, false)
+		res.FreezeTime = ptr.Deref(detection.Freeze.TimeSeconds, 0)
+	}
+	return res, token
+}
+
+// routerAdvertisementsToFRR returns the router advertisements of the gateway
+// interfaces of the L2VNIs enabling them.
+func routerAdvertisementsToFRR(l2vnis []v1alpha1.L2VNI, evpn *v1alpha1.EVPNConfig) ([]frr.RouterAdvertisement, error) {
+	var res []frr.RouterAdvertisement
+	for _, l2vni := range l2vnis {
+		ra := l2vni.Spec.RouterAdvertisement
+		if ra == nil || !ptr.Deref(ra.Enabled, true) {
+			continue
+		}
+		params := hostnetwork.L2VNIParams{VNIParams: hostnetwork.VNIParams{VNI: l2vni.Spec.VNI}}
+		if isVLANAwareBridge(evpn) {
+			vlan, err := l2vniVLAN(l2vni)
+			if err != nil {
+				return nil, fmt.Errorf("L2VNI %s: %w", l2vni.Name, err)
+			}
+			params.VLAN = vlan
+		}
+
+		frrRA := frr.RouterAdvertisement{
+			Interface:  hostnetwork.L2GatewayInterfaceName(params),
+			Interval:   ptr.Deref(ra.IntervalSeconds, 0),
+			Lifetime:   ra.LifetimeSeconds,
+			DNSServers: ra.DNSServers,
+		}
+		for _, p := range ra.Prefixes {
+			_, prefix, err := net.ParseCIDR(p.Prefix)
+			if err != nil {
+				return nil, fmt.Errorf("L2VNI %s: invalid router advertisement prefix %s: %w", l2vni.Name, p.Prefix, err)
+			}
+			frrRA.Prefixes = append(frrRA.Prefixes, frr.RouterAdvertisementPrefix{
+				Prefix:       prefix.String(),
+				OffLink:      !ptr.Deref(p.OnLink, true),
+				NoAutoconfig: !ptr.Deref(p.Autonomous, true),
+			})
+		}
+		res = append(res, frrRA)
+	}
+	return res, nil
+}
+
+// l2vnisToFRR returns the EVPN advertisement flags of the L2VNIs setting
+// any.
+func l2vnisToFRR(l2vnis []v1alpha1.L2VNI) []frr.L2VNIConfig {
+	var res []frr.L2VNIConfig
+	for _, l2vni := range l2vnis {
+		adv := l2vni.Spec.EVPNAdvertisement
+		if adv == nil {
+			continue
+		}
+		c := frr.L2VNIConfig{
+			VNI:                l2vni.Spec.VNI,
+			AdvertiseDefaultGW: ptr.Deref(adv.DefaultGateway, false),
+			AdvertiseSVIIP:     ptr.Deref(adv.SVIIP, false),
+		}
+		if c.AdvertiseDefaultGW || c.AdvertiseSVIIP {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nodeIndex int,
+	secrets map[string]corev1.Secret) (*frr.UnderlayISIS, error) {
 	if isisConfig == nil {
 		return nil, nil
 	}
@@ -406,12 +690,35 @@ func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nod
 		hasIPv6 := intf.IPFamily != nil &&
 			(*intf.IPFamily == v1alpha1.IPFamilyIPv6 || *intf.IPFamily == v1alpha1.IPFamilyDualStack)
 
-		isisInterfaces[intf.Name] = frr.ISISInterface{
-			Name:      intf.Name,
-			IPv4:      hasIPv4,
-			IPv6:      hasIPv6,
-			IsPassive: slices.Contains(intf.Features, passiveInterface),
+		password, err := isisPasswordToFRR(intf.Authentication, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authentication for interface %s: %w", intf.Name, err)
+		}
+
+		isisInterface := frr.ISISInterface{
+			Name:            intf.Name,
+			IPv4:            hasIPv4,
+			IPv6:            hasIPv6,
+			IsPassive:       slices.Contains(intf.Features, passiveInterface),
+			Metric:          ptr.Deref(intf.Metric, 0),
+			HelloInterval:   ptr.Deref(intf.HelloInterval, 0),
+			HelloMultiplier: ptr.Deref(intf.HelloMultiplier, 0),
+			Password:        password,
+			BFD:             intf.BFD != nil,
 		}
+		if bfdProfile(bfdProfileNameForISISInterface(intf), intf.BFD) != nil {
+			isisInterface.BFDProfile = bfdProfileNameForISISInterface(intf)
+		}
+		isisInterfaces[intf.Name] = isisInterface
+	}
+
+	areaPassword, err := isisPasswordToFRR(isisConfig.AreaAuthentication, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid area authentication: %w", err)
+	}
+	domainPassword, err := isisPasswordToFRR(isisConfig.DomainAuthentication, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain authentication: %w", err)
 	}
 
 	return &frr.UnderlayISIS{
@@ -420,14 +727,45 @@ func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nod
 		Level:                isisLevel,
 		AdvertisePassiveOnly: slices.Contains(isisConfig.Features, advertisePassiveOnly),
 		Interfaces:           mapOfInterfacesToSortedList(isisInterfaces),
+		AreaPassword:         areaPassword,
+		DomainPassword:       domainPassword,
+		Overload:             ptr.Deref(isisConfig.Overload, false),
 	}, nil
 }
 
-func mapOfInterfacesToSortedList(m map[string]frr.ISISInterface) []frr.ISISInterface {
-	s := slices.Collect(maps.Values(m))
-	slices.SortFunc(s, func(x, y frr.ISISInterface) int {
-		return cmp.Compare(x.Name, y.Name)
-	})
+// isisPasswordToFRR returns the password the ISIS PDUs are authenticated
+// with, read from the secret of the authentication.
+func isisPasswordToFRR(authentication *v1alpha1.ISISAuthentication,
+	secrets map[string]corev1.Secret) (*frr.ISISPassword, error) {
+	if authentication == nil {
+		return nil, nil
+	}
+
+	password, err := sessionPassword(nil, &authentication.PasswordSecret, secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	switch authentication.Type {
+	case v1alpha1.ISISAuthenticationClear:
+		return &frr.ISISPassword{Password: password}, nil
+	case v1alpha1.ISISAuthenticationHMACMD5:
+		return &frr.ISISPassword{HMACMD5: true, Password: password}, nil
+	}
+	return nil, fmt.Errorf("invalid authentication type %q", authentication.Type)
+}
+
+func bfdProfileNameForISISInterface(intf v1alpha1.ISISInterface) string {
+	return fmt.Sprintf("isis-%s", intf.Name)
+}
+
+// mapOfInterfacesToSortedList returns the interfaces of the map, sorted by
+// name.
+func mapOfInterfacesToSortedList[T any](m map[string]T) []T {
+	s := make([]T, 0, len(m))
+	for _, name := range slices.Sorted(maps.Keys(m)) {
+		s = append(s, m[name])
+	}
 	return s
 }
 
@@ -448,7 +786,7 @@ func rawConfigSnippets(rawFRRConfigs []v1alpha1.RawFRRConfig) []frr.RawFRRSnippe
 	return snippets
 }
 
-func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*frr.PassthroughConfig, error) {
+func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int, secrets map[string]corev1.Secret) (*frr.PassthroughConfig, error) {
 	if len(l3Passthroughs) == 0 {
 		return nil, nil
 	}
@@ -472,13 +810,19 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 	}
 
 	const passthroughConnectRetrySeconds = int64(5)
+	importRouteMap, exportRouteMap := hostSessionRouteMapNames(passthroughRouteMapPrefix, passthrough.Spec.HostSession)
 
 	if vethIPs.Ipv4.HostSide.IP != nil {
 		res.LocalNeighborV4 = &frr.NeighborConfig{
-			ASN:         asn,
-			Addr:        vethIPs.Ipv4.HostSide.IP.String(),
-			ID:          vethIPs.Ipv4.HostSide.IP.String(),
-			ConnectTime: new(passthroughConnectRetrySeconds),
+			ASN:            asn,
+			Addr:           vethIPs.Ipv4.HostSide.IP.String(),
+			ID:             vethIPs.Ipv4.HostSide.IP.String(),
+			ConnectTime:    new(passthroughConnectRetrySeconds),
+			ImportRouteMap: importRouteMap,
+			ExportRouteMap: exportRouteMap,
+		}
+		if err := setHostSessionNeighborOptions(res.LocalNeighborV4, passthrough.Spec.HostSession, passthroughRouteMapPrefix, secrets); err != nil {
+			return nil, fmt.Errorf("passthrough HostSession: %w", err)
 		}
 		ipnet := net.IPNet{
 			IP:   vethIPs.Ipv4.HostSide.IP,
@@ -489,10 +833,15 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 	}
 	if vethIPs.Ipv6.HostSide.IP != nil {
 		res.LocalNeighborV6 = &frr.NeighborConfig{
-			ASN:         asn,
-			Addr:        vethIPs.Ipv6.HostSide.IP.String(),
-			ID:          vethIPs.Ipv6.HostSide.IP.String(),
-			ConnectTime: new(passthroughConnectRetrySeconds),
+			ASN:            asn,
+			Addr:           vethIPs.Ipv6.HostSide.IP.String(),
+			ID:             vethIPs.Ipv6.HostSide.IP.String(),
+			ConnectTime:    new(passthroughConnectRetrySeconds),
+			ImportRouteMap: importRouteMap,
+			ExportRouteMap: exportRouteMap,
+		}
+		if err := setHostSessionNeighborOptions(res.LocalNeighborV6, passthrough.Spec.HostSession, passthroughRouteMapPrefix, secrets); err != nil {
+			return nil, fmt.Errorf("passthrough HostSession: %w", err)
 		}
 
 		ipnet := net.IPNet{
@@ -505,22 +854,33 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 	return res, nil
 }
 
-// l3vniToFRR converts an L3VNI CR into one or two FRR L3VNIConfigs.
+// l3vniToFRR converts an L3VNI CR into one or more FRR L3VNIConfigs.
 // If no HostSession is defined, it returns a single config using the underlay ASN.
-// Otherwise, it derives veth IPs from the HostSession's local CIDR pool for the given node index
+// Otherwise, for each host session it derives veth IPs from the session's local CIDR pool for the given node index
 // and creates a config per IP family (IPv4/IPv6), each with a local neighbor and the corresponding prefixes to advertise.
-func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeIndex int, opts ...L3VNIOption) ([]frr.L3VNIConfig, error) {
+func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeIndex int,
+	secrets map[string]corev1.Secret, opts ...L3VNIOption) ([]frr.L3VNIConfig, error) {
 	exportRTs := convertRTsToSliceOfStrings(vni.Spec.ExportRTs)
 	importRTs := convertRTsToSliceOfStrings(vni.Spec.ImportRTs)
+	// The import communities are matched by the global EVPN import route-map.
+	fabricExportRouteMap, _ := vrfCommunitiesRouteMapNames(vni.Spec.VRF, vni.Spec.Communities)
+	aggregatesIPv4, aggregatesIPv6 := aggregatesToFRR(vni.Spec.Aggregates)
 
-	if vni.Spec.HostSession == nil { // no neighbor, just the vni / vrf
+	sessions := vrfHostSessions(vni.Spec.HostSession, vni.Spec.HostSessions)
+	if len(sessions) == 0 { // no neighbor, just the vni / vrf
 		cfg := frr.L3VNIConfig{
-			VNI:       vni.Spec.VNI,
-			VRF:       vni.Spec.VRF,
-			ASN:       underlayASN, // Since there is no session, the ASN is arbitrary
-			RouterID:  routerID,
-			ExportRTs: exportRTs,
-			ImportRTs: importRTs,
+			VNI:                  vni.Spec.VNI,
+			VRF:                  vni.Spec.VRF,
+			ASN:                  underlayASN, // Since there is no session, the ASN is arbitrary
+			RouterID:             routerID,
+			ExportRTs:            exportRTs,
+			ImportRTs:            importRTs,
+			ExportRouteMap:       fabricExportRouteMap,
+			Multipath:            multipathToFRR(vni.Spec.Multipath),
+			StaticRoutesRouteMap: staticRoutesRouteMapName(vni.Spec.VRF, vni.Spec.StaticRoutes),
+			AggregatesIPv4:       aggregatesIPv4,
+			AggregatesIPv6:       aggregatesIPv6,
+			ImportVRFs:           vrfImportsToFRR(vni.Spec.VRF, l3vniLeakSources(vni)),
 		}
 		for _, opt := range opts {
 			if err := opt(&cfg); err != nil {
@@ -530,44 +890,31 @@ func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeInde
 		return []frr.L3VNIConfig{cfg}, nil
 	}
 
-	hostASN, err := frr.NewPeerASN(vni.Spec.HostSession.HostASN, vni.Spec.HostSession.HostType)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse HostSession, err: %w", err)
-	}
-
-	hostSideIPs, err := hostSessionToHostSideIPs(vni.Spec.HostSession, nodeIndex)
-	if err != nil {
-		return nil, err
-	}
-
 	configs := []frr.L3VNIConfig{}
-	for _, af := range []ipfamily.Family{ipfamily.IPv4, ipfamily.IPv6} {
-		ipnet, hasFamily := hostSideIPs[af]
-		if !hasFamily {
-			continue
+	for _, session := range sessions {
+		neighbors, err := hostSessionToFRR(vni.Spec.VRF, session, sessions[0].ASN, nodeIndex, secrets)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			configs = append(configs, frr.L3VNIConfig{
+				ASN:                  sessions[0].ASN,
+				VNI:                  vni.Spec.VNI,
+				VRF:                  vni.Spec.VRF,
+				RouterID:             routerID,
+				LocalNeighbor:        n.neighbor,
+				ExportRTs:            exportRTs,
+				ImportRTs:            importRTs,
+				ToAdvertiseIPv4:      n.toAdvertiseIPv4,
+				ToAdvertiseIPv6:      n.toAdvertiseIPv6,
+				ExportRouteMap:       fabricExportRouteMap,
+				Multipath:            multipathToFRR(vni.Spec.Multipath),
+				StaticRoutesRouteMap: staticRoutesRouteMapName(vni.Spec.VRF, vni.Spec.StaticRoutes),
+				AggregatesIPv4:       aggregatesIPv4,
+				AggregatesIPv6:       aggregatesIPv6,
+				ImportVRFs:           vrfImportsToFRR(vni.Spec.VRF, l3vniLeakSources(vni)),
+			})
 		}
-		toAdvertiseIPv4, toAdvertiseIPv6 := []string{}, []string{}
-		if af == ipfamily.IPv4 {
-			toAdvertiseIPv4 = []string{ipnet.String()}
-		} else {
-			toAdvertiseIPv6 = []string{ipnet.String()}
-		}
-
-		configs = append(configs, frr.L3VNIConfig{
-			ASN:      vni.Spec.HostSession.ASN,
-			VNI:      vni.Spec.VNI,
-			VRF:      vni.Spec.VRF,
-			RouterID: routerID,
-			LocalNeighbor: &frr.NeighborConfig{
-				Addr: ipnet.IP.String(),
-				ID:   ipnet.IP.String(),
-				ASN:  hostASN,
-			},
-			ExportRTs:       exportRTs,
-			ImportRTs:       importRTs,
-			ToAdvertiseIPv4: toAdvertiseIPv4,
-			ToAdvertiseIPv6: toAdvertiseIPv6,
-		})
 	}
 	for i := range configs {
 		for _, opt := range opts {
@@ -585,6 +932,7 @@ func l3vpnConfigsToFRR(
 	asn int64,
 	nodeIndex int,
 	vrfsWithL2Gateway map[string][]string,
+	secrets map[string]corev1.Secret,
 ) ([]frr.L3VPNConfig, error) {
 	vpnConfigs := []frr.L3VPNConfig{}
 	for _, vpn := range l3VPNs {
@@ -592,7 +940,7 @@ func l3vpnConfigsToFRR(
 		if gatewayCIDRs, ok := vrfsWithL2Gateway[vpn.Spec.VRF]; ok {
 			opts = []L3VPNOption{L3VPNWithGatewayIPs(gatewayCIDRs)}
 		}
-		frrVNI, err := l3vpnToFRR(vpn, routerID, asn, nodeIndex, opts...)
+		frrVNI, err := l3vpnToFRR(vpn, routerID, asn, nodeIndex, secrets, opts...)
 		if err != nil {
 			return []frr.L3VPNConfig{}, fmt.Errorf("failed to translate l3vpn to frr: %w, vni %v", err, vpn)
 		}
@@ -601,9 +949,9 @@ func l3vpnConfigsToFRR(
 	return vpnConfigs, nil
 }
 
-// l3vpnToFRR converts an L3VPN CR into one or two FRR L3VPNConfigs.
+// l3vpnToFRR converts an L3VPN CR into one or more FRR L3VPNConfigs.
 // If no HostSession is defined, it returns a single config using the underlay ASN.
-// Otherwise, it derives veth IPs from the HostSession's local CIDR pool for the given node index
+// Otherwise, for each host session it derives veth IPs from the session's local CIDR pool for the given node index
 // and creates a config per IP family (IPv4/IPv6), each with a local neighbor and the corresponding prefixes to
 // advertise.
 func l3vpnToFRR(
@@ -611,6 +959,7 @@ func l3vpnToFRR(
 	routerID string,
 	underlayASN int64,
 	nodeIndex int,
+	secrets map[string]corev1.Secret,
 	opts ...L3VPNOption,
 ) ([]frr.L3VPNConfig, error) {
 	// importRTs cannot be auto-derived. Unfortunately, FRR does not support wildcard notation, e.g. *:200. And
@@ -624,15 +973,24 @@ func l3vpnToFRR(
 	if len(vpn.Spec.ExportRTs) > 0 {
 		exportRTs = convertRTsToSliceOfStrings(vpn.Spec.ExportRTs)
 	}
+	fabricExportRouteMap, fabricImportRouteMap := vrfCommunitiesRouteMapNames(vpn.Spec.VRF, vpn.Spec.Communities)
+	aggregatesIPv4, aggregatesIPv6 := aggregatesToFRR(vpn.Spec.Aggregates)
 
-	if vpn.Spec.HostSession == nil { // no neighbor, just the vni / vrf
+	sessions := vrfHostSessions(vpn.Spec.HostSession, vpn.Spec.HostSessions)
+	if len(sessions) == 0 { // no neighbor, just the vni / vrf
 		cfg := frr.L3VPNConfig{
-			ASN:                underlayASN, // Since there is no session, the ASN is arbitrary
-			VRF:                vpn.Spec.VRF,
-			RouterID:           routerID,
-			ExportRTs:          exportRTs,
-			ImportRTs:          importRTs,
-			RouteDistinguisher: routeDistinguisher(routerID, vpn.Spec.RDAssignedNumber),
+			ASN:                  underlayASN, // Since there is no session, the ASN is arbitrary
+			VRF:                  vpn.Spec.VRF,
+			RouterID:             routerID,
+			ExportRTs:            exportRTs,
+			ImportRTs:            importRTs,
+			RouteDistinguisher:   routeDistinguisher(routerID, vpn.Spec.RDAssignedNumber),
+			ExportRouteMap:       fabricExportRouteMap,
+			ImportRouteMap:       fabricImportRouteMap,
+			Multipath:            multipathToFRR(vpn.Spec.Multipath),
+			StaticRoutesRouteMap: staticRoutesRouteMapName(vpn.Spec.VRF, vpn.Spec.StaticRoutes),
+			AggregatesIPv4:       aggregatesIPv4,
+			AggregatesIPv6:       aggregatesIPv6,
 		}
 		for _, opt := range opts {
 			if err := opt(&cfg); err != nil {
@@ -642,57 +1000,104 @@ func l3vpnToFRR(
 		return []frr.L3VPNConfig{cfg}, nil
 	}
 
-	hostASN, err := frr.NewPeerASN(vpn.Spec.HostSession.HostASN, vpn.Spec.HostSession.HostType)
+	configs := []frr.L3VPNConfig{}
+	for _, session := range sessions {
+		neighbors, err := hostSessionToFRR(vpn.Spec.VRF, session, sessions[0].ASN, nodeIndex, secrets)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			configs = append(configs, frr.L3VPNConfig{
+				ASN:                  sessions[0].ASN,
+				ExportRTs:            exportRTs,
+				ImportRTs:            importRTs,
+				RouteDistinguisher:   routeDistinguisher(routerID, vpn.Spec.RDAssignedNumber),
+				VRF:                  vpn.Spec.VRF,
+				RouterID:             routerID,
+				LocalNeighbor:        n.neighbor,
+				ToAdvertiseIPv4:      n.toAdvertiseIPv4,
+				ToAdvertiseIPv6:      n.toAdvertiseIPv6,
+				ExportRouteMap:       fabricExportRouteMap,
+				ImportRouteMap:       fabricImportRouteMap,
+				Multipath:            multipathToFRR(vpn.Spec.Multipath),
+				StaticRoutesRouteMap: staticRoutesRouteMapName(vpn.Spec.VRF, vpn.Spec.StaticRoutes),
+				AggregatesIPv4:       aggregatesIPv4,
+				AggregatesIPv6:       aggregatesIPv6,
+			})
+		}
+	}
+	for i := range configs {
+		for _, opt := range opts {
+			if err := opt(&configs[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return configs, nil
+}
+
+func routeDistinguisher(left string, right int32) string {
+	return fmt.Sprintf("%s:%d", left, right)
+}
+
+// hostSessionNeighbor is the local neighbor of a host session for a single
+// address family, together with the veth IP to advertise.
+type hostSessionNeighbor struct {
+	neighbor        *frr.NeighborConfig
+	toAdvertiseIPv4 []string
+	toAdvertiseIPv6 []string
+}
+
+// hostSessionToFRR converts a host session of a VRF into a local neighbor per
+// IP family of its local CIDR. routerASN is the AS number of the BGP instance
+// of the VRF, a session with a different asn uses it as local AS.
+func hostSessionToFRR(vrf string, session v1alpha1.VRFHostSession, routerASN int64,
+	nodeIndex int, secrets map[string]corev1.Secret) ([]hostSessionNeighbor, error) {
+	hostASN, err := frr.NewPeerASN(session.HostASN, session.HostType)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse HostSession, err: %w", err)
 	}
 
-	hostSideIPs, err := hostSessionToHostSideIPs(vpn.Spec.HostSession, nodeIndex)
+	hostSideIPs, err := hostSessionToHostSideIPs(&session.HostSession, nodeIndex)
 	if err != nil {
 		return nil, err
 	}
+	prefix := hostSessionRouteMapPrefix(vrf, session)
+	importRouteMap, exportRouteMap := hostSessionRouteMapNames(prefix, session.HostSession)
+	localASN := int64(0)
+	if session.ASN != routerASN {
+		localASN = session.ASN
+	}
 
-	configs := []frr.L3VPNConfig{}
+	res := []hostSessionNeighbor{}
 	for _, af := range []ipfamily.Family{ipfamily.IPv4, ipfamily.IPv6} {
 		ipnet, hasFamily := hostSideIPs[af]
 		if !hasFamily {
 			continue
 		}
-		toAdvertiseIPv4, toAdvertiseIPv6 := []string{}, []string{}
+		n := hostSessionNeighbor{
+			neighbor: &frr.NeighborConfig{
+				Addr:           ipnet.IP.String(),
+				ID:             ipnet.IP.String(),
+				ASN:            hostASN,
+				LocalASN:       localASN,
+				ImportRouteMap: importRouteMap,
+				ExportRouteMap: exportRouteMap,
+			},
+			toAdvertiseIPv4: []string{},
+			toAdvertiseIPv6: []string{},
+		}
+		if err := setHostSessionNeighborOptions(n.neighbor, session.HostSession, prefix, secrets); err != nil {
+			return nil, err
+		}
 		if af == ipfamily.IPv4 {
-			toAdvertiseIPv4 = []string{ipnet.String()}
+			n.toAdvertiseIPv4 = []string{ipnet.String()}
 		} else {
-			toAdvertiseIPv6 = []string{ipnet.String()}
-		}
-
-		configs = append(configs, frr.L3VPNConfig{
-			ASN:                vpn.Spec.HostSession.ASN,
-			ExportRTs:          exportRTs,
-			ImportRTs:          importRTs,
-			RouteDistinguisher: routeDistinguisher(routerID, vpn.Spec.RDAssignedNumber),
-			VRF:                vpn.Spec.VRF,
-			RouterID:           routerID,
-			LocalNeighbor: &frr.NeighborConfig{
-				Addr: ipnet.IP.String(),
-				ID:   ipnet.IP.String(),
-				ASN:  hostASN,
-			},
-			ToAdvertiseIPv4: toAdvertiseIPv4,
-			ToAdvertiseIPv6: toAdvertiseIPv6,
-		})
-	}
-	for i := range configs {
-		for _, opt := range opts {
-			if err := opt(&configs[i]); err != nil {
-				return nil, err
-			}
+			n.toAdvertiseIPv6 = []string{ipnet.String()}
 		}
+		res = append(res, n)
 	}
-	return configs, nil
-}
-
-func routeDistinguisher(left string, right int32) string {
-	return fmt.Sprintf("%s:%d", left, right)
+	return res, nil
 }
 
 func hostSessionToHostSideIPs(hostSession *v1alpha1.HostSession, nodeIndex int) (map[ipfamily.Family]net.IPNet, error) {
@@ -714,6 +1119,50 @@ func hostSessionToHostSideIPs(hostSession *v1alpha1.HostSession, nodeIndex int)
 	return hostSideIPs, nil
 }
 
+// setHostSessionNeighborOptions applies the session options of a host
+// session to its local neighbor. prefix is the name of the BFD profile
+// generated for the session, when its BFD settings require one, and the
+// prefix of the names of its route-maps.
+func setHostSessionNeighborOptions(n *frr.NeighborConfig, session v1alpha1.HostSession, prefix string,
+	secrets map[string]corev1.Secret) error {
+	password, err := sessionPassword(session.Password, session.PasswordSecret, secrets)
+	if err != nil {
+		return err
+	}
+	n.Password = password
+	n.HoldTime = session.HoldTimeSeconds
+	n.KeepaliveTime = session.KeepaliveTimeSeconds
+	if session.ConnectTimeSeconds != nil {
+		n.ConnectTime = session.ConnectTimeSeconds
+	}
+
+	if session.BFD != nil {
+		n.BFDEnabled = true
+		if bfdProfile(prefix, session.BFD) != nil {
+			n.BFDProfile = prefix
+		}
+	}
+
+	if session.DefaultOriginate != nil {
+		family := ptr.Deref(session.DefaultOriginate.IPFamily, v1alpha1.IPFamilyDualStack)
+		n.DefaultOriginate = &frr.DefaultOriginate{
+			IPv4:     family != v1alpha1.IPFamilyIPv6,
+			IPv6:     family != v1alpha1.IPFamilyIPv4,
+			RouteMap: defaultOriginateRouteMapName(prefix, session.DefaultOriginate),
+		}
+	}
+
+	switch ptr.Deref(session.GracefulRestart, "") {
+	case v1alpha1.GracefulRestartModeEnabled:
+		n.GracefulRestart = frr.NeighborGracefulRestartEnabled
+	case v1alpha1.GracefulRestartModeHelperOnly:
+		n.GracefulRestart = frr.NeighborGracefulRestartHelper
+	case v1alpha1.GracefulRestartModeDisabled:
+		n.GracefulRestart = frr.NeighborGracefulRestartDisabled
+	}
+	return nil
+}
+
 // convertRTsToSliceOfStrings converts the provided routeTarget []v1alpha1.RouteTarget to slice of strings.
 // convertRTsToSliceOfStrings does not validate the provided routeTargets:
 // - for APItoFRR,  FilterValidL3VNIs -> validateL3VNI already did the validation
@@ -1034,25 +1483,28 @@ func defaultNLPsForNeighbor(n v1alpha1.Neighbor,
 }
 
 func bfdProfileForNeighbor(n v1alpha1.Neighbor) *frr.BFDProfile {
-	if n.BFD == nil {
+	return bfdProfile(bfdProfileNameForNeighbor(n), n.BFD)
+}
+
+// bfdProfile returns the BFD profile with the given name for the settings,
+// or nil when the settings do not deviate from the defaults.
+func bfdProfile(name string, settings *v1alpha1.BFDSettings) *frr.BFDProfile {
+	if settings == nil {
 		return nil
 	}
 
-	if ptr.AllPtrFieldsNil(n.BFD) {
+	if ptr.AllPtrFieldsNil(settings) {
 		return nil
 	}
 
-	profileName := bfdProfileNameForNeighbor(n)
-	bfdProfile := &frr.BFDProfile{
-		Name:             profileName,
-		ReceiveInterval:  n.BFD.ReceiveInterval,
-		TransmitInterval: n.BFD.TransmitInterval,
-		DetectMultiplier: n.BFD.DetectMultiplier,
-		PassiveMode:      ptr.Deref(n.BFD.SessionMode, v1alpha1.BFDSessionModeActive) == v1alpha1.BFDSessionModePassive,
-		MinimumTTL:       n.BFD.MinimumTTL,
+	return &frr.BFDProfile{
+		Name:             name,
+		ReceiveInterval:  settings.ReceiveInterval,
+		TransmitInterval: settings.TransmitInterval,
+		DetectMultiplier: settings.DetectMultiplier,
+		PassiveMode:      ptr.Deref(settings.SessionMode, v1alpha1.BFDSessionModeActive) == v1alpha1.BFDSessionModePassive,
+		MinimumTTL:       settings.MinimumTTL,
 	}
-
-	return bfdProfile
 }
 
 // ebgpMultiHopForNeighbor returns whether the ebgpMultiHop property is set on
@@ -1105,7 +1557,10 @@ func routerIDFromUnderlay(underlay v1alpha1.Underlay, nodeIndex int) (string, er
 	return routerID, nil
 }
 
-func vrfsWithL2Gateways(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string) (map[string][]string, error) {
+// vrfsWithL2Gateways returns the prefixes to advertise from each VRF for the
+// gateways of its L2VNIs: the gateway subnets, and the address of the node
+// the DHCP requests are relayed from, which the servers reply to.
+func vrfsWithL2Gateways(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string, nodeIndex int) (map[string][]string, error) {
 	res := make(map[string][]string)
 	for _, l2vni := range l2vnis {
 		if len(l2vni.Spec.GatewayIPs) == 0 {
@@ -1116,6 +1571,13 @@ func vrfsWithL2Gateways(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string) (map[
 			return nil, fmt.Errorf("L2VNI %q has gatewayIPs but no resolvable VRF", l2vni.Name)
 		}
 		res[vrfName] = append(res[vrfName], l2vni.Spec.GatewayIPs...)
+		sourceIP, err := dhcpRelaySourceIP(l2vni, nodeIndex)
+		if err != nil {
+			return nil, fmt.Errorf("L2VNI %q: %w", l2vni.Name, err)
+		}
+		if sourceIP != "" {
+			res[vrfName] = append(res[vrfName], sourceIP)
+		}
 	}
 	for vrf := range res {
 		slices.Sort(res[vrf])
@@ -1124,6 +1586,18 @@ func vrfsWithL2Gateways(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string) (map[
 	return res, nil
 }
 
+// asymmetricIRBVRFs returns the VRFs whose L2VNIs are routed with
+// asymmetric IRB.
+func asymmetricIRBVRFs(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string) map[string]bool {
+	res := make(map[string]bool)
+	for _, l2vni := range l2vnis {
+		if isAsymmetricIRB(l2vni) {
+			res[resolveVRFForL2VNI(l2vni, vrfMap)] = true
+		}
+	}
+	return res
+}
+
 func validateNeighbor(n v1alpha1.Neighbor) error {
 	intf := ptr.Deref(n.Interface, "")
 	addr := ptr.Deref(n.Address, "")
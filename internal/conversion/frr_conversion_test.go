@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
@@ -22,6 +23,7 @@ func TestAPItoFRR(t *testing.T) {
 		l2vnis        []v1alpha1.L2VNI
 		vpns          []v1alpha1.L3VPN
 		l3Passthrough []v1alpha1.L3Passthrough
+		secrets       map[string]corev1.Secret
 		logLevel      string
 		want          frr.Config
 		wantErr       bool
@@ -644,6 +646,153 @@ func TestAPItoFRR(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:      "multiple underlays",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65100,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.2.0/24"},
+						},
+						Neighbors: []v1alpha1.Neighbor{{Address: new("192.168.2.1"), ASN: new(int64(65101))}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VRF: "vrf1",
+						VNI: 200,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni2"},
+					Spec: v1alpha1.L3VNISpec{
+						VRF:      "vrf2",
+						VNI:      201,
+						Underlay: new("fabric-b"),
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					AdditionalTunnelEndpoints: []frr.TunnelEndpoint{
+						{IPv4CIDR: "192.168.2.0/32"},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							ExportRouteMap:     "underlay-fabric-a-export",
+							EVPNExportRouteMap: "underlay-fabric-a-evpn-export",
+						},
+						{
+							Name:     "65101@192.168.2.1",
+							ASN:      mustNewPeerASNFromNumber(65101),
+							LocalASN: 65100,
+							Addr:     "192.168.2.1",
+							ID:       "192.168.2.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							ExportRouteMap:     "underlay-fabric-b-export",
+							EVPNExportRouteMap: "underlay-fabric-b-evpn-export",
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:       65000,
+						VNI:       200,
+						VRF:       "vrf1",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+					},
+					{
+						ASN:       65100,
+						VNI:       201,
+						VRF:       "vrf2",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+				PrefixLists: []frr.PrefixList{
+					{
+						Name:    "underlay-fabric-a-export",
+						Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "192.168.1.0/32"}},
+					},
+					{
+						Name:    "underlay-fabric-b-export",
+						Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "192.168.2.0/32"}},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "underlay-fabric-a-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "underlay-fabric-a-export"}},
+							{Seq: 65535, Action: "deny"},
+						},
+					},
+					{
+						Name: "underlay-fabric-a-evpn-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{EVPNVNI: 200}},
+							{Seq: 65535, Action: "deny"},
+						},
+					},
+					{
+						Name: "underlay-fabric-b-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "underlay-fabric-b-export"}},
+							{Seq: 65535, Action: "deny"},
+						},
+					},
+					{
+						Name: "underlay-fabric-b-evpn-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{EVPNVNI: 201}},
+							{Seq: 65535, Action: "deny"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:      "ipv4 with route targets",
 			nodeIndex: 0,
@@ -931,20 +1080,1312 @@ func TestAPItoFRR(t *testing.T) {
 							ID:   "192.168.2.2",
 							ASN:  mustNewPeerASNFromType("External"),
 						},
-						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
-						ToAdvertiseIPv6: []string{},
-						ExportRTs:       []string{},
-						ImportRTs:       []string{},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "hostsession internal",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN:  new(int64(0)),
+							HostType: new("Internal"),
+						},
+						VRF: "vni1",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      65000,
+						VNI:      200,
+						VRF:      "vni1",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr: "192.168.2.2",
+							ID:   "192.168.2.2",
+							ASN:  mustNewPeerASNFromType("Internal"),
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "hostsession with import and export policies",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN: new(int64(65001)),
+							ImportPolicy: &v1alpha1.PrefixPolicy{
+								Rules: []v1alpha1.PrefixRule{
+									{Prefix: "10.100.10.0/24", Action: new(v1alpha1.PrefixRuleActionDeny), LE: new(int32(32))},
+									{Prefix: "10.100.0.1/16", GE: new(int32(24)), LE: new(int32(32))},
+									{Prefix: "2001:db8:100::/48", Action: new(v1alpha1.PrefixRuleActionPermit), GE: new(int32(64))},
+								},
+							},
+							ExportPolicy: &v1alpha1.PrefixPolicy{
+								Rules: []v1alpha1.PrefixRule{
+									{Prefix: "0.0.0.0/0"},
+								},
+							},
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      65000,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr:           "192.168.2.2",
+							ID:             "192.168.2.2",
+							ASN:            mustNewPeerASNFromNumber(65001),
+							ImportRouteMap: "red-hostsession-import",
+							ExportRouteMap: "red-hostsession-export",
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				PrefixLists: []frr.PrefixList{
+					{
+						Name: "red-hostsession-import",
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "deny", Prefix: "10.100.10.0/24", LE: new(int32(32))},
+							{Seq: 10, Action: "permit", Prefix: "10.100.0.0/16", GE: new(int32(24))},
+						},
+					},
+					{
+						Name: "red-hostsession-import",
+						IPv6: true,
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "permit", Prefix: "2001:db8:100::/48", GE: new(int32(64))},
+						},
+					},
+					{
+						Name: "red-hostsession-export",
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "permit", Prefix: "0.0.0.0/0"},
+						},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "red-hostsession-import",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "red-hostsession-import"}},
+							{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{IPv6PrefixList: "red-hostsession-import"}},
+						},
+					},
+					{
+						Name: "red-hostsession-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "red-hostsession-export"}},
+						},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "multiple host sessions",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSessions: []v1alpha1.VRFHostSession{
+							{
+								Name: "metallb",
+								HostSession: v1alpha1.HostSession{
+									ASN: 64514,
+									LocalCIDR: v1alpha1.LocalCIDRConfig{
+										IPv4: new("192.168.2.0/24"),
+										IPv6: new("2001:db8:2::/64"),
+									},
+									HostASN: new(int64(64515)),
+								},
+							},
+							{
+								Name: "calico",
+								HostSession: v1alpha1.HostSession{
+									ASN: 64520,
+									LocalCIDR: v1alpha1.LocalCIDRConfig{
+										IPv4: new("192.168.3.0/24"),
+									},
+									HostASN: new(int64(64521)),
+									ExportPolicy: &v1alpha1.PrefixPolicy{
+										Rules: []v1alpha1.PrefixRule{
+											{Prefix: "0.0.0.0/0"},
+										},
+									},
+								},
+							},
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr: "192.168.2.2",
+							ID:   "192.168.2.2",
+							ASN:  mustNewPeerASNFromNumber(64515),
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr: "2001:db8:2::2",
+							ID:   "2001:db8:2::2",
+							ASN:  mustNewPeerASNFromNumber(64515),
+						},
+						ToAdvertiseIPv4: []string{},
+						ToAdvertiseIPv6: []string{"2001:db8:2::2/128"},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr:           "192.168.3.2",
+							ID:             "192.168.3.2",
+							ASN:            mustNewPeerASNFromNumber(64521),
+							LocalASN:       64520,
+							ExportRouteMap: "red-hostsession-calico-export",
+						},
+						ToAdvertiseIPv4: []string{"192.168.3.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				PrefixLists: []frr.PrefixList{
+					{
+						Name: "red-hostsession-calico-export",
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "permit", Prefix: "0.0.0.0/0"},
+						},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "red-hostsession-calico-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "red-hostsession-calico-export"}},
+						},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "host session with session options",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 64514,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN:              new(int64(64515)),
+							PasswordSecret:       new("hostsession-password"),
+							HoldTimeSeconds:      new(int64(9)),
+							KeepaliveTimeSeconds: new(int64(3)),
+							ConnectTimeSeconds:   new(int64(10)),
+							BFD: &v1alpha1.BFDSettings{
+								ReceiveInterval: new(int32(100)),
+							},
+							GracefulRestart: new(v1alpha1.GracefulRestartModeHelperOnly),
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+			},
+			secrets: map[string]corev1.Secret{
+				"hostsession-password": {
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3cret")},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr:            "192.168.2.2",
+							ID:              "192.168.2.2",
+							ASN:             mustNewPeerASNFromNumber(64515),
+							Password:        "s3cret",
+							HoldTime:        new(int64(9)),
+							KeepaliveTime:   new(int64(3)),
+							ConnectTime:     new(int64(10)),
+							BFDEnabled:      true,
+							BFDProfile:      "red-hostsession",
+							GracefulRestart: frr.NeighborGracefulRestartHelper,
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				VPNs: []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{
+					{
+						Name:            "red-hostsession",
+						ReceiveInterval: new(int32(100)),
+					},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "host session with missing password secret",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 64514,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN:        new(int64(64515)),
+							PasswordSecret: new("hostsession-password"),
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			wantErr:       true,
+		},
+		{
+			name:      "L3VNI with static routes",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 64514,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN: new(int64(64515)),
+						},
+						StaticRoutes: []v1alpha1.StaticRoute{
+							{Prefix: "10.100.0.1/16", NextHop: new("192.168.2.2"), Advertise: new(true)},
+							{Prefix: "10.200.0.0/16", Interface: new("eth1"), Distance: new(int32(1))},
+							{Prefix: "2001:db8:100::/48", Blackhole: new(true), Distance: new(int32(250))},
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni2"},
+					Spec: v1alpha1.L3VNISpec{
+						StaticRoutes: []v1alpha1.StaticRoute{
+							{Prefix: "10.0.0.0/8", Blackhole: new(true)},
+						},
+						VRF: "blue",
+						VNI: 300,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr: "192.168.2.2",
+							ID:   "192.168.2.2",
+							ASN:  mustNewPeerASNFromNumber(64515),
+						},
+						ToAdvertiseIPv4:      []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6:      []string{},
+						ExportRTs:            []string{},
+						ImportRTs:            []string{},
+						StaticRoutesRouteMap: "red-static",
+					},
+					{
+						ASN:       65000,
+						VNI:       300,
+						VRF:       "blue",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name:    "red-static",
+						Entries: []frr.RouteMapEntry{{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{Tag: 1}}},
+					},
+				},
+				StaticRoutes: []frr.VRFStaticRoutes{
+					{
+						VRF: "red",
+						Routes: []frr.StaticRoute{
+							{Prefix: "10.100.0.0/16", NextHop: "192.168.2.2", Tag: 1},
+							{Prefix: "10.200.0.0/16", NextHop: "eth1"},
+							{Prefix: "2001:db8:100::/48", IPv6: true, NextHop: "blackhole", Distance: new(int32(250))},
+						},
+					},
+					{
+						VRF: "blue",
+						Routes: []frr.StaticRoute{
+							{Prefix: "10.0.0.0/8", NextHop: "blackhole"},
+						},
+					},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "L3VNI with aggregates",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 64514,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN: new(int64(64515)),
+						},
+						Aggregates: []v1alpha1.Aggregate{
+							{Prefix: "192.168.2.1/24", SummaryOnly: new(true)},
+							{Prefix: "2001:db8::/64", ASSet: new(true)},
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni2"},
+					Spec: v1alpha1.L3VNISpec{
+						Aggregates: []v1alpha1.Aggregate{
+							{Prefix: "192.170.1.0/24", SummaryOnly: new(false)},
+						},
+						VRF: "blue",
+						VNI: 300,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr: "192.168.2.2",
+							ID:   "192.168.2.2",
+							ASN:  mustNewPeerASNFromNumber(64515),
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+						AggregatesIPv4:  []frr.Aggregate{{Prefix: "192.168.2.0/24", SummaryOnly: true}},
+						AggregatesIPv6:  []frr.Aggregate{{Prefix: "2001:db8::/64", ASSet: true}},
+					},
+					{
+						ASN:            65000,
+						VNI:            300,
+						VRF:            "blue",
+						RouterID:       "10.0.0.1",
+						ExportRTs:      []string{},
+						ImportRTs:      []string{},
+						AggregatesIPv4: []frr.Aggregate{{Prefix: "192.170.1.0/24"}},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "L3VNIs with vrf imports",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						ImportVRFs: []v1alpha1.VRFImport{
+							{VRF: "blue"},
+							{VRF: "default", Policy: &v1alpha1.PrefixPolicy{
+								Rules: []v1alpha1.PrefixRule{
+									{Prefix: "10.1.0.0/16", LE: new(int32(32))},
+									{Prefix: "2001:db8:1::/48", LE: new(int32(128))},
+								},
+							}},
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni2"},
+					Spec: v1alpha1.L3VNISpec{
+						ExportToDefaultVRF: &v1alpha1.DefaultVRFExport{},
+						VRF:                "blue",
+						VNI:                300,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+					ImportVRFs: &frr.VRFImports{VRFs: []string{"blue"}},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:       65000,
+						VNI:       200,
+						VRF:       "red",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+						ImportVRFs: &frr.VRFImports{
+							VRFs:     []string{"blue", "default"},
+							RouteMap: "red-import-vrfs",
+						},
+					},
+					{
+						ASN:       65000,
+						VNI:       300,
+						VRF:       "blue",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				PrefixLists: []frr.PrefixList{
+					{
+						Name:    "red-import-vrfs-default",
+						Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "10.1.0.0/16", LE: new(int32(32))}},
+					},
+					{
+						Name:    "red-import-vrfs-default",
+						IPv6:    true,
+						Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "2001:db8:1::/48", LE: new(int32(128))}},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "red-import-vrfs",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{SourceVRF: "blue"}},
+							{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{SourceVRF: "default", IPv4PrefixList: "red-import-vrfs-default"}},
+							{Seq: 30, Action: "permit", Match: frr.RouteMapMatch{SourceVRF: "default", IPv6PrefixList: "red-import-vrfs-default"}},
+						},
+					},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "host session with default originate",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN: 64514,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							HostASN: new(int64(64515)),
+							DefaultOriginate: &v1alpha1.DefaultOriginate{
+								IPFamily:          new(v1alpha1.IPFamilyIPv4),
+								ConditionPrefixes: []string{"0.0.0.0/0", "2001:db8::/32"},
+								OnlyDefaultRoute:  new(true),
+							},
+						},
+						VRF: "red",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      64514,
+						VNI:      200,
+						VRF:      "red",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr:           "192.168.2.2",
+							ID:             "192.168.2.2",
+							ASN:            mustNewPeerASNFromNumber(64515),
+							ExportRouteMap: "red-hostsession-export",
+							DefaultOriginate: &frr.DefaultOriginate{
+								IPv4:     true,
+								RouteMap: "red-hostsession-default-originate",
+							},
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				PrefixLists: []frr.PrefixList{
+					{
+						Name:    "red-hostsession-default-originate",
+						Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "0.0.0.0/0"}},
+					},
+					{
+						Name:    "red-hostsession-default-originate",
+						IPv6:    true,
+						Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "2001:db8::/32"}},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name:    "red-hostsession-export",
+						Entries: []frr.RouteMapEntry{{Seq: 10, Action: "deny"}},
+					},
+					{
+						Name: "red-hostsession-default-originate",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "red-hostsession-default-originate"}},
+							{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{IPv6PrefixList: "red-hostsession-default-originate"}},
+						},
+					},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "L3 passthrough with import policy",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{},
+			l3Passthrough: []v1alpha1.L3Passthrough{
+				{
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							HostASN: new(int64(65001)),
+							ASN:     65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							ImportPolicy: &v1alpha1.PrefixPolicy{
+								Rules: []v1alpha1.PrefixRule{
+									{Prefix: "192.170.0.0/16", LE: new(int32(24))},
+								},
+							},
+						},
+					},
+				},
+			},
+			logLevel: "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN:    65000,
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name:                  "65001@192.168.1.1",
+							ASN:                   mustNewPeerASNFromNumber(65001),
+							Addr:                  "192.168.1.1",
+							ID:                    "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+							EBGPMultiHop:          false,
+						},
+					},
+				},
+				Passthrough: &frr.PassthroughConfig{
+					LocalNeighborV4: &frr.NeighborConfig{
+						ASN:            mustNewPeerASNFromNumber(65001),
+						Addr:           "192.168.2.2",
+						ID:             "192.168.2.2",
+						ConnectTime:    new(int64(5)),
+						ImportRouteMap: "passthrough-import",
+					},
+					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+					ToAdvertiseIPv6: []string{},
+				},
+				PrefixLists: []frr.PrefixList{
+					{
+						Name: "passthrough-import",
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "permit", Prefix: "192.170.0.0/16", LE: new(int32(24))},
+						},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "passthrough-import",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "passthrough-import"}},
+						},
+					},
+				},
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "l3vni with communities",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VRF: "red",
+						VNI: 200,
+						Communities: &v1alpha1.VRFCommunities{
+							Export: &v1alpha1.Communities{
+								Standard: []string{"64512:100"},
+								Extended: []v1alpha1.ExtendedCommunity{
+									{Type: v1alpha1.ExtendedCommunitySiteOfOrigin, Value: "64512:1"},
+								},
+							},
+							ExportPrefixes: []v1alpha1.PrefixCommunities{
+								{
+									Prefix:      "192.168.10.1/24",
+									Communities: v1alpha1.Communities{Standard: []string{"64512:100", "64512:200"}},
+								},
+								{
+									Prefix:      "2001:db8::/64",
+									Communities: v1alpha1.Communities{Large: []string{"64512:1:200"}},
+								},
+							},
+							ImportMatch: &v1alpha1.Communities{
+								Standard: []string{"64512:300"},
+								Large:    []string{"64512:1:300"},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni2"},
+					Spec: v1alpha1.L3VNISpec{
+						VRF: "blue",
+						VNI: 300,
+						Communities: &v1alpha1.VRFCommunities{
+							ImportMatch: &v1alpha1.Communities{
+								Extended: []v1alpha1.ExtendedCommunity{
+									{Type: v1alpha1.ExtendedCommunityRouteTarget, Value: "64512:300"},
+								},
+							},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+					EVPNImportRouteMap: "evpn-import",
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:            65000,
+						VNI:            200,
+						VRF:            "red",
+						RouterID:       "10.0.0.1",
+						ExportRTs:      []string{},
+						ImportRTs:      []string{},
+						ExportRouteMap: "red-fabric-export",
+					},
+					{
+						ASN:       65000,
+						VNI:       300,
+						VRF:       "blue",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+					},
+				},
+				PrefixLists: []frr.PrefixList{
+					{
+						Name: "red-fabric-export-1",
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "permit", Prefix: "192.168.10.0/24", LE: new(int32(32))},
+						},
+					},
+					{
+						Name: "red-fabric-export-2",
+						IPv6: true,
+						Entries: []frr.PrefixListEntry{
+							{Seq: 5, Action: "permit", Prefix: "2001:db8::/64", LE: new(int32(128))},
+						},
+					},
+				},
+				CommunityLists: []frr.CommunityList{
+					{
+						Name:    "red-fabric-import",
+						Type:    frr.StandardCommunityList,
+						Entries: []frr.CommunityListEntry{{Seq: 5, Value: "64512:300"}},
+					},
+					{
+						Name:    "red-fabric-import",
+						Type:    frr.LargeCommunityList,
+						Entries: []frr.CommunityListEntry{{Seq: 5, Value: "64512:1:300"}},
+					},
+					{
+						Name:    "blue-fabric-import",
+						Type:    frr.ExtCommunityList,
+						Entries: []frr.CommunityListEntry{{Seq: 5, Value: "rt 64512:300"}},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "red-fabric-export",
+						Entries: []frr.RouteMapEntry{
+							{
+								Seq: 10, Action: "permit",
+								Match: frr.RouteMapMatch{IPv4PrefixList: "red-fabric-export-1"},
+								Set: frr.RouteMapSet{
+									Communities:     []string{"64512:100", "64512:200"},
+									ExtCommunitySoO: []string{"64512:1"},
+								},
+							},
+							{
+								Seq: 20, Action: "permit",
+								Match: frr.RouteMapMatch{IPv6PrefixList: "red-fabric-export-2"},
+								Set: frr.RouteMapSet{
+									Communities:      []string{"64512:100"},
+									LargeCommunities: []string{"64512:1:200"},
+									ExtCommunitySoO:  []string{"64512:1"},
+								},
+							},
+							{
+								Seq: 30, Action: "permit",
+								Set: frr.RouteMapSet{
+									Communities:     []string{"64512:100"},
+									ExtCommunitySoO: []string{"64512:1"},
+								},
+							},
+						},
+					},
+					{
+						Name: "evpn-import",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{CommunityList: "red-fabric-import", EVPNVNI: 200}},
+							{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{LargeCommunityList: "red-fabric-import", EVPNVNI: 200}},
+							{Seq: 30, Action: "deny", Match: frr.RouteMapMatch{EVPNRouteType: "prefix", EVPNVNI: 200}},
+							{Seq: 40, Action: "permit", Match: frr.RouteMapMatch{ExtCommunityList: "blue-fabric-import", EVPNVNI: 300}},
+							{Seq: 50, Action: "deny", Match: frr.RouteMapMatch{EVPNRouteType: "prefix", EVPNVNI: 300}},
+							{Seq: 65535, Action: "permit"},
+						},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "l3vpn with communities",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vpns: []v1alpha1.L3VPN{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vpn1"},
+					Spec: v1alpha1.L3VPNSpec{
+						VRF:              "red",
+						RDAssignedNumber: 200,
+						ImportRTs:        []v1alpha1.RouteTarget{"65000:200"},
+						Communities: &v1alpha1.VRFCommunities{
+							Export:      &v1alpha1.Communities{Large: []string{"64512:1:100"}},
+							ImportMatch: &v1alpha1.Communities{Standard: []string{"64512:300", "64512:301"}},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN:    65000,
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{},
+				VPNs: []frr.L3VPNConfig{
+					{
+						ASN:                65000,
+						VRF:                "red",
+						RouterID:           "10.0.0.1",
+						ExportRTs:          []string{"65000:200"},
+						ImportRTs:          []string{"65000:200"},
+						RouteDistinguisher: "10.0.0.1:200",
+						ExportRouteMap:     "red-fabric-export",
+						ImportRouteMap:     "red-fabric-import",
+					},
+				},
+				CommunityLists: []frr.CommunityList{
+					{
+						Name: "red-fabric-import",
+						Type: frr.StandardCommunityList,
+						Entries: []frr.CommunityListEntry{
+							{Seq: 5, Value: "64512:300"},
+							{Seq: 10, Value: "64512:301"},
+						},
+					},
+				},
+				RouteMaps: []frr.RouteMap{
+					{
+						Name: "red-fabric-export",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Set: frr.RouteMapSet{LargeCommunities: []string{"64512:1:100"}}},
+						},
+					},
+					{
+						Name: "red-fabric-import",
+						Entries: []frr.RouteMapEntry{
+							{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{CommunityList: "red-fabric-import"}},
+						},
 					},
 				},
-				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
 				Loglevel:    "debug",
 			},
 			wantErr: false,
 		},
 		{
-			name:      "hostsession internal",
+			name:      "underlay and l3vni with multipath",
 			nodeIndex: 0,
 			underlays: []v1alpha1.Underlay{
 				{
@@ -955,6 +2396,13 @@ func TestAPItoFRR(t *testing.T) {
 						},
 						RouterIDCIDR: new("10.0.0.0/24"),
 						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						Multipath: &v1alpha1.UnderlayMultipathConfig{
+							MultipathConfig: v1alpha1.MultipathConfig{
+								EBGPPaths:   new(int32(2)),
+								ASPathRelax: new(true),
+							},
+							HashPolicy: new(v1alpha1.MultipathHashPolicyLayer4),
+						},
 					},
 				},
 			},
@@ -962,16 +2410,11 @@ func TestAPItoFRR(t *testing.T) {
 				{
 					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
 					Spec: v1alpha1.L3VNISpec{
-						HostSession: &v1alpha1.HostSession{
-							ASN: 65000,
-							LocalCIDR: v1alpha1.LocalCIDRConfig{
-								IPv4: new("192.168.2.0/24"),
-							},
-							HostASN:  new(int64(0)),
-							HostType: new("Internal"),
-						},
-						VRF: "vni1",
+						VRF: "red",
 						VNI: 200,
+						Multipath: &v1alpha1.MultipathConfig{
+							IBGPPaths: new(int32(4)),
+						},
 					},
 				},
 			},
@@ -997,22 +2440,24 @@ func TestAPItoFRR(t *testing.T) {
 							EBGPMultiHop: false,
 						},
 					},
+					Multipath: &frr.Multipath{
+						EBGPPaths:   2,
+						IBGPPaths:   64,
+						ASPathRelax: true,
+					},
 				},
 				VNIs: []frr.L3VNIConfig{
 					{
-						ASN:      65000,
-						VNI:      200,
-						VRF:      "vni1",
-						RouterID: "10.0.0.1",
-						LocalNeighbor: &frr.NeighborConfig{
-							Addr: "192.168.2.2",
-							ID:   "192.168.2.2",
-							ASN:  mustNewPeerASNFromType("Internal"),
+						ASN:       65000,
+						VNI:       200,
+						VRF:       "red",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+						Multipath: &frr.Multipath{
+							EBGPPaths: 64,
+							IBGPPaths: 4,
 						},
-						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
-						ToAdvertiseIPv6: []string{},
-						ExportRTs:       []string{},
-						ImportRTs:       []string{},
 					},
 				},
 				VPNs:        []frr.L3VPNConfig{},
@@ -2395,6 +3840,138 @@ func TestAPItoFRR(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:      "ISIS authentication, metrics and BFD",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+							AreaAuthentication: &v1alpha1.ISISAuthentication{
+								Type:           v1alpha1.ISISAuthenticationClear,
+								PasswordSecret: "isis-area",
+							},
+							DomainAuthentication: &v1alpha1.ISISAuthentication{
+								Type:           v1alpha1.ISISAuthenticationHMACMD5,
+								PasswordSecret: "isis-domain",
+							},
+							Overload: new(true),
+							Interfaces: []v1alpha1.ISISInterface{
+								{
+									Name:            "eth0",
+									IPFamily:        new(v1alpha1.IPFamilyIPv6),
+									Metric:          new(int32(100)),
+									HelloInterval:   new(int32(1)),
+									HelloMultiplier: new(int32(3)),
+									Authentication: &v1alpha1.ISISAuthentication{
+										Type:           v1alpha1.ISISAuthenticationHMACMD5,
+										PasswordSecret: "isis-area",
+									},
+									BFD: &v1alpha1.BFDSettings{ReceiveInterval: new(int32(100))},
+								},
+								{
+									Name:     "eth1",
+									IPFamily: new(v1alpha1.IPFamilyIPv6),
+									BFD:      &v1alpha1.BFDSettings{},
+								},
+							},
+						},
+					},
+				},
+			},
+			secrets: map[string]corev1.Secret{
+				"isis-area": {
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("areapass")},
+				},
+				"isis-domain": {
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("domainpass")},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					ISIS: &frr.UnderlayISIS{
+						Name:           isisProcessName,
+						Net:            frr.MustParseISISNet("49.0001.0002.0003.0004.00"),
+						AreaPassword:   &frr.ISISPassword{Password: "areapass"},
+						DomainPassword: &frr.ISISPassword{HMACMD5: true, Password: "domainpass"},
+						Overload:       true,
+						Interfaces: []frr.ISISInterface{
+							{
+								Name:            "eth0",
+								IPv6:            true,
+								Metric:          100,
+								HelloInterval:   1,
+								HelloMultiplier: 3,
+								Password:        &frr.ISISPassword{HMACMD5: true, Password: "areapass"},
+								BFD:             true,
+								BFDProfile:      "isis-eth0",
+							},
+							{Name: "eth1", IPv6: true, BFD: true},
+							{Name: "lo", IPv6: true, IsPassive: true},
+						},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				Passthrough: nil,
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{
+					{Name: "isis-eth0", ReceiveInterval: new(int32(100))},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ISIS authentication with missing secret",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+							AreaAuthentication: &v1alpha1.ISISAuthentication{
+								Type:           v1alpha1.ISISAuthenticationHMACMD5,
+								PasswordSecret: "isis-area",
+							},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			wantErr:       true,
+		},
 		{
 			name:      "ISIS enable passive only",
 			nodeIndex: 0,
@@ -2508,7 +4085,141 @@ func TestAPItoFRR(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:      "ISIS invalid net",
+			name:      "ISIS invalid net",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.000",
+							Level:   new(int32(1)),
+							Interfaces: []v1alpha1.ISISInterface{
+								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
+								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+							},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want:          frr.Config{},
+			wantErr:       true,
+		},
+		{
+			name:      "ISIS net unset",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							Level: new(int32(1)),
+							Interfaces: []v1alpha1.ISISInterface{
+								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
+								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+							},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want:          frr.Config{},
+			wantErr:       true,
+		},
+		{
+			name:      "ISIS invalid level",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+							Level:   new(int32(3)),
+							Interfaces: []v1alpha1.ISISInterface{
+								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
+								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+							},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want:          frr.Config{},
+			wantErr:       true,
+		},
+		{
+			name:      "OSPF",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth10"},
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					OSPF: &frr.UnderlayOSPF{
+						Area: "0.0.0.0",
+						IPv4: true,
+						Interfaces: []frr.OSPFInterface{
+							{Name: "eth0"},
+							{Name: "eth10"},
+							{Name: "lo", IsPassive: true},
+						},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				Passthrough: nil,
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "OSPF interface overrides and message digest authentication",
 			nodeIndex: 0,
 			underlays: []v1alpha1.Underlay{
 				{
@@ -2516,48 +4227,90 @@ func TestAPItoFRR(t *testing.T) {
 						ASN:          65000,
 						RouterIDCIDR: new("10.0.0.0/24"),
 						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
-						ISIS: &v1alpha1.ISISConfig{
-							BaseNet: "49.0001.0002.0003.0004.000",
-							Level:   new(int32(1)),
-							Interfaces: []v1alpha1.ISISInterface{
-								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
-								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24", "fd00:1::/64"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth10"},
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new("10"),
+							Authentication: &v1alpha1.OSPFAuthentication{
+								Type:      v1alpha1.OSPFAuthenticationMessageDigest,
+								KeyID:     new(int32(3)),
+								KeySecret: "ospf-key",
+							},
+							Interfaces: []v1alpha1.OSPFInterface{
+								{Name: "eth0", NetworkType: new(v1alpha1.OSPFNetworkTypePointToPoint), Cost: new(int32(100))},
+								{Name: "eth1", Passive: new(true)},
 							},
 						},
 					},
 				},
 			},
+			secrets: map[string]corev1.Secret{
+				"ospf-key": {
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3cret")},
+				},
+			},
 			l3Passthrough: []v1alpha1.L3Passthrough{},
 			logLevel:      "debug",
-			want:          frr.Config{},
-			wantErr:       true,
-		},
-		{
-			name:      "ISIS net unset",
-			nodeIndex: 0,
-			underlays: []v1alpha1.Underlay{
-				{
-					Spec: v1alpha1.UnderlaySpec{
-						ASN:          65000,
-						RouterIDCIDR: new("10.0.0.0/24"),
-						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
-						ISIS: &v1alpha1.ISISConfig{
-							Level: new(int32(1)),
-							Interfaces: []v1alpha1.ISISInterface{
-								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
-								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoint: &frr.TunnelEndpoint{
+						IPv4CIDR: "192.168.1.0/32",
+						IPv6CIDR: "fd00:1::/128",
+					},
+					OSPF: &frr.UnderlayOSPF{
+						Area: "10",
+						IPv4: true,
+						IPv6: true,
+						Interfaces: []frr.OSPFInterface{
+							{Name: "eth0", NetworkType: "point-to-point", Cost: 100},
+							{Name: "eth1", IsPassive: true},
+							{Name: "eth10"},
+							{Name: "lo", IsPassive: true},
+						},
+						Authentication: &frr.OSPFAuthentication{
+							MessageDigest: true,
+							KeyID:         3,
+							Key:           "s3cret",
+						},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.Unicast},
 							},
+							EBGPMultiHop: false,
 						},
 					},
 				},
+				Passthrough: nil,
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
 			},
-			l3Passthrough: []v1alpha1.L3Passthrough{},
-			logLevel:      "debug",
-			want:          frr.Config{},
-			wantErr:       true,
+			wantErr: false,
 		},
 		{
-			name:      "ISIS invalid level",
+			name:      "OSPF simple authentication with IPv6",
 			nodeIndex: 0,
 			underlays: []v1alpha1.Underlay{
 				{
@@ -2565,17 +4318,22 @@ func TestAPItoFRR(t *testing.T) {
 						ASN:          65000,
 						RouterIDCIDR: new("10.0.0.0/24"),
 						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
-						ISIS: &v1alpha1.ISISConfig{
-							BaseNet: "49.0001.0002.0003.0004.00",
-							Level:   new(int32(3)),
-							Interfaces: []v1alpha1.ISISInterface{
-								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
-								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+						OSPF: &v1alpha1.OSPFConfig{
+							IPFamily: new(v1alpha1.IPFamilyIPv6),
+							Authentication: &v1alpha1.OSPFAuthentication{
+								Type:      v1alpha1.OSPFAuthenticationSimple,
+								KeySecret: "ospf-key",
 							},
 						},
 					},
 				},
 			},
+			secrets: map[string]corev1.Secret{
+				"ospf-key": {
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3cret")},
+				},
+			},
 			l3Passthrough: []v1alpha1.L3Passthrough{},
 			logLevel:      "debug",
 			want:          frr.Config{},
@@ -3302,11 +5060,12 @@ func TestAPItoFRR(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			apiConfig := APIConfigData{
-				Underlays:     tt.underlays,
-				L3VNIs:        tt.vnis,
-				L2VNIs:        tt.l2vnis,
-				L3Passthrough: tt.l3Passthrough,
-				L3VPNs:        tt.vpns,
+				Underlays:       tt.underlays,
+				L3VNIs:          tt.vnis,
+				L2VNIs:          tt.l2vnis,
+				L3Passthrough:   tt.l3Passthrough,
+				L3VPNs:          tt.vpns,
+				PasswordSecrets: tt.secrets,
 			}
 			got, err := APItoFRR(apiConfig, tt.nodeIndex, tt.logLevel)
 			if (err != nil) != tt.wantErr {
@@ -3788,6 +5547,26 @@ func TestVrfsWithL2Gateways(t *testing.T) {
 				"red": {"192.168.0.1/24", "192.168.1.1/24", "192.168.2.1/24"},
 			},
 		},
+		{
+			name: "the dhcp relay source ip of the node is advertised",
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni100"},
+					Spec: v1alpha1.L2VNISpec{
+						RoutingDomain: l3vniRoutingDomain("red"),
+						GatewayIPs:    []string{"192.168.0.1/24"},
+						DHCPRelay: &v1alpha1.DHCPRelayConfig{
+							Servers:    []string{"10.100.0.10"},
+							SourceCIDR: "10.200.0.0/24",
+						},
+					},
+				},
+			},
+			vrfMap: vrfMap,
+			want: map[string][]string{
+				"red": {"10.200.0.1/32", "192.168.0.1/24"},
+			},
+		},
 		{
 			name: "L2VNI with gatewayIPs but no resolvable VRF errors",
 			l2vnis: []v1alpha1.L2VNI{
@@ -3806,7 +5585,7 @@ func TestVrfsWithL2Gateways(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := vrfsWithL2Gateways(tt.l2vnis, tt.vrfMap)
+			got, err := vrfsWithL2Gateways(tt.l2vnis, tt.vrfMap, 1)
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("vrfsWithL2Gateways() expected error, got nil")
@@ -3937,6 +5716,330 @@ func TestAPItoFRRListenLimit(t *testing.T) {
 	}
 }
 
+func TestAPItoFRRMulticast(t *testing.T) {
+	baseUnderlay := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+		Spec: v1alpha1.UnderlaySpec{
+			ASN: 64514,
+			Neighbors: []v1alpha1.Neighbor{
+				{ASN: new(int64(64517)), Address: new("192.168.11.2")},
+			},
+			Interfaces: []v1alpha1.UnderlayInterface{
+				{
+					Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+					NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+				},
+			},
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+		},
+	}
+	multicastL2VNI := v1alpha1.L2VNI{
+		ObjectMeta: metav1.ObjectMeta{Name: "l2", Namespace: "openperouter-system"},
+		Spec:       v1alpha1.L2VNISpec{VNI: 110, MulticastGroup: new("239.1.1.110")},
+	}
+	headEndL2VNI := v1alpha1.L2VNI{
+		ObjectMeta: metav1.ObjectMeta{Name: "l2", Namespace: "openperouter-system"},
+		Spec:       v1alpha1.L2VNISpec{VNI: 110},
+	}
+	multicast := &v1alpha1.EVPNMulticastConfig{RendezvousPoint: "10.0.0.100"}
+	pim := &frr.UnderlayPIM{
+		RendezvousPoint: "10.0.0.100",
+		Interfaces:      []string{"lo", "ipmr-lo", "eth1"},
+	}
+
+	tests := []struct {
+		name           string
+		multicast      *v1alpha1.EVPNMulticastConfig
+		l2vnis         []v1alpha1.L2VNI
+		wantPIM        *frr.UnderlayPIM
+		wantNoFlooding bool
+	}{
+		{
+			name:   "multicast disabled",
+			l2vnis: []v1alpha1.L2VNI{headEndL2VNI},
+		},
+		{
+			name:      "multicast enabled without multicast l2vnis",
+			multicast: multicast,
+			l2vnis:    []v1alpha1.L2VNI{headEndL2VNI},
+			wantPIM:   pim,
+		},
+		{
+			name:           "multicast enabled with multicast l2vnis",
+			multicast:      multicast,
+			l2vnis:         []v1alpha1.L2VNI{multicastL2VNI},
+			wantPIM:        pim,
+			wantNoFlooding: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := baseUnderlay.DeepCopy()
+			if tt.multicast != nil {
+				u.Spec.EVPN = &v1alpha1.EVPNConfig{Multicast: tt.multicast}
+			}
+
+			config := APIConfigData{
+				Underlays: []v1alpha1.Underlay{*u},
+				L2VNIs:    tt.l2vnis,
+			}
+			got, err := APItoFRR(config, 0, "")
+			if err != nil {
+				t.Fatalf("APItoFRR() unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(got.Underlay.PIM, tt.wantPIM) {
+				t.Errorf("PIM diff: %s", cmp.Diff(tt.wantPIM, got.Underlay.PIM))
+			}
+			if got.Underlay.DisableEVPNFlooding != tt.wantNoFlooding {
+				t.Errorf("DisableEVPNFlooding = %v, want %v", got.Underlay.DisableEVPNFlooding, tt.wantNoFlooding)
+			}
+		})
+	}
+}
+
+func TestAPItoFRRDupAddrDetection(t *testing.T) {
+	baseUnderlay := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+		Spec: v1alpha1.UnderlaySpec{
+			ASN: 64514,
+			Neighbors: []v1alpha1.Neighbor{
+				{ASN: new(int64(64517)), Address: new("192.168.11.2")},
+			},
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		detection     *v1alpha1.EVPNDuplicateAddressDetection
+		wantDetection *frr.DupAddrDetection
+		wantToken     string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:          "defaults",
+			detection:     &v1alpha1.EVPNDuplicateAddressDetection{},
+			wantDetection: &frr.DupAddrDetection{MaxMoves: 5, Time: 180},
+		},
+		{
+			name: "permanent freeze with clear token",
+			detection: &v1alpha1.EVPNDuplicateAddressDetection{
+				MaxMoves:         new(int32(10)),
+				TimeSeconds:      new(int32(600)),
+				Freeze:           &v1alpha1.EVPNDuplicateAddressFreeze{Permanent: new(true)},
+				ClearFrozenToken: new("first"),
+			},
+			wantDetection: &frr.DupAddrDetection{MaxMoves: 10, Time: 600, FreezePermanent: true},
+			wantToken:     "first",
+		},
+		{
+			name: "timed freeze",
+			detection: &v1alpha1.EVPNDuplicateAddressDetection{
+				Freeze: &v1alpha1.EVPNDuplicateAddressFreeze{TimeSeconds: new(int32(300))},
+			},
+			wantDetection: &frr.DupAddrDetection{MaxMoves: 5, Time: 180, FreezeTime: 300},
+		},
+		{
+			name: "disabled",
+			detection: &v1alpha1.EVPNDuplicateAddressDetection{
+				Enabled: new(false),
+			},
+			wantDetection: &frr.DupAddrDetection{Disabled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := baseUnderlay.DeepCopy()
+			if tt.detection != nil {
+				u.Spec.EVPN = &v1alpha1.EVPNConfig{DuplicateAddressDetection: tt.detection}
+			}
+
+			got, err := APItoFRR(APIConfigData{Underlays: []v1alpha1.Underlay{*u}}, 0, "")
+			if err != nil {
+				t.Fatalf("APItoFRR() unexpected error: %v", err)
+			}
+
+			if !cmp.Equal(got.Underlay.DupAddrDetection, tt.wantDetection) {
+				t.Errorf("DupAddrDetection diff: %s", cmp.Diff(tt.wantDetection, got.Underlay.DupAddrDetection))
+			}
+			if got.Underlay.DupAddrClearToken != tt.wantToken {
+				t.Errorf("DupAddrClearToken = %q, want %q", got.Underlay.DupAddrClearToken, tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestRouterAdvertisementsToFRR(t *testing.T) {
+	l2vni := func(name string, vni int32, ra *v1alpha1.RouterAdvertisementConfig) v1alpha1.L2VNI {
+		return v1alpha1.L2VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openperouter-system"},
+			Spec: v1alpha1.L2VNISpec{
+				VNI:                 vni,
+				GatewayIPs:          []string{"2001:db8::1/64"},
+				RouterAdvertisement: ra,
+			},
+		}
+	}
+	l2vnis := []v1alpha1.L2VNI{
+		l2vni("no-ra", 100, nil),
+		l2vni("disabled", 110, &v1alpha1.RouterAdvertisementConfig{Enabled: new(false)}),
+		l2vni("default", 120, &v1alpha1.RouterAdvertisementConfig{}),
+		l2vni("custom", 130, &v1alpha1.RouterAdvertisementConfig{
+			IntervalSeconds: new(int32(30)),
+			LifetimeSeconds: new(int32(0)),
+			Prefixes: []v1alpha1.RouterAdvertisementPrefix{
+				{Prefix: "2001:db8::1/64", Autonomous: new(false)},
+				{Prefix: "2001:db8:1::/64", OnLink: new(false), Autonomous: new(true)},
+			},
+			DNSServers: []string{"2001:db8::53"},
+		}),
+	}
+	custom := frr.RouterAdvertisement{
+		Interval: 30,
+		Lifetime: new(int32(0)),
+		Prefixes: []frr.RouterAdvertisementPrefix{
+			{Prefix: "2001:db8::/64", NoAutoconfig: true},
+			{Prefix: "2001:db8:1::/64", OffLink: true},
+		},
+		DNSServers: []string{"2001:db8::53"},
+	}
+	withInterface := func(ra frr.RouterAdvertisement, name string) frr.RouterAdvertisement {
+		ra.Interface = name
+		return ra
+	}
+
+	tests := []struct {
+		name string
+		evpn *v1alpha1.EVPNConfig
+		want []frr.RouterAdvertisement
+	}{
+		{
+			name: "per vni bridges",
+			want: []frr.RouterAdvertisement{
+				{Interface: "br-pe-120"},
+				withInterface(custom, "br-pe-130"),
+			},
+		},
+		{
+			name: "vlan aware bridge",
+			evpn: &v1alpha1.EVPNConfig{BridgeMode: new(v1alpha1.EVPNBridgeModeVLANAware)},
+			want: []frr.RouterAdvertisement{
+				{Interface: "svi120"},
+				withInterface(custom, "svi130"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := routerAdvertisementsToFRR(l2vnis, tt.evpn)
+			if err != nil {
+				t.Fatalf("routerAdvertisementsToFRR() unexpected error: %v", err)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("router advertisements diff: %s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestAPItoFRRIRB(t *testing.T) {
+	underlay := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+		Spec: v1alpha1.UnderlaySpec{
+			ASN: 64514,
+			Neighbors: []v1alpha1.Neighbor{
+				{ASN: new(int64(64517)), Address: new("192.168.11.2")},
+			},
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+		},
+	}
+	l3vni := v1alpha1.L3VNI{
+		ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "openperouter-system"},
+		Spec:       v1alpha1.L3VNISpec{VRF: "red", VNI: 100},
+	}
+	l2vni := func(name string, vni int32, gateway string, mode *v1alpha1.IRBMode, adv *v1alpha1.EVPNAdvertisementConfig) v1alpha1.L2VNI {
+		return v1alpha1.L2VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openperouter-system"},
+			Spec: v1alpha1.L2VNISpec{
+				VNI: vni,
+				RoutingDomain: &v1alpha1.RoutingDomain{
+					Type:    v1alpha1.RoutingDomainTypeL3VNI,
+					L3VNI:   &v1alpha1.L3VNIReference{Name: "red"},
+					IRBMode: mode,
+				},
+				GatewayIPs:        []string{gateway},
+				EVPNAdvertisement: adv,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		l2vnis               []v1alpha1.L2VNI
+		wantPrefixRoutesOnly bool
+		wantL2VNIs           []frr.L2VNIConfig
+	}{
+		{
+			name:   "symmetric by default",
+			l2vnis: []v1alpha1.L2VNI{l2vni("l2", 110, "192.168.10.1/24", nil, nil)},
+		},
+		{
+			name:   "explicit symmetric",
+			l2vnis: []v1alpha1.L2VNI{l2vni("l2", 110, "192.168.10.1/24", new(v1alpha1.IRBModeSymmetric), nil)},
+		},
+		{
+			name: "asymmetric",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("l2", 110, "192.168.10.1/24", new(v1alpha1.IRBModeAsymmetric), nil),
+				l2vni("l2-2", 120, "192.168.20.1/24", new(v1alpha1.IRBModeAsymmetric), nil),
+			},
+			wantPrefixRoutesOnly: true,
+		},
+		{
+			name: "evpn advertisement flags",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("none", 110, "192.168.10.1/24", nil, &v1alpha1.EVPNAdvertisementConfig{DefaultGateway: new(false)}),
+				l2vni("default-gw", 120, "192.168.20.1/24", nil, &v1alpha1.EVPNAdvertisementConfig{DefaultGateway: new(true)}),
+				l2vni("svi-ip", 130, "192.168.30.1/24", nil, &v1alpha1.EVPNAdvertisementConfig{SVIIP: new(true)}),
+			},
+			wantL2VNIs: []frr.L2VNIConfig{
+				{VNI: 120, AdvertiseDefaultGW: true},
+				{VNI: 130, AdvertiseSVIIP: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := APIConfigData{
+				Underlays: []v1alpha1.Underlay{underlay},
+				L3VNIs:    []v1alpha1.L3VNI{l3vni},
+				L2VNIs:    tt.l2vnis,
+			}
+			got, err := APItoFRR(config, 0, "")
+			if err != nil {
+				t.Fatalf("APItoFRR() unexpected error: %v", err)
+			}
+
+			if len(got.VNIs) != 1 {
+				t.Fatalf("expected 1 vni, got %d", len(got.VNIs))
+			}
+			if got.VNIs[0].PrefixRoutesOnly != tt.wantPrefixRoutesOnly {
+				t.Errorf("PrefixRoutesOnly = %v, want %v", got.VNIs[0].PrefixRoutesOnly, tt.wantPrefixRoutesOnly)
+			}
+			if !cmp.Equal(got.L2VNIs, tt.wantL2VNIs) {
+				t.Errorf("L2VNIs diff: %s", cmp.Diff(tt.wantL2VNIs, got.L2VNIs))
+			}
+		})
+	}
+}
+
 func TestAPItoFRRListenRange(t *testing.T) {
 	evpn := networklayerprotocol.NLP{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN}
 	ipv4 := networklayerprotocol.NLP{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+)
+
+const (
+	defaultOSPFArea  = "0.0.0.0"
+	defaultOSPFKeyID = 1
+	// maxOSPFSimpleKeyLen and maxOSPFMessageDigestKeyLen are the lengths of
+	// the authentication data of the OSPF packets.
+	maxOSPFSimpleKeyLen        = 8
+	maxOSPFMessageDigestKeyLen = 16
+)
+
+var ospfNetworkTypes = map[v1alpha1.OSPFNetworkType]string{
+	v1alpha1.OSPFNetworkTypePointToPoint: "point-to-point",
+	v1alpha1.OSPFNetworkTypeBroadcast:    "broadcast",
+}
+
+func underlayOSPFToFRR(ospfConfig *v1alpha1.OSPFConfig, interfaces []string,
+	tunnelEndpoint *v1alpha1.TunnelEndpointConfig, secrets map[string]corev1.Secret) (*frr.UnderlayOSPF, error) {
+	if ospfConfig == nil {
+		return nil, nil
+	}
+
+	family, err := ospfIPFamily(ospfConfig.IPFamily, tunnelEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always add the loopback as a passive interface, so that the tunnel
+	// endpoint addresses are advertised.
+	ospfInterfaces := map[string]frr.OSPFInterface{
+		loopbackName: {
+			Name:      loopbackName,
+			IsPassive: true,
+		},
+	}
+	for _, iface := range interfaces {
+		ospfInterfaces[iface] = frr.OSPFInterface{Name: iface}
+	}
+
+	// The OSPFInterface slice may override the settings of the loopback and
+	// of the interfaces. CEL enforces uniqueness by name.
+	for _, intf := range ospfConfig.Interfaces {
+		ospfInterface := ospfInterfaces[intf.Name]
+		ospfInterface.Name = intf.Name
+		if intf.NetworkType != nil {
+			networkType, ok := ospfNetworkTypes[*intf.NetworkType]
+			if !ok {
+				return nil, fmt.Errorf("invalid network type %q for interface %s", *intf.NetworkType, intf.Name)
+			}
+			ospfInterface.NetworkType = networkType
+		}
+		ospfInterface.Cost = ptr.Deref(intf.Cost, 0)
+		ospfInterface.IsPassive = ptr.Deref(intf.Passive, ospfInterface.IsPassive)
+		ospfInterfaces[intf.Name] = ospfInterface
+	}
+
+	authentication, err := ospfAuthenticationToFRR(ospfConfig.Authentication, family, secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &frr.UnderlayOSPF{
+		Area:           ptr.Deref(ospfConfig.Area, defaultOSPFArea),
+		IPv4:           family == ipfamily.IPv4 || family == ipfamily.DualStack,
+		IPv6:           family == ipfamily.IPv6 || family == ipfamily.DualStack,
+		Interfaces:     mapOfInterfacesToSortedList(ospfInterfaces),
+		Authentication: authentication,
+	}, nil
+}
+
+// ospfIPFamily returns the family OSPF runs for, defaulting to the one of
+// the tunnel endpoint.
+func ospfIPFamily(family *v1alpha1.IPFamily, tunnelEndpoint *v1alpha1.TunnelEndpointConfig) (ipfamily.Family, error) {
+	if family != nil {
+		switch *family {
+		case v1alpha1.IPFamilyIPv4:
+			return ipfamily.IPv4, nil
+		case v1alpha1.IPFamilyIPv6:
+			return ipfamily.IPv6, nil
+		case v1alpha1.IPFamilyDualStack:
+			return ipfamily.DualStack, nil
+		}
+		return ipfamily.Unknown, fmt.Errorf("invalid ipFamily %q", *family)
+	}
+	if tunnelEndpoint == nil || len(tunnelEndpoint.CIDRs) == 0 {
+		return ipfamily.IPv4, nil
+	}
+	res, err := ipfamily.ForCIDRStrings(tunnelEndpoint.CIDRs...)
+	if err != nil {
+		return ipfamily.Unknown, fmt.Errorf("failed to get the ip family of the tunnel endpoint: %w", err)
+	}
+	return res, nil
+}
+
+func ospfAuthenticationToFRR(authentication *v1alpha1.OSPFAuthentication, family ipfamily.Family,
+	secrets map[string]corev1.Secret) (*frr.OSPFAuthentication, error) {
+	if authentication == nil {
+		return nil, nil
+	}
+
+	key, err := sessionPassword(nil, &authentication.KeySecret, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authentication: %w", err)
+	}
+
+	switch authentication.Type {
+	case v1alpha1.OSPFAuthenticationSimple:
+		if family != ipfamily.IPv4 {
+			return nil, errors.New("simple authentication is only supported by OSPFv2, ipFamily must be IPv4")
+		}
+		if len(key) > maxOSPFSimpleKeyLen {
+			return nil, fmt.Errorf("simple authentication key must be at most %d characters long", maxOSPFSimpleKeyLen)
+		}
+		return &frr.OSPFAuthentication{Key: key}, nil
+	case v1alpha1.OSPFAuthenticationMessageDigest:
+		if len(key) > maxOSPFMessageDigestKeyLen {
+			return nil, fmt.Errorf("message digest authentication key must be at most %d characters long",
+				maxOSPFMessageDigestKeyLen)
+		}
+		return &frr.OSPFAuthentication{
+			MessageDigest: true,
+			KeyID:         ptr.Deref(authentication.KeyID, defaultOSPFKeyID),
+			Key:           key,
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid authentication type %q", authentication.Type)
+}
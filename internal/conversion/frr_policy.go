@@ -0,0 +1,414 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// hostSessionRouteMapSuffix is appended to the vrf name to build the
+	// names of the route-maps of the host session of an L3VNI / L3VPN.
+	hostSessionRouteMapSuffix = "-hostsession"
+	// passthroughRouteMapPrefix prefixes the names of the route-maps of
+	// the host session of the L3Passthrough.
+	passthroughRouteMapPrefix = "passthrough"
+	// fabricRouteMapSuffix is appended to the vrf name to build the names
+	// of the policies applied to the routes exchanged with the fabric.
+	fabricRouteMapSuffix = "-fabric"
+	// evpnImportRouteMap is the route-map applied to the EVPN routes
+	// received from the underlay neighbors.
+	evpnImportRouteMap = "evpn-import"
+	// lastRouteMapSeq is the sequence number of the catch all entries.
+	lastRouteMapSeq = 65535
+)
+
+// frrPolicies holds the prefix-lists, community-lists and route-maps
+// implementing the policies of the host sessions and of the VRFs. Those
+// are global in FRR and referenced by name from the VRF configurations.
+type frrPolicies struct {
+	prefixLists    []frr.PrefixList
+	communityLists []frr.CommunityList
+	routeMaps      []frr.RouteMap
+	// evpnImportRouteMap is the name of the route-map to apply to the
+	// EVPN routes received from the underlay neighbors, if any.
+	evpnImportRouteMap string
+}
+
+// policiesToFRR converts the host session policies, the communities, the
+// advertised static routes and the route leaking policies of the given
+// resources to FRR policies.
+func policiesToFRR(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN,
+	l3Passthroughs []v1alpha1.L3Passthrough) (frrPolicies, error) {
+	res := frrPolicies{}
+	if err := res.addHostSessionPolicies(l3vnis, l3vpns, l3Passthroughs); err != nil {
+		return frrPolicies{}, fmt.Errorf("failed to translate host session policies: %w", err)
+	}
+	if err := res.addVRFCommunities(l3vnis, l3vpns); err != nil {
+		return frrPolicies{}, fmt.Errorf("failed to translate vrf communities: %w", err)
+	}
+	res.addStaticRoutesPolicies(l3vnis, l3vpns)
+	if err := res.addVRFImportPolicies(l3vnis); err != nil {
+		return frrPolicies{}, fmt.Errorf("failed to translate vrf imports: %w", err)
+	}
+	return res, nil
+}
+
+// hostSessionRouteMapNames returns the names of the route-maps implementing
+// the import and export policies of the host session, or empty strings
+// for the policies that are not set. Route-maps are global in FRR, so
+// prefix must be unique across the host sessions.
+func hostSessionRouteMapNames(prefix string, hostSession v1alpha1.HostSession) (string, string) {
+	importName, exportName := "", ""
+	if hostSession.ImportPolicy != nil {
+		importName = prefix + "-import"
+	}
+	if hostSession.ExportPolicy != nil || onlyDefaultRoute(hostSession) {
+		exportName = prefix + "-export"
+	}
+	return importName, exportName
+}
+
+// onlyDefaultRoute tells if the host session must receive the default
+// route only.
+func onlyDefaultRoute(hostSession v1alpha1.HostSession) bool {
+	return hostSession.DefaultOriginate != nil && ptr.Deref(hostSession.DefaultOriginate.OnlyDefaultRoute, false)
+}
+
+// defaultOriginateRouteMapName returns the name of the route-map making the
+// advertisement of the default route conditional, or an empty string if
+// the default route is always advertised.
+func defaultOriginateRouteMapName(prefix string, defaultOriginate *v1alpha1.DefaultOriginate) string {
+	if defaultOriginate == nil || len(defaultOriginate.ConditionPrefixes) == 0 {
+		return ""
+	}
+	return prefix + "-default-originate"
+}
+
+// hostSessionRouteMapPrefix returns the prefix of the names of the
+// route-maps of the given host session of a VRF.
+func hostSessionRouteMapPrefix(vrf string, session v1alpha1.VRFHostSession) string {
+	if session.Name == "" {
+		return vrf + hostSessionRouteMapSuffix
+	}
+	return vrf + hostSessionRouteMapSuffix + "-" + session.Name
+}
+
+// addHostSessionPolicies converts the policies of all the host sessions
+// to the route-maps referenced by the local neighbors.
+func (p *frrPolicies) addHostSessionPolicies(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN,
+	l3Passthroughs []v1alpha1.L3Passthrough) error {
+	type hostSessionWithPrefix struct {
+		prefix      string
+		hostSession v1alpha1.HostSession
+	}
+	sessions := []hostSessionWithPrefix{}
+	for _, vni := range l3vnis {
+		for _, s := range vrfHostSessions(vni.Spec.HostSession, vni.Spec.HostSessions) {
+			sessions = append(sessions, hostSessionWithPrefix{hostSessionRouteMapPrefix(vni.Spec.VRF, s), s.HostSession})
+		}
+	}
+	for _, vpn := range l3vpns {
+		for _, s := range vrfHostSessions(vpn.Spec.HostSession, vpn.Spec.HostSessions) {
+			sessions = append(sessions, hostSessionWithPrefix{hostSessionRouteMapPrefix(vpn.Spec.VRF, s), s.HostSession})
+		}
+	}
+	if len(l3Passthroughs) > 0 {
+		sessions = append(sessions, hostSessionWithPrefix{passthroughRouteMapPrefix, l3Passthroughs[0].Spec.HostSession})
+	}
+
+	for _, s := range sessions {
+		importName, exportName := hostSessionRouteMapNames(s.prefix, s.hostSession)
+		if importName != "" {
+			if err := p.addPrefixPolicy(importName, *s.hostSession.ImportPolicy); err != nil {
+				return fmt.Errorf("invalid import policy %s: %w", importName, err)
+			}
+		}
+		switch {
+		case s.hostSession.ExportPolicy != nil:
+			if err := p.addPrefixPolicy(exportName, *s.hostSession.ExportPolicy); err != nil {
+				return fmt.Errorf("invalid export policy %s: %w", exportName, err)
+			}
+		case onlyDefaultRoute(s.hostSession):
+			// The default route bypasses the export route-map, so denying
+			// everything leaves it as the only route advertised.
+			p.routeMaps = append(p.routeMaps, frr.RouteMap{
+				Name:    exportName,
+				Entries: []frr.RouteMapEntry{{Seq: 10, Action: "deny"}},
+			})
+		}
+		if name := defaultOriginateRouteMapName(s.prefix, s.hostSession.DefaultOriginate); name != "" {
+			if err := p.addPrefixPolicy(name, conditionPrefixesPolicy(s.hostSession.DefaultOriginate.ConditionPrefixes)); err != nil {
+				return fmt.Errorf("invalid default originate condition %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// conditionPrefixesPolicy returns the policy permitting the routes with
+// exactly one of the given prefixes.
+func conditionPrefixesPolicy(prefixes []string) v1alpha1.PrefixPolicy {
+	res := v1alpha1.PrefixPolicy{}
+	for _, p := range prefixes {
+		res.Rules = append(res.Rules, v1alpha1.PrefixRule{Prefix: p})
+	}
+	return res
+}
+
+// addPrefixPolicy converts a prefix policy to a route-map matching a
+// prefix-list per address family, both named after the route-map. The
+// order of the rules is preserved via the sequence numbers of the
+// prefix-list entries.
+func (p *frrPolicies) addPrefixPolicy(name string, policy v1alpha1.PrefixPolicy) error {
+	matches, err := p.addPrefixLists(name, policy)
+	if err != nil {
+		return err
+	}
+	routeMap := frr.RouteMap{Name: name}
+	for _, match := range matches {
+		seq := 10
+		if match.IPv6PrefixList != "" {
+			seq = 20
+		}
+		routeMap.Entries = append(routeMap.Entries, frr.RouteMapEntry{
+			Seq: seq, Action: "permit", Match: match,
+		})
+	}
+	p.routeMaps = append(p.routeMaps, routeMap)
+	return nil
+}
+
+// addPrefixLists adds a prefix-list named name for each address family
+// of the rules of the policy, and returns the route-map match conditions
+// referencing them. A route matching any of the conditions is permitted
+// by the policy.
+func (p *frrPolicies) addPrefixLists(name string, policy v1alpha1.PrefixPolicy) ([]frr.RouteMapMatch, error) {
+	ipv4 := frr.PrefixList{Name: name}
+	ipv6 := frr.PrefixList{Name: name, IPv6: true}
+	for _, rule := range policy.Rules {
+		_, ipnet, err := net.ParseCIDR(rule.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix %q: %w", rule.Prefix, err)
+		}
+		entry := frr.PrefixListEntry{
+			Action: "permit",
+			Prefix: ipnet.String(),
+			GE:     rule.GE,
+			LE:     rule.LE,
+		}
+		if ptr.Deref(rule.Action, v1alpha1.PrefixRuleActionPermit) == v1alpha1.PrefixRuleActionDeny {
+			entry.Action = "deny"
+		}
+		// ge implies an le equal to the address length, and FRR does not
+		// show it in the running config. Rendering it would make every
+		// reload detect a change.
+		_, bits := ipnet.Mask.Size()
+		if entry.GE != nil && ptr.Deref(entry.LE, 0) == int32(bits) {
+			entry.LE = nil
+		}
+		list := &ipv4
+		if ipfamily.ForCIDR(ipnet) == ipfamily.IPv6 {
+			list = &ipv6
+		}
+		entry.Seq = (len(list.Entries) + 1) * 5
+		list.Entries = append(list.Entries, entry)
+	}
+
+	var res []frr.RouteMapMatch
+	if len(ipv4.Entries) > 0 {
+		p.prefixLists = append(p.prefixLists, ipv4)
+		res = append(res, frr.RouteMapMatch{IPv4PrefixList: name})
+	}
+	if len(ipv6.Entries) > 0 {
+		p.prefixLists = append(p.prefixLists, ipv6)
+		res = append(res, frr.RouteMapMatch{IPv6PrefixList: name})
+	}
+	return res, nil
+}
+
+// vrfCommunitiesRouteMapNames returns the names of the route-maps adding
+// the export communities and matching the import ones for the given vrf,
+// or empty strings for the ones that are not needed.
+func vrfCommunitiesRouteMapNames(vrf string, communities *v1alpha1.VRFCommunities) (string, string) {
+	if communities == nil {
+		return "", ""
+	}
+	exportName, importName := "", ""
+	if communities.Export != nil || len(communities.ExportPrefixes) > 0 {
+		exportName = vrf + fabricRouteMapSuffix + "-export"
+	}
+	if communities.ImportMatch != nil {
+		importName = vrf + fabricRouteMapSuffix + "-import"
+	}
+	return exportName, importName
+}
+
+// addVRFCommunities converts the communities of the given L3VNIs and L3VPNs.
+// L3VPNs filter the imported routes with a per vrf route-map, while EVPN
+// has no per vrf import policy: the type 5 routes of the L3VNIs are filtered
+// by a single route-map applied to the underlay neighbors, matching the
+// l3 vni of the routes.
+func (p *frrPolicies) addVRFCommunities(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) error {
+	evpnImport := frr.RouteMap{Name: evpnImportRouteMap}
+	for _, vni := range l3vnis {
+		exportName, importName := vrfCommunitiesRouteMapNames(vni.Spec.VRF, vni.Spec.Communities)
+		if exportName != "" {
+			if err := p.addExportCommunities(exportName, *vni.Spec.Communities); err != nil {
+				return fmt.Errorf("invalid communities for vni %s: %w", vni.Name, err)
+			}
+		}
+		if importName == "" {
+			continue
+		}
+		for _, match := range p.addCommunityLists(importName, *vni.Spec.Communities.ImportMatch) {
+			match.EVPNVNI = vni.Spec.VNI
+			evpnImport.Entries = append(evpnImport.Entries, frr.RouteMapEntry{
+				Seq: (len(evpnImport.Entries) + 1) * 10, Action: "permit", Match: match,
+			})
+		}
+		evpnImport.Entries = append(evpnImport.Entries, frr.RouteMapEntry{
+			Seq:    (len(evpnImport.Entries) + 1) * 10,
+			Action: "deny",
+			Match:  frr.RouteMapMatch{EVPNRouteType: "prefix", EVPNVNI: vni.Spec.VNI},
+		})
+	}
+	if len(evpnImport.Entries) > 0 {
+		evpnImport.Entries = append(evpnImport.Entries, frr.RouteMapEntry{Seq: lastRouteMapSeq, Action: "permit"})
+		p.routeMaps = append(p.routeMaps, evpnImport)
+		p.evpnImportRouteMap = evpnImport.Name
+	}
+
+	for _, vpn := range l3vpns {
+		exportName, importName := vrfCommunitiesRouteMapNames(vpn.Spec.VRF, vpn.Spec.Communities)
+		if exportName != "" {
+			if err := p.addExportCommunities(exportName, *vpn.Spec.Communities); err != nil {
+				return fmt.Errorf("invalid communities for vpn %s: %w", vpn.Name, err)
+			}
+		}
+		if importName == "" {
+			continue
+		}
+		importMap := frr.RouteMap{Name: importName}
+		for _, match := range p.addCommunityLists(importName, *vpn.Spec.Communities.ImportMatch) {
+			importMap.Entries = append(importMap.Entries, frr.RouteMapEntry{
+				Seq: (len(importMap.Entries) + 1) * 10, Action: "permit", Match: match,
+			})
+		}
+		p.routeMaps = append(p.routeMaps, importMap)
+	}
+	return nil
+}
+
+// addExportCommunities adds the route-map setting the export communities.
+// Each export prefix gets its own entry matching a prefix-list named after
+// the route-map and the position of the prefix, followed by an entry
+// setting the vrf wide communities on all the other routes.
+func (p *frrPolicies) addExportCommunities(name string, communities v1alpha1.VRFCommunities) error {
+	vrfWide := ptr.Deref(communities.Export, v1alpha1.Communities{})
+	routeMap := frr.RouteMap{Name: name}
+	for i, prefix := range communities.ExportPrefixes {
+		_, ipnet, err := net.ParseCIDR(prefix.Prefix)
+		if err != nil {
+			return fmt.Errorf("invalid prefix %q: %w", prefix.Prefix, err)
+		}
+		listName := fmt.Sprintf("%s-%d", name, i+1)
+		entry := frr.PrefixListEntry{Seq: 5, Action: "permit", Prefix: ipnet.String()}
+		if ones, bits := ipnet.Mask.Size(); ones < bits {
+			entry.LE = ptr.To(int32(bits))
+		}
+		list := frr.PrefixList{Name: listName, Entries: []frr.PrefixListEntry{entry}}
+		match := frr.RouteMapMatch{IPv4PrefixList: listName}
+		if ipfamily.ForCIDR(ipnet) == ipfamily.IPv6 {
+			list.IPv6 = true
+			match = frr.RouteMapMatch{IPv6PrefixList: listName}
+		}
+		p.prefixLists = append(p.prefixLists, list)
+		routeMap.Entries = append(routeMap.Entries, frr.RouteMapEntry{
+			Seq:    (i + 1) * 10,
+			Action: "permit",
+			Match:  match,
+			Set:    communitiesToRouteMapSet(vrfWide, prefix.Communities),
+		})
+	}
+	routeMap.Entries = append(routeMap.Entries, frr.RouteMapEntry{
+		Seq:    (len(routeMap.Entries) + 1) * 10,
+		Action: "permit",
+		Set:    communitiesToRouteMapSet(vrfWide),
+	})
+	p.routeMaps = append(p.routeMaps, routeMap)
+	return nil
+}
+
+// addCommunityLists adds a community-list named name for each kind of
+// community in communities, and returns the route-map match conditions
+// referencing them. A route matching any of the conditions carries at
+// least one of the communities.
+func (p *frrPolicies) addCommunityLists(name string, communities v1alpha1.Communities) []frr.RouteMapMatch {
+	var res []frr.RouteMapMatch
+	if len(communities.Standard) > 0 {
+		p.communityLists = append(p.communityLists, communityList(name, frr.StandardCommunityList, communities.Standard))
+		res = append(res, frr.RouteMapMatch{CommunityList: name})
+	}
+	if len(communities.Large) > 0 {
+		p.communityLists = append(p.communityLists, communityList(name, frr.LargeCommunityList, communities.Large))
+		res = append(res, frr.RouteMapMatch{LargeCommunityList: name})
+	}
+	if len(communities.Extended) > 0 {
+		values := make([]string, 0, len(communities.Extended))
+		for _, c := range communities.Extended {
+			values = append(values, extendedCommunityKeyword(c.Type)+" "+c.Value)
+		}
+		p.communityLists = append(p.communityLists, communityList(name, frr.ExtCommunityList, values))
+		res = append(res, frr.RouteMapMatch{ExtCommunityList: name})
+	}
+	return res
+}
+
+func communityList(name string, listType frr.CommunityListType, values []string) frr.CommunityList {
+	res := frr.CommunityList{Name: name, Type: listType}
+	for i, v := range values {
+		res.Entries = append(res.Entries, frr.CommunityListEntry{Seq: (i + 1) * 5, Value: v})
+	}
+	return res
+}
+
+// communitiesToRouteMapSet merges the given communities into the set
+// clause of a route-map entry, dropping the duplicates.
+func communitiesToRouteMapSet(communities ...v1alpha1.Communities) frr.RouteMapSet {
+	res := frr.RouteMapSet{}
+	appendUnique := func(to []string, values ...string) []string {
+		for _, v := range values {
+			if !slices.Contains(to, v) {
+				to = append(to, v)
+			}
+		}
+		return to
+	}
+	for _, c := range communities {
+		res.Communities = appendUnique(res.Communities, c.Standard...)
+		res.LargeCommunities = appendUnique(res.LargeCommunities, c.Large...)
+		for _, e := range c.Extended {
+			if e.Type == v1alpha1.ExtendedCommunitySiteOfOrigin {
+				res.ExtCommunitySoO = appendUnique(res.ExtCommunitySoO, e.Value)
+				continue
+			}
+			res.ExtCommunityRT = appendUnique(res.ExtCommunityRT, e.Value)
+		}
+	}
+	return res
+}
+
+func extendedCommunityKeyword(t v1alpha1.ExtendedCommunityType) string {
+	if t == v1alpha1.ExtendedCommunitySiteOfOrigin {
+		return "soo"
+	}
+	return "rt"
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+)
+
+// importVRFsRouteMapSuffix is appended to the vrf name to build the name of
+// the route-map filtering the routes leaked into the vrf from the others.
+const importVRFsRouteMapSuffix = "-import-vrfs"
+
+// leakSource is a VRF whose routes are leaked into another one, filtered
+// by policy when set.
+type leakSource struct {
+	vrf    string
+	policy *v1alpha1.PrefixPolicy
+}
+
+// vrfImportsToFRR returns the VRFs leaked into the given vrf, or nil if
+// there are none.
+func vrfImportsToFRR(vrf string, sources []leakSource) *frr.VRFImports {
+	if len(sources) == 0 {
+		return nil
+	}
+	res := &frr.VRFImports{}
+	for _, s := range sources {
+		res.VRFs = append(res.VRFs, s.vrf)
+		if s.policy != nil {
+			res.RouteMap = vrf + importVRFsRouteMapSuffix
+		}
+	}
+	return res
+}
+
+// l3vniLeakSources returns the VRFs the given L3VNI imports the routes from.
+func l3vniLeakSources(vni v1alpha1.L3VNI) []leakSource {
+	var res []leakSource
+	for _, i := range vni.Spec.ImportVRFs {
+		res = append(res, leakSource{vrf: i.VRF, policy: i.Policy})
+	}
+	return res
+}
+
+// defaultVRFLeakSources returns the VRFs of the L3VNIs exporting their
+// routes to the default VRF.
+func defaultVRFLeakSources(l3vnis []v1alpha1.L3VNI) []leakSource {
+	var res []leakSource
+	for _, vni := range l3vnis {
+		if vni.Spec.ExportToDefaultVRF == nil {
+			continue
+		}
+		res = append(res, leakSource{vrf: vni.Spec.VRF, policy: vni.Spec.ExportToDefaultVRF.Policy})
+	}
+	return res
+}
+
+// addVRFImportPolicies adds the route-maps filtering the routes leaked
+// between the VRFs of the given L3VNIs and the default VRF.
+func (p *frrPolicies) addVRFImportPolicies(l3vnis []v1alpha1.L3VNI) error {
+	for _, vni := range l3vnis {
+		if err := p.addVRFImportPolicy(vni.Spec.VRF, l3vniLeakSources(vni)); err != nil {
+			return fmt.Errorf("invalid vrf imports for vni %s: %w", vni.Name, err)
+		}
+	}
+	if err := p.addVRFImportPolicy(v1alpha1.DefaultVRFName, defaultVRFLeakSources(l3vnis)); err != nil {
+		return fmt.Errorf("invalid default vrf exports: %w", err)
+	}
+	return nil
+}
+
+// addVRFImportPolicy adds the route-map filtering the routes leaked into
+// vrf, if any of the sources has a policy. FRR allows a single route-map
+// for all the VRFs leaked into a given one, so the entries match the
+// source VRF of the routes, followed by the prefix-lists of its policy,
+// if any.
+func (p *frrPolicies) addVRFImportPolicy(vrf string, sources []leakSource) error {
+	imports := vrfImportsToFRR(vrf, sources)
+	if imports == nil || imports.RouteMap == "" {
+		return nil
+	}
+	routeMap := frr.RouteMap{Name: imports.RouteMap}
+	for _, s := range sources {
+		if s.policy == nil {
+			routeMap.Entries = append(routeMap.Entries, frr.RouteMapEntry{
+				Seq:    (len(routeMap.Entries) + 1) * 10,
+				Action: "permit",
+				Match:  frr.RouteMapMatch{SourceVRF: s.vrf},
+			})
+			continue
+		}
+		matches, err := p.addPrefixLists(imports.RouteMap+"-"+s.vrf, *s.policy)
+		if err != nil {
+			return fmt.Errorf("invalid policy for vrf %s: %w", s.vrf, err)
+		}
+		for _, match := range matches {
+			match.SourceVRF = s.vrf
+			routeMap.Entries = append(routeMap.Entries, frr.RouteMapEntry{
+				Seq:    (len(routeMap.Entries) + 1) * 10,
+				Action: "permit",
+				Match:  match,
+			})
+		}
+	}
+	p.routeMaps = append(p.routeMaps, routeMap)
+	return nil
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// staticRoutesRouteMapSuffix is appended to the vrf name to build the
+	// name of the route-map redistributing the advertised static routes.
+	staticRoutesRouteMapSuffix = "-static"
+	// advertisedStaticRouteTag is set on the static routes to advertise,
+	// so that the route-map can tell them from the others.
+	advertisedStaticRouteTag = uint32(1)
+	// defaultStaticRouteDistance is the distance FRR gives to static routes.
+	defaultStaticRouteDistance = int32(1)
+)
+
+// staticRoutesToFRR converts the static routes of the given L3VNIs and L3VPNs.
+func staticRoutesToFRR(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) ([]frr.VRFStaticRoutes, error) {
+	var res []frr.VRFStaticRoutes
+	add := func(vrf string, routes []v1alpha1.StaticRoute) error {
+		if len(routes) == 0 {
+			return nil
+		}
+		vrfRoutes := frr.VRFStaticRoutes{VRF: vrf}
+		for _, r := range routes {
+			frrRoute, err := staticRouteToFRR(r)
+			if err != nil {
+				return fmt.Errorf("vrf %s: %w", vrf, err)
+			}
+			vrfRoutes.Routes = append(vrfRoutes.Routes, frrRoute)
+		}
+		res = append(res, vrfRoutes)
+		return nil
+	}
+
+	for _, vni := range l3vnis {
+		if err := add(vni.Spec.VRF, vni.Spec.StaticRoutes); err != nil {
+			return nil, err
+		}
+	}
+	for _, vpn := range l3vpns {
+		if err := add(vpn.Spec.VRF, vpn.Spec.StaticRoutes); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func staticRouteToFRR(r v1alpha1.StaticRoute) (frr.StaticRoute, error) {
+	_, prefix, err := net.ParseCIDR(r.Prefix)
+	if err != nil {
+		return frr.StaticRoute{}, fmt.Errorf("invalid static route prefix %s: %w", r.Prefix, err)
+	}
+
+	res := frr.StaticRoute{
+		// FRR stores the network address of the prefix, so we render it
+		// the same way to avoid a diff on every reload.
+		Prefix: prefix.String(),
+		IPv6:   ipfamily.ForCIDR(prefix) == ipfamily.IPv6,
+	}
+	switch {
+	case r.NextHop != nil:
+		res.NextHop = *r.NextHop
+	case r.Interface != nil:
+		res.NextHop = *r.Interface
+	case ptr.Deref(r.Blackhole, false):
+		res.NextHop = "blackhole"
+	default:
+		return frr.StaticRoute{}, fmt.Errorf("static route %s: one of nextHop, interface or blackhole must be set", r.Prefix)
+	}
+	if ptr.Deref(r.Advertise, false) {
+		res.Tag = advertisedStaticRouteTag
+	}
+	// FRR does not show the default distance in its running config.
+	if ptr.Deref(r.Distance, defaultStaticRouteDistance) != defaultStaticRouteDistance {
+		res.Distance = r.Distance
+	}
+	return res, nil
+}
+
+// staticRoutesRouteMapName returns the name of the route-map redistributing
+// the advertised static routes of the vrf into BGP, or an empty string when
+// none of the routes is advertised.
+func staticRoutesRouteMapName(vrf string, routes []v1alpha1.StaticRoute) string {
+	for _, r := range routes {
+		if ptr.Deref(r.Advertise, false) {
+			return vrf + staticRoutesRouteMapSuffix
+		}
+	}
+	return ""
+}
+
+// addStaticRoutesPolicies adds the route-maps permitting the advertised
+// static routes of the given L3VNIs and L3VPNs, which are tagged by
+// staticRouteToFRR.
+func (p *frrPolicies) addStaticRoutesPolicies(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) {
+	add := func(vrf string, routes []v1alpha1.StaticRoute) {
+		name := staticRoutesRouteMapName(vrf, routes)
+		if name == "" {
+			return
+		}
+		p.routeMaps = append(p.routeMaps, frr.RouteMap{
+			Name: name,
+			Entries: []frr.RouteMapEntry{
+				{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{Tag: advertisedStaticRouteTag}},
+			},
+		})
+	}
+	for _, vni := range l3vnis {
+		add(vni.Spec.VRF, vni.Spec.StaticRoutes)
+	}
+	for _, vpn := range l3vpns {
+		add(vpn.Spec.VRF, vpn.Spec.StaticRoutes)
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+)
+
+// underlayRouteMapPrefix is prepended to the underlay name to build the
+// names of the route-maps filtering the routes advertised to its neighbors.
+const underlayRouteMapPrefix = "underlay-"
+
+// exportRouteMapNames returns the names of the route-maps filtering the
+// unicast and the EVPN routes advertised to the neighbors of the given
+// underlay, or empty strings when the node has a single underlay.
+func (u nodeUnderlays) exportRouteMapNames(underlay v1alpha1.Underlay) (string, string) {
+	if len(u) < 2 {
+		return "", ""
+	}
+	prefix := underlayRouteMapPrefix + underlay.Name
+	return prefix + "-export", prefix + "-evpn-export"
+}
+
+// setExportRouteMaps sets the route-maps filtering the routes advertised
+// to the given neighbors of the underlay.
+func (u nodeUnderlays) setExportRouteMaps(underlay v1alpha1.Underlay, neighbors []frr.NeighborConfig) {
+	unicast, evpn := u.exportRouteMapNames(underlay)
+	for i := range neighbors {
+		neighbors[i].ExportRouteMap = unicast
+		neighbors[i].EVPNExportRouteMap = evpn
+	}
+}
+
+// addUnderlayExportPolicies adds the route-maps keeping apart the underlays
+// of a node sharing the BGP instance. The neighbors of an underlay are
+// advertised its tunnel endpoint and the EVPN routes of the VNIs riding on
+// it only. The neighbors of the primary underlay are also advertised the
+// routes of the passthrough and the ones exported to the default VRF by the
+// L3VNIs riding on it.
+func (p *frrPolicies) addUnderlayExportPolicies(underlays nodeUnderlays, config APIConfigData, nodeIndex int,
+	passthrough *frr.PassthroughConfig) error {
+	for _, underlay := range underlays {
+		unicastName, evpnName := underlays.exportRouteMapNames(underlay)
+		if unicastName == "" {
+			return nil
+		}
+
+		tunnelEndpoint, err := tunnelEndpointToFRR(underlay.Spec.TunnelEndpoint, nodeIndex)
+		if err != nil {
+			return fmt.Errorf("failed to translate tunnel endpoint settings of underlay %s, err: %w",
+				underlay.Name, err)
+		}
+		toAdvertise := v1alpha1.PrefixPolicy{}
+		addPrefix := func(prefix string) {
+			if prefix != "" {
+				toAdvertise.Rules = append(toAdvertise.Rules, v1alpha1.PrefixRule{Prefix: prefix})
+			}
+		}
+		if tunnelEndpoint != nil {
+			addPrefix(tunnelEndpoint.IPv4CIDR)
+			addPrefix(tunnelEndpoint.IPv6CIDR)
+		}
+		isPrimary := underlay.Name == underlays.primary().Name
+		if isPrimary && passthrough != nil {
+			for _, prefix := range passthrough.ToAdvertiseIPv4 {
+				addPrefix(prefix)
+			}
+			for _, prefix := range passthrough.ToAdvertiseIPv6 {
+				addPrefix(prefix)
+			}
+		}
+		matches, err := p.addPrefixLists(unicastName, toAdvertise)
+		if err != nil {
+			return fmt.Errorf("invalid prefixes for underlay %s: %w", underlay.Name, err)
+		}
+		if isPrimary && passthrough != nil {
+			for _, n := range []*frr.NeighborConfig{passthrough.LocalNeighborV4, passthrough.LocalNeighborV6} {
+				if n != nil {
+					matches = append(matches, frr.RouteMapMatch{Peer: n.ID})
+				}
+			}
+		}
+		for _, vni := range underlays.l3vnisOn(underlay, config.L3VNIs) {
+			if vni.Spec.ExportToDefaultVRF != nil {
+				matches = append(matches, frr.RouteMapMatch{SourceVRF: vni.Spec.VRF})
+			}
+		}
+		p.routeMaps = append(p.routeMaps, exportRouteMap(unicastName, matches))
+
+		matches = nil
+		for _, vni := range underlays.l2vnisOn(underlay, config.L2VNIs) {
+			matches = append(matches, frr.RouteMapMatch{EVPNVNI: vni.Spec.VNI})
+		}
+		for _, vni := range underlays.l3vnisOn(underlay, config.L3VNIs) {
+			matches = append(matches, frr.RouteMapMatch{EVPNVNI: vni.Spec.VNI})
+		}
+		p.routeMaps = append(p.routeMaps, exportRouteMap(evpnName, matches))
+	}
+	return nil
+}
+
+// exportRouteMap returns a route-map permitting the routes matching any of
+// the given conditions and denying the others.
+func exportRouteMap(name string, matches []frr.RouteMapMatch) frr.RouteMap {
+	res := frr.RouteMap{Name: name}
+	for i, match := range matches {
+		res.Entries = append(res.Entries, frr.RouteMapEntry{Seq: (i + 1) * 10, Action: "permit", Match: match})
+	}
+	// The catch all entry is explicit so the route-map exists, and denies
+	// all the routes, even when nothing is advertised to the neighbors.
+	res.Entries = append(res.Entries, frr.RouteMapEntry{Seq: lastRouteMapSeq, Action: "deny"})
+	return res
+}
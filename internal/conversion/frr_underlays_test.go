@@ -0,0 +1,136 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+)
+
+func TestAddUnderlayExportPolicies(t *testing.T) {
+	fabricA := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+		Spec: v1alpha1.UnderlaySpec{
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.64.0.0/24"}},
+		},
+	}
+	fabricB := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+		Spec: v1alpha1.UnderlaySpec{
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24", "fd00:65::/64"}},
+		},
+	}
+	config := APIConfigData{
+		L3VNIs: []v1alpha1.L3VNI{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "red"},
+				Spec: v1alpha1.L3VNISpec{
+					VRF:                "red",
+					VNI:                100,
+					ExportToDefaultVRF: &v1alpha1.DefaultVRFExport{},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "blue"},
+				Spec: v1alpha1.L3VNISpec{
+					VRF:                "blue",
+					VNI:                200,
+					Underlay:           new("fabric-b"),
+					ExportToDefaultVRF: &v1alpha1.DefaultVRFExport{},
+				},
+			},
+		},
+		L2VNIs: []v1alpha1.L2VNI{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "l2blue"},
+				Spec:       v1alpha1.L2VNISpec{VNI: 210, Underlay: new("fabric-b")},
+			},
+		},
+	}
+	passthrough := &frr.PassthroughConfig{
+		LocalNeighborV4: &frr.NeighborConfig{ID: "192.169.10.1"},
+		ToAdvertiseIPv4: []string{"192.169.10.0/24"},
+	}
+
+	t.Run("single underlay", func(t *testing.T) {
+		policies := frrPolicies{}
+		underlays := sortUnderlays([]v1alpha1.Underlay{fabricA})
+		if err := policies.addUnderlayExportPolicies(underlays, config, 1, passthrough); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(frrPolicies{}, policies, cmp.AllowUnexported(frrPolicies{})); diff != "" {
+			t.Errorf("expected no policies (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("multiple underlays", func(t *testing.T) {
+		policies := frrPolicies{}
+		underlays := sortUnderlays([]v1alpha1.Underlay{fabricB, fabricA})
+		if err := policies.addUnderlayExportPolicies(underlays, config, 1, passthrough); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := frrPolicies{
+			prefixLists: []frr.PrefixList{
+				{
+					Name: "underlay-fabric-a-export",
+					Entries: []frr.PrefixListEntry{
+						{Seq: 5, Action: "permit", Prefix: "100.64.0.1/32"},
+						{Seq: 10, Action: "permit", Prefix: "192.169.10.0/24"},
+					},
+				},
+				{
+					Name:    "underlay-fabric-b-export",
+					Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "100.65.0.1/32"}},
+				},
+				{
+					Name:    "underlay-fabric-b-export",
+					IPv6:    true,
+					Entries: []frr.PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "fd00:65::1/128"}},
+				},
+			},
+			routeMaps: []frr.RouteMap{
+				{
+					Name: "underlay-fabric-a-export",
+					Entries: []frr.RouteMapEntry{
+						{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "underlay-fabric-a-export"}},
+						{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{Peer: "192.169.10.1"}},
+						{Seq: 30, Action: "permit", Match: frr.RouteMapMatch{SourceVRF: "red"}},
+						{Seq: 65535, Action: "deny"},
+					},
+				},
+				{
+					Name: "underlay-fabric-a-evpn-export",
+					Entries: []frr.RouteMapEntry{
+						{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{EVPNVNI: 100}},
+						{Seq: 65535, Action: "deny"},
+					},
+				},
+				{
+					Name: "underlay-fabric-b-export",
+					Entries: []frr.RouteMapEntry{
+						{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{IPv4PrefixList: "underlay-fabric-b-export"}},
+						{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{IPv6PrefixList: "underlay-fabric-b-export"}},
+						{Seq: 30, Action: "permit", Match: frr.RouteMapMatch{SourceVRF: "blue"}},
+						{Seq: 65535, Action: "deny"},
+					},
+				},
+				{
+					Name: "underlay-fabric-b-evpn-export",
+					Entries: []frr.RouteMapEntry{
+						{Seq: 10, Action: "permit", Match: frr.RouteMapMatch{EVPNVNI: 210}},
+						{Seq: 20, Action: "permit", Match: frr.RouteMapMatch{EVPNVNI: 200}},
+						{Seq: 65535, Action: "deny"},
+					},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, policies, cmp.AllowUnexported(frrPolicies{})); diff != "" {
+			t.Errorf("unexpected policies (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/netip"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/cniinvoker"
+	"github.com/openperouter/openperouter/internal/dhcprelay"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
 	"github.com/openperouter/openperouter/internal/ipam"
 	"github.com/openperouter/openperouter/internal/ipfamily"
@@ -33,7 +36,8 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 		return HostConfigData{}, err
 	}
 
-	underlay := apiConfig.Underlays[0]
+	underlays := sortUnderlays(apiConfig.Underlays)
+	underlay := underlays.primary()
 
 	if err := validateTunnelEndpointForHostConfig(underlay.Spec.TunnelEndpoint, apiConfig); err != nil {
 		return HostConfigData{}, err
@@ -58,6 +62,7 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 	if underlay.Spec.TunnelEndpoint == nil {
 		return HostConfigData{
 			Underlay: hostnetwork.UnderlayParams{
+				Name:               underlay.Name,
 				TargetNS:           targetNS,
 				UnderlayInterfaces: underlayInterfaces,
 			},
@@ -76,9 +81,19 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 		return HostConfigData{}, err
 	}
 
+	additionalUnderlays, err := additionalUnderlaysToHost(underlays, targetNS, nodeIndex)
+	if err != nil {
+		return HostConfigData{}, err
+	}
+	tunnelEndpoints := map[string]hostnetwork.UnderlayTunnelEndpointParams{underlay.Name: underlayConfigTunnelEndpoint}
+	for _, u := range additionalUnderlays {
+		tunnelEndpoints[u.Name] = *u.TunnelEndpoint
+	}
+
 	l3VNIs, err := l3vnisToHost(
 		apiConfig.L3VNIs,
-		underlayConfigTunnelEndpoint,
+		underlays,
+		tunnelEndpoints,
 		targetNS,
 		nodeIndex)
 	if err != nil {
@@ -88,9 +103,11 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 	vrfMap := createVRFMap(apiConfig.L3VNIs, apiConfig.L3VPNs)
 	l2VNIs, err := l2vnisToHost(
 		apiConfig.L2VNIs,
-		underlayConfigTunnelEndpoint,
+		underlays,
+		tunnelEndpoints,
 		targetNS,
-		vrfMap)
+		vrfMap,
+		nodeIndex)
 	if err != nil {
 		return HostConfigData{}, fmt.Errorf("failed to translate L2VNIs to host, err: %w", err)
 	}
@@ -104,8 +121,11 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 		return HostConfigData{}, fmt.Errorf("failed to translate L3VPNs to host, err: %w", err)
 	}
 
+	dhcpRelays := dhcpRelaysToHost(apiConfig.L2VNIs, l2VNIs)
+
 	return HostConfigData{
 		Underlay: hostnetwork.UnderlayParams{
+			Name:               underlay.Name,
 			TargetNS:           targetNS,
 			UnderlayInterfaces: underlayInterfaces,
 			TunnelEndpoint:     &underlayConfigTunnelEndpoint,
@@ -114,9 +134,40 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 		L2VNIs:        l2VNIs,
 		L3VPNs:        l3VPNs,
 		L3Passthrough: l3Passthrough,
+		DHCPRelays:    dhcpRelays,
+
+		AdditionalUnderlays: additionalUnderlays,
 	}, nil
 }
 
+// additionalUnderlaysToHost returns the host configuration of the underlays
+// other than the primary one, which all have a tunnel endpoint.
+func additionalUnderlaysToHost(underlays nodeUnderlays, targetNS string,
+	nodeIndex int) ([]hostnetwork.UnderlayParams, error) {
+	var res []hostnetwork.UnderlayParams
+	for _, underlay := range underlays.additional() {
+		if underlay.Spec.TunnelEndpoint == nil {
+			return nil, fmt.Errorf("underlay %s: tunnel endpoint must be specified", underlay.Name)
+		}
+		interfaces, err := underlayInterfacesToHost(underlay.Spec.Interfaces)
+		if err != nil {
+			return nil, fmt.Errorf("underlay %s: %w", underlay.Name, err)
+		}
+		tunnelEndpoint, err := tunnelEndpointToHost(underlay.Spec.TunnelEndpoint, nodeIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate tunnel endpoint configuration of underlay %s to host, err: %w",
+				underlay.Name, err)
+		}
+		res = append(res, hostnetwork.UnderlayParams{
+			Name:               underlay.Name,
+			TargetNS:           targetNS,
+			UnderlayInterfaces: interfaces,
+			TunnelEndpoint:     &tunnelEndpoint,
+		})
+	}
+	return res, nil
+}
+
 // validateTunnelEndpointForHostConfig makes sure that whenever L3VNIs, L2VNIs or L3VPNs are set, the tunnelEndpoint
 // must be configured, too.
 func validateTunnelEndpointForHostConfig(tunnelEndpoint *v1alpha1.TunnelEndpointConfig, apiConfig APIConfigData) error {
@@ -164,7 +215,7 @@ func passthroughConfigToHost(l3Passthrough []v1alpha1.L3Passthrough, targetNS st
 }
 
 func validateOverlayPrerequisitesForHost(config APIConfigData, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams) error {
-	underlay := config.Underlays[0]
+	underlay := PrimaryUnderlay(config.Underlays)
 
 	var errs []error
 	if len(config.L3VNIs) > 0 && tunnelEndpoint.IPv4CIDR == "" && tunnelEndpoint.IPv6CIDR == "" {
@@ -215,11 +266,16 @@ func tunnelEndpointToHost(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, n
 	return tunnelEndpoint, nil
 }
 
-func l3vnisToHost(l3vnis []v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams,
+func l3vnisToHost(l3vnis []v1alpha1.L3VNI, underlays nodeUnderlays,
+	tunnelEndpoints map[string]hostnetwork.UnderlayTunnelEndpointParams,
 	targetNS string, nodeIndex int) ([]hostnetwork.L3VNIParams, error) {
 	hostL3VNIs := []hostnetwork.L3VNIParams{}
 	for _, l3vni := range l3vnis {
-		hostL3VNI, err := l3vniToHost(l3vni, tunnelEndpoint, targetNS, nodeIndex)
+		underlay, err := underlays.byName(l3vni.Spec.Underlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate L3VNI %s, err: %w", l3vni.Name, err)
+		}
+		hostL3VNI, err := l3vniToHost(l3vni, tunnelEndpoints[underlay.Name], targetNS, nodeIndex, underlay.Spec.EVPN)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate L3VNI %s, err: %w", l3vni.Name, err)
 		}
@@ -228,12 +284,15 @@ func l3vnisToHost(l3vnis []v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTu
 	return hostL3VNIs, nil
 }
 
-func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams, targetNS string, nodeIndex int) (hostnetwork.L3VNIParams, error) {
+func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams, targetNS string, nodeIndex int,
+	evpn *v1alpha1.EVPNConfig) (hostnetwork.L3VNIParams, error) {
 	vtepIP, err := resolveVTEPIP(l3vni.Spec.UnderlayAddressFamily, tunnelEndpoint)
 	if err != nil {
 		return hostnetwork.L3VNIParams{}, fmt.Errorf("L2VNI %s: %w", l3vni.Name, err)
 	}
 
+	tunnel := vxlanTunnelToHost(l3vni.Spec.VXLanTunnel, evpn)
+
 	hostL3VNI := hostnetwork.L3VNIParams{
 		Name: l3vni.Name,
 		VNIParams: hostnetwork.VNIParams{
@@ -242,26 +301,19 @@ func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnel
 			VTEPIP:    vtepIP,
 			VNI:       l3vni.Spec.VNI,
 			VXLanPort: vxlanPort(l3vni.Spec.VXLanPort),
+			Tunnel:    tunnel,
 		},
 	}
-	if l3vni.Spec.HostSession == nil {
-		return hostL3VNI, nil
-	}
-
-	vethIPs, err := ipam.VethIPsFromPool(
-		l3vni.Spec.HostSession.LocalCIDR.IPv4,
-		l3vni.Spec.HostSession.LocalCIDR.IPv6,
-		nodeIndex)
+	linkIPs, err := hostSessionsLinkIPs(vrfHostSessions(l3vni.Spec.HostSession, l3vni.Spec.HostSessions), nodeIndex)
 	if err != nil {
-		return hostnetwork.L3VNIParams{}, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d, err: %w",
-			l3vni.Spec.HostSession.LocalCIDR, nodeIndex, err)
+		return hostnetwork.L3VNIParams{}, err
 	}
-
-	hostL3VNI.LinkIPs = &hostnetwork.LinkIPs{
-		HostIPv4: ipNetToString(vethIPs.Ipv4.HostSide),
-		NSIPv4:   ipNetToString(vethIPs.Ipv4.PeSide),
-		HostIPv6: ipNetToString(vethIPs.Ipv6.HostSide),
-		NSIPv6:   ipNetToString(vethIPs.Ipv6.PeSide),
+	if len(linkIPs) == 0 {
+		return hostL3VNI, nil
+	}
+	hostL3VNI.LinkIPs = &linkIPs[0]
+	if len(linkIPs) > 1 {
+		hostL3VNI.AdditionalLinkIPs = linkIPs[1:]
 	}
 
 	return hostL3VNI, nil
@@ -269,13 +321,19 @@ func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnel
 
 func l2vnisToHost(
 	l2vnis []v1alpha1.L2VNI,
-	tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams,
+	underlays nodeUnderlays,
+	tunnelEndpoints map[string]hostnetwork.UnderlayTunnelEndpointParams,
 	targetNS string,
 	vrfMap map[string]string,
+	nodeIndex int,
 ) ([]hostnetwork.L2VNIParams, error) {
 	hostL2VNIs := []hostnetwork.L2VNIParams{}
 	for _, l2vni := range l2vnis {
-		vni, err := l2vniToHost(l2vni, tunnelEndpoint, targetNS, vrfMap)
+		underlay, err := underlays.byName(l2vni.Spec.Underlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate L2VNI %s, err: %w", l2vni.Name, err)
+		}
+		vni, err := l2vniToHost(l2vni, tunnelEndpoints[underlay.Name], targetNS, vrfMap, underlay.Spec.EVPN, nodeIndex)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate L2VNI %s, err: %w", l2vni.Name, err)
 		}
@@ -289,12 +347,16 @@ func l2vniToHost(
 	tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams,
 	targetNS string,
 	vrfMap map[string]string,
+	evpn *v1alpha1.EVPNConfig,
+	nodeIndex int,
 ) (hostnetwork.L2VNIParams, error) {
 	vtepIP, err := resolveVTEPIP(l2vni.Spec.UnderlayAddressFamily, tunnelEndpoint)
 	if err != nil {
 		return hostnetwork.L2VNIParams{}, fmt.Errorf("L2VNI %s: %w", l2vni.Name, err)
 	}
 
+	tunnel := vxlanTunnelToHost(l2vni.Spec.VXLanTunnel, evpn)
+
 	hostL2VNI := hostnetwork.L2VNIParams{
 		Name: l2vni.Name,
 		VNIParams: hostnetwork.VNIParams{
@@ -302,15 +364,33 @@ func l2vniToHost(
 			VTEPIP:    vtepIP,
 			VNI:       l2vni.Spec.VNI,
 			VXLanPort: vxlanPort(l2vni.Spec.VXLanPort),
+			Tunnel:    tunnel,
+
+			MulticastGroup: ptr.Deref(l2vni.Spec.MulticastGroup, ""),
 		},
+		NeighSuppression: l2vni.Spec.ARPNDSuppression,
+		ProxyARP:         l2vni.Spec.ProxyARP,
 	}
 	if hasRoutingDomain(l2vni) {
 		hostL2VNI.VRF = resolveVRFForL2VNI(l2vni, vrfMap)
 	}
+	if isVLANAwareBridge(evpn) {
+		vlan, err := l2vniVLAN(l2vni)
+		if err != nil {
+			return hostnetwork.L2VNIParams{}, fmt.Errorf("L2VNI %s: %w", l2vni.Name, err)
+		}
+		hostL2VNI.VLAN = vlan
+	}
 	if len(l2vni.Spec.GatewayIPs) > 0 {
 		hostL2VNI.L2GatewayIPs = make([]string, len(l2vni.Spec.GatewayIPs))
 		copy(hostL2VNI.L2GatewayIPs, l2vni.Spec.GatewayIPs)
+		hostL2VNI.GatewayMAC = gatewayMAC(l2vni, evpn)
 	}
+	sourceIP, err := dhcpRelaySourceIP(l2vni, nodeIndex)
+	if err != nil {
+		return hostnetwork.L2VNIParams{}, fmt.Errorf("L2VNI %s: %w", l2vni.Name, err)
+	}
+	hostL2VNI.DHCPRelaySourceIP = sourceIP
 	if l2vni.Spec.HostMaster != nil {
 		hm, err := convertHostMaster(&l2vni)
 		if err != nil {
@@ -321,6 +401,84 @@ func l2vniToHost(
 	return hostL2VNI, nil
 }
 
+// gatewayMAC returns the MAC address of the anycast gateway of the L2VNI,
+// falling back to the default of the underlay. An empty string means that
+// the MAC address is derived from the VNI.
+func gatewayMAC(l2vni v1alpha1.L2VNI, evpn *v1alpha1.EVPNConfig) string {
+	if l2vni.Spec.GatewayMAC != nil {
+		return *l2vni.Spec.GatewayMAC
+	}
+	if evpn != nil {
+		return ptr.Deref(evpn.GatewayMAC, "")
+	}
+	return ""
+}
+
+// dhcpRelaysToHost returns the DHCP relays of the L2VNIs, relaying the requests
+// received on their gateway interface. The host L2VNIs are in the same order
+// as the API ones.
+func dhcpRelaysToHost(l2vnis []v1alpha1.L2VNI, hostL2VNIs []hostnetwork.L2VNIParams) []dhcprelay.Relay {
+	relays := []dhcprelay.Relay{}
+	for i, l2vni := range l2vnis {
+		if l2vni.Spec.DHCPRelay == nil {
+			continue
+		}
+		gatewayIP, _ := ipv4GatewayIP(l2vni.Spec.GatewayIPs)
+		sourceIP, _, _ := net.ParseCIDR(hostL2VNIs[i].DHCPRelaySourceIP)
+		relay := dhcprelay.Relay{
+			Name:      l2vni.Name,
+			TargetNS:  hostL2VNIs[i].TargetNS,
+			VRF:       hostL2VNIs[i].VRF,
+			Interface: hostnetwork.L2GatewayInterfaceName(hostL2VNIs[i]),
+			GatewayIP: gatewayIP,
+			SourceIP:  sourceIP.String(),
+			Servers:   slices.Clone(l2vni.Spec.DHCPRelay.Servers),
+		}
+		if l2vni.Spec.DHCPRelay.Option82 != nil {
+			relay.Option82 = dhcpRelayOption82ToHost(l2vni)
+		}
+		relays = append(relays, relay)
+	}
+	return relays
+}
+
+// dhcpRelaySourceIP returns the address of the node, as a host CIDR, the
+// DHCP requests of the L2VNI are relayed from, or an empty string if the
+// L2VNI has no DHCP relay.
+func dhcpRelaySourceIP(l2vni v1alpha1.L2VNI, nodeIndex int) (string, error) {
+	if l2vni.Spec.DHCPRelay == nil {
+		return "", nil
+	}
+	ip, err := ipam.TunnelEndpointIP(l2vni.Spec.DHCPRelay.SourceCIDR, nodeIndex)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the dhcp relay source ip from %s: %w", l2vni.Spec.DHCPRelay.SourceCIDR, err)
+	}
+	return ip.String(), nil
+}
+
+// dhcpRelayOption82ToHost returns the relay agent information option of the
+// DHCP relay of the L2VNI, the circuit ID defaulting to the L2VNI name.
+func dhcpRelayOption82ToHost(l2vni v1alpha1.L2VNI) *dhcprelay.Option82 {
+	option82 := l2vni.Spec.DHCPRelay.Option82
+	return &dhcprelay.Option82{
+		CircuitID: ptr.Deref(option82.CircuitID, l2vni.Name),
+		RemoteID:  ptr.Deref(option82.RemoteID, ""),
+		Policy:    dhcprelay.Option82Policy(ptr.Deref(option82.Policy, v1alpha1.DHCPRelayOption82Keep)),
+	}
+}
+
+// ipv4GatewayIP returns the IPv4 address, without prefix length, of the given
+// gateway CIDRs.
+func ipv4GatewayIP(gatewayIPs []string) (string, bool) {
+	for _, cidr := range gatewayIPs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err == nil && ip.To4() != nil {
+			return ip.String(), true
+		}
+	}
+	return "", false
+}
+
 func l3vpnsToHost(l3vpns []v1alpha1.L3VPN, srv6Config *v1alpha1.SRV6Config,
 	targetNS string, nodeIndex int) ([]hostnetwork.L3VPNParams, error) {
 	if srv6Config == nil {
@@ -347,27 +505,82 @@ func l3vpnToHost(l3vpn v1alpha1.L3VPN, targetNS string, nodeIndex int) (hostnetw
 		TargetNS:         targetNS,
 		RDAssignedNumber: l3vpn.Spec.RDAssignedNumber,
 	}
-	if l3vpn.Spec.HostSession == nil {
+	linkIPs, err := hostSessionsLinkIPs(vrfHostSessions(l3vpn.Spec.HostSession, l3vpn.Spec.HostSessions), nodeIndex)
+	if err != nil {
+		return hostnetwork.L3VPNParams{}, err
+	}
+	if len(linkIPs) == 0 {
 		return hostL3VPN, nil
 	}
-
-	vethIPs, err := ipam.VethIPsFromPool(
-		l3vpn.Spec.HostSession.LocalCIDR.IPv4,
-		l3vpn.Spec.HostSession.LocalCIDR.IPv6,
-		nodeIndex)
-	if err != nil {
-		return hostnetwork.L3VPNParams{}, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d, err: %w",
-			l3vpn.Spec.HostSession.LocalCIDR, nodeIndex, err)
+	hostL3VPN.LinkIPs = &linkIPs[0]
+	if len(linkIPs) > 1 {
+		hostL3VPN.AdditionalLinkIPs = linkIPs[1:]
 	}
 
-	hostL3VPN.LinkIPs = &hostnetwork.LinkIPs{
-		HostIPv4: ipNetToString(vethIPs.Ipv4.HostSide),
-		NSIPv4:   ipNetToString(vethIPs.Ipv4.PeSide),
-		HostIPv6: ipNetToString(vethIPs.Ipv6.HostSide),
-		NSIPv6:   ipNetToString(vethIPs.Ipv6.PeSide),
+	return hostL3VPN, nil
+}
+
+// hostSessionsLinkIPs returns the IPs of the veth pair of each of the host
+// sessions, for the given node index.
+func hostSessionsLinkIPs(sessions []v1alpha1.VRFHostSession, nodeIndex int) ([]hostnetwork.LinkIPs, error) {
+	res := []hostnetwork.LinkIPs{}
+	for _, s := range sessions {
+		vethIPs, err := ipam.VethIPsFromPool(s.LocalCIDR.IPv4, s.LocalCIDR.IPv6, nodeIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d, err: %w",
+				s.LocalCIDR, nodeIndex, err)
+		}
+		res = append(res, hostnetwork.LinkIPs{
+			Session:  s.Name,
+			HostIPv4: ipNetToString(vethIPs.Ipv4.HostSide),
+			NSIPv4:   ipNetToString(vethIPs.Ipv4.PeSide),
+			HostIPv6: ipNetToString(vethIPs.Ipv6.HostSide),
+			NSIPv6:   ipNetToString(vethIPs.Ipv6.PeSide),
+		})
 	}
+	return res, nil
+}
 
-	return hostL3VPN, nil
+// vxlanTunnelToHost returns the properties of the outer headers of the
+// packets encapsulated by the VXLan device of a VNI, falling back for each
+// of them to the default of the underlay.
+func vxlanTunnelToHost(tunnel *v1alpha1.VXLanTunnelConfig, evpn *v1alpha1.EVPNConfig) hostnetwork.VXLanTunnelParams {
+	var defaults *v1alpha1.VXLanTunnelConfig
+	if evpn != nil {
+		defaults = evpn.VXLanTunnel
+	}
+
+	res := hostnetwork.VXLanTunnelParams{
+		TTL: int(ptr.Deref(vxlanTunnelSetting(tunnel, defaults, func(c *v1alpha1.VXLanTunnelConfig) *int32 { return c.TTL }), 0)),
+		// The DSCP is held by the 6 most significant bits of the TOS.
+		TOS:         int(ptr.Deref(vxlanTunnelSetting(tunnel, defaults, func(c *v1alpha1.VXLanTunnelConfig) *int32 { return c.DSCP }), 0)) << 2,
+		UDPChecksum: vxlanTunnelSetting(tunnel, defaults, func(c *v1alpha1.VXLanTunnelConfig) *bool { return c.UDPChecksum }),
+	}
+	df := vxlanTunnelSetting(tunnel, defaults, func(c *v1alpha1.VXLanTunnelConfig) *v1alpha1.VXLanDontFragment { return c.DontFragment })
+	switch ptr.Deref(df, v1alpha1.VXLanDontFragmentUnset) {
+	case v1alpha1.VXLanDontFragmentSet:
+		res.DF = hostnetwork.VXLanDFSet
+	case v1alpha1.VXLanDontFragmentInherit:
+		res.DF = hostnetwork.VXLanDFInherit
+	}
+	portRange := vxlanTunnelSetting(tunnel, defaults, func(c *v1alpha1.VXLanTunnelConfig) *v1alpha1.PortRange { return c.SourcePortRange })
+	if portRange != nil {
+		res.SourcePortLow = int(portRange.Min)
+		res.SourcePortHigh = int(portRange.Max)
+	}
+	return res
+}
+
+// vxlanTunnelSetting returns the setting of the VNI, or the default one of
+// the underlay when the VNI does not set it.
+func vxlanTunnelSetting[T any](tunnel, defaults *v1alpha1.VXLanTunnelConfig, get func(*v1alpha1.VXLanTunnelConfig) *T) *T {
+	if tunnel != nil && get(tunnel) != nil {
+		return get(tunnel)
+	}
+	if defaults != nil {
+		return get(defaults)
+	}
+	return nil
 }
 
 func vxlanPort(p *int32) *int32 {
@@ -395,6 +608,14 @@ func convertHostMaster(l2vni *v1alpha1.L2VNI) (*hostnetwork.HostMaster, error) {
 				AutoCreate: new(l2vni.Spec.HostMaster.OVSBridge.Lifecycle == v1alpha1.BridgeLifecycleManaged),
 			}, nil
 		}
+	case v1alpha1.VLANTrunk:
+		if l2vni.Spec.HostMaster.VLANTrunk != nil {
+			return &hostnetwork.HostMaster{
+				Name:      l2vni.Spec.HostMaster.VLANTrunk.Bridge,
+				Type:      l2vni.Spec.HostMaster.Type,
+				TrunkVLAN: uint16(l2vni.Spec.HostMaster.VLANTrunk.VLANID),
+			}, nil
+		}
 	default:
 		return nil, fmt.Errorf(
 			"unknown host master type %q for L2VNI %s",
@@ -451,10 +672,19 @@ func ipNetToString(ipNet net.IPNet) string {
 }
 
 // underlayNetworkDeviceInterfaceNames extracts the host interface names from the underlay
-// interfaces list. Entries of other modes (e.g. CNI) are skipped.
+// interfaces list, the bond names for the bonds and the sub-interface names for the VLANs.
+// Entries of other modes (e.g. CNI) are skipped.
 func underlayNetworkDeviceInterfaceNames(interfaces []v1alpha1.UnderlayInterface) ([]string, error) {
 	names := make([]string, 0, len(interfaces))
 	for _, iface := range interfaces {
+		if iface.Type == v1alpha1.UnderlayInterfaceTypeBond || iface.Type == v1alpha1.UnderlayInterfaceTypeVLAN {
+			hostIface, err := underlayInterfaceToHost(iface)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, hostIface.InterfaceName)
+			continue
+		}
 		if iface.Type != v1alpha1.UnderlayInterfaceTypeNetworkDevice {
 			continue
 		}
@@ -475,8 +705,8 @@ func underlayNetworkDeviceInterfaceNames(interfaces []v1alpha1.UnderlayInterface
 // unified host representation, unmarshalling the
 // opaque runtimeConfig of the CNI-provisioned interfaces into the capability
 // arguments handed to the plugin. It rejects duplicate or invalid interface
-// names, invalid CNI configurations and mixes of NetworkDevice and CNIDevice
-// interfaces.
+// and bond member names, invalid CNI configurations and mixes of interface
+// types.
 func underlayInterfacesToHost(interfaces []v1alpha1.UnderlayInterface) ([]hostnetwork.UnderlayInterface, error) {
 	res := make([]hostnetwork.UnderlayInterface, 0, len(interfaces))
 	seenNames := sets.Set[string]{}
@@ -486,12 +716,14 @@ func underlayInterfacesToHost(interfaces []v1alpha1.UnderlayInterface) ([]hostne
 		if err != nil {
 			return nil, err
 		}
-		if seenNames.Has(hostIface.InterfaceName) {
-			return nil, fmt.Errorf("duplicate underlay interface name %s", hostIface.InterfaceName)
-		}
-		seenNames.Insert(hostIface.InterfaceName)
-		if err := isValidInterfaceName(hostIface.InterfaceName); err != nil {
-			return nil, fmt.Errorf("invalid interface name %s: %w", hostIface.InterfaceName, err)
+		for _, name := range hostInterfaceNames(hostIface) {
+			if seenNames.Has(name) {
+				return nil, fmt.Errorf("duplicate underlay interface name %s", name)
+			}
+			seenNames.Insert(name)
+			if err := isValidInterfaceName(name); err != nil {
+				return nil, fmt.Errorf("invalid interface name %s: %w", name, err)
+			}
 		}
 		if hostIface.Kind == hostnetwork.UnderlayInterfaceCNIDev {
 			if err := cniinvoker.ValidateConfig(hostIface.CNI.Config); err != nil {
@@ -514,6 +746,10 @@ func underlayInterfaceToHost(iface v1alpha1.UnderlayInterface) (hostnetwork.Unde
 		return networkDeviceInterfaceToHost(iface)
 	case v1alpha1.UnderlayInterfaceTypeCNIDevice:
 		return cniDeviceInterfaceToHost(iface)
+	case v1alpha1.UnderlayInterfaceTypeBond:
+		return bondInterfaceToHost(iface)
+	case v1alpha1.UnderlayInterfaceTypeVLAN:
+		return vlanInterfaceToHost(iface)
 	default:
 		return hostnetwork.UnderlayInterface{}, fmt.Errorf("unsupported underlay interface type %q", iface.Type)
 	}
@@ -565,3 +801,137 @@ func cniDeviceInterfaceToHost(iface v1alpha1.UnderlayInterface) (hostnetwork.Und
 		},
 	}, nil
 }
+
+var (
+	bondModes = map[v1alpha1.BondMode]string{
+		v1alpha1.BondModeBalanceRR:    "balance-rr",
+		v1alpha1.BondModeActiveBackup: "active-backup",
+		v1alpha1.BondModeBalanceXOR:   "balance-xor",
+		v1alpha1.BondModeBroadcast:    "broadcast",
+		v1alpha1.BondModeLACP:         "802.3ad",
+		v1alpha1.BondModeBalanceTLB:   "balance-tlb",
+		v1alpha1.BondModeBalanceALB:   "balance-alb",
+	}
+	bondLACPRates = map[v1alpha1.BondLACPRate]string{
+		v1alpha1.BondLACPRateSlow: "slow",
+		v1alpha1.BondLACPRateFast: "fast",
+	}
+	bondTransmitHashPolicies = map[v1alpha1.BondTransmitHashPolicy]string{
+		v1alpha1.BondTransmitHashPolicyLayer2:  "layer2",
+		v1alpha1.BondTransmitHashPolicyLayer23: "layer2+3",
+		v1alpha1.BondTransmitHashPolicyLayer34: "layer3+4",
+		v1alpha1.BondTransmitHashPolicyEncap23: "encap2+3",
+		v1alpha1.BondTransmitHashPolicyEncap34: "encap3+4",
+	}
+)
+
+const defaultBondMIIMonInterval = 100
+
+func bondInterfaceToHost(iface v1alpha1.UnderlayInterface) (hostnetwork.UnderlayInterface, error) {
+	if iface.Bond == nil {
+		return hostnetwork.UnderlayInterface{},
+			fmt.Errorf("bond configuration is missing for interface type Bond")
+	}
+	if iface.Bond.InterfaceName == "" {
+		return hostnetwork.UnderlayInterface{}, fmt.Errorf("interfaceName is empty for bond")
+	}
+	if len(iface.Bond.Members) == 0 {
+		return hostnetwork.UnderlayInterface{}, fmt.Errorf("bond %s has no members", iface.Bond.InterfaceName)
+	}
+
+	mode := ptr.Deref(iface.Bond.Mode, v1alpha1.BondModeLACP)
+	params := &hostnetwork.BondParams{
+		Members: iface.Bond.Members,
+		Mode:    bondModes[mode],
+		MIIMon:  int(ptr.Deref(iface.Bond.MIIMonInterval, defaultBondMIIMonInterval)),
+	}
+	if params.Mode == "" {
+		return hostnetwork.UnderlayInterface{}, fmt.Errorf("invalid mode %q for bond %s", mode, iface.Bond.InterfaceName)
+	}
+	if iface.Bond.LACPRate != nil {
+		if mode != v1alpha1.BondModeLACP {
+			return hostnetwork.UnderlayInterface{},
+				fmt.Errorf("lacpRate can only be set with the LACP mode for bond %s", iface.Bond.InterfaceName)
+		}
+		params.LACPRate = bondLACPRates[*iface.Bond.LACPRate]
+		if params.LACPRate == "" {
+			return hostnetwork.UnderlayInterface{},
+				fmt.Errorf("invalid lacpRate %q for bond %s", *iface.Bond.LACPRate, iface.Bond.InterfaceName)
+		}
+	}
+	if iface.Bond.TransmitHashPolicy != nil {
+		if mode != v1alpha1.BondModeLACP && mode != v1alpha1.BondModeBalanceXOR && mode != v1alpha1.BondModeBalanceTLB {
+			return hostnetwork.UnderlayInterface{},
+				fmt.Errorf("transmitHashPolicy can't be set with the %s mode for bond %s", mode, iface.Bond.InterfaceName)
+		}
+		params.XmitHashPolicy = bondTransmitHashPolicies[*iface.Bond.TransmitHashPolicy]
+		if params.XmitHashPolicy == "" {
+			return hostnetwork.UnderlayInterface{}, fmt.Errorf("invalid transmitHashPolicy %q for bond %s",
+				*iface.Bond.TransmitHashPolicy, iface.Bond.InterfaceName)
+		}
+	}
+	addresses, err := underlayInterfaceAddresses(iface.Bond.Addresses)
+	if err != nil {
+		return hostnetwork.UnderlayInterface{}, fmt.Errorf("bond %s: %w", iface.Bond.InterfaceName, err)
+	}
+	params.Addresses = addresses
+
+	return hostnetwork.UnderlayInterface{
+		InterfaceName: iface.Bond.InterfaceName,
+		Kind:          hostnetwork.UnderlayInterfaceBond,
+		Bond:          params,
+	}, nil
+}
+
+func vlanInterfaceToHost(iface v1alpha1.UnderlayInterface) (hostnetwork.UnderlayInterface, error) {
+	if iface.VLAN == nil {
+		return hostnetwork.UnderlayInterface{},
+			fmt.Errorf("vlan configuration is missing for interface type VLAN")
+	}
+	if iface.VLAN.Parent == "" {
+		return hostnetwork.UnderlayInterface{}, fmt.Errorf("parent is empty for vlan")
+	}
+	if iface.VLAN.VLANID < 1 || iface.VLAN.VLANID > 4094 {
+		return hostnetwork.UnderlayInterface{},
+			fmt.Errorf("invalid vlanID %d for vlan on %s, must be between 1 and 4094", iface.VLAN.VLANID, iface.VLAN.Parent)
+	}
+	addresses, err := underlayInterfaceAddresses(iface.VLAN.Addresses)
+	if err != nil {
+		return hostnetwork.UnderlayInterface{}, fmt.Errorf("vlan on %s: %w", iface.VLAN.Parent, err)
+	}
+	return hostnetwork.UnderlayInterface{
+		InterfaceName: hostnetwork.UnderlayVLANName(iface.VLAN.Parent, int(iface.VLAN.VLANID)),
+		Kind:          hostnetwork.UnderlayInterfaceVLAN,
+		VLAN: &hostnetwork.VLANParams{
+			Parent:    iface.VLAN.Parent,
+			VLANID:    int(iface.VLAN.VLANID),
+			Addresses: addresses,
+		},
+	}, nil
+}
+
+// underlayInterfaceAddresses validates the addresses of an underlay
+// interface created by the router, returning them in canonical form so
+// that they compare equal to the ones read from the interface.
+func underlayInterfaceAddresses(addresses []string) ([]string, error) {
+	var res []string
+	for _, a := range addresses {
+		prefix, err := netip.ParsePrefix(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", a, err)
+		}
+		res = append(res, prefix.String())
+	}
+	return res, nil
+}
+
+// hostInterfaceNames returns the names of the interfaces the underlay
+// interface takes in the router namespace: the interface itself and, for a
+// bond, its members.
+func hostInterfaceNames(iface hostnetwork.UnderlayInterface) []string {
+	names := []string{iface.InterfaceName}
+	if iface.Bond != nil {
+		names = append(names, iface.Bond.Members...)
+	}
+	return names
+}
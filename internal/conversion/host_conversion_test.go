@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/dhcprelay"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +31,8 @@ func TestAPItoHostConfig(t *testing.T) {
 		wantL3VPNParams []hostnetwork.L3VPNParams
 		wantPassthrough *hostnetwork.PassthroughParams
 		wantErr         bool
+
+		wantAdditionalUnderlays []hostnetwork.UnderlayParams
 	}{
 		{
 			name:            "no underlays",
@@ -51,29 +54,82 @@ func TestAPItoHostConfig(t *testing.T) {
 			targetNS:  "namespace",
 			underlays: []v1alpha1.Underlay{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
 					Spec: v1alpha1.UnderlaySpec{
 						Interfaces: []v1alpha1.UnderlayInterface{
 							{
 								Type:          "NetworkDevice",
-								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
 							},
 						},
-						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.1.0/24"}},
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
 					Spec: v1alpha1.UnderlaySpec{
 						Interfaces: []v1alpha1.UnderlayInterface{
 							{
 								Type:          "NetworkDevice",
-								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
 							},
 						},
-						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.1.0/24"}},
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
 					},
 				},
 			},
-			wantErr: true,
+			vnis: []v1alpha1.L3VNI{
+				{Spec: v1alpha1.L3VNISpec{VRF: "red", HostSession: &v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("10.1.0.0/24")}}, VNI: 100, VXLanPort: new(int32(4789))}},
+				{Spec: v1alpha1.L3VNISpec{VRF: "blue", Underlay: new("fabric-b"), VNI: 200, VXLanPort: new(int32(4789))}},
+			},
+			l2vnis:        []v1alpha1.L2VNI{},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				Name:               "fabric-a",
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantAdditionalUnderlays: []hostnetwork.UnderlayParams{
+				{
+					Name:               "fabric-b",
+					UnderlayInterfaces: netdevInterfaces("eth1"),
+					TargetNS:           "namespace",
+					TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+						IPv4CIDR: "10.0.1.0/32",
+					},
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       100,
+						VXLanPort: new(int32(4789)),
+					},
+					LinkIPs: &hostnetwork.LinkIPs{
+						HostIPv4: "10.1.0.2/24",
+						NSIPv4:   "10.1.0.1/24",
+					},
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "blue",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.1.0/32",
+						VNI:       200,
+						VXLanPort: new(int32(4789)),
+					},
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
 		},
 		{
 			name:      "ipv4 only",
@@ -124,6 +180,66 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantPassthrough: nil,
 			wantErr:         false,
 		},
+		{
+			name:      "multiple host sessions",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          "NetworkDevice",
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{Spec: v1alpha1.L3VNISpec{VRF: "red", HostSessions: []v1alpha1.VRFHostSession{
+					{Name: "metallb", HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("10.1.0.0/24")}}},
+					{Name: "calico", HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv6: new("fd00:1::/64")}}},
+				}, VNI: 100, VXLanPort: new(int32(4789))}},
+			},
+			l2vnis:        []v1alpha1.L2VNI{},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       100,
+						VXLanPort: new(int32(4789)),
+					},
+					LinkIPs: &hostnetwork.LinkIPs{
+						Session:  "metallb",
+						HostIPv4: "10.1.0.2/24",
+						NSIPv4:   "10.1.0.1/24",
+					},
+					AdditionalLinkIPs: []hostnetwork.LinkIPs{
+						{
+							Session:  "calico",
+							HostIPv6: "fd00:1::2/64",
+							NSIPv6:   "fd00:1::1/64",
+						},
+					},
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
 		{
 			name:      "two underlay interfaces",
 			nodeIndex: 0,
@@ -380,6 +496,42 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantPassthrough: nil,
 			wantErr:         false,
 		},
+		{
+			name:      "l2 vni with neighbor suppression disabled and proxy arp",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
+			},
+			vnis: []v1alpha1.L3VNI{},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{VNI: 200, VXLanPort: new(int32(4789)), ARPNDSuppression: new(false), ProxyARP: new(true)}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       200,
+						VXLanPort: new(int32(4789)),
+					},
+					NeighSuppression: new(false),
+					ProxyARP:         new(true),
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
 		{
 			name:      "disconnected l2 vni gets empty VRF in host config",
 			nodeIndex: 0,
@@ -387,12 +539,263 @@ func TestAPItoHostConfig(t *testing.T) {
 			underlays: []v1alpha1.Underlay{
 				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
 			},
-			vnis: []v1alpha1.L3VNI{},
+			vnis: []v1alpha1.L3VNI{},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-l2vni"},
+					Spec:       v1alpha1.L2VNISpec{VNI: 200, VXLanPort: new(int32(4789))},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					Name: "my-l2vni",
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       200,
+						VXLanPort: new(int32(4789)),
+					},
+					L2GatewayIPs: nil,
+					HostMaster:   nil,
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
+		{
+			name:      "l2 vni with hostmaster and gatewayips",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{ObjectMeta: metav1.ObjectMeta{Name: "gw-l3"}, Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 300}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{
+					RoutingDomain: &v1alpha1.RoutingDomain{
+						Type:  v1alpha1.RoutingDomainTypeL3VNI,
+						L3VNI: &v1alpha1.L3VNIReference{Name: "gw-l3"},
+					},
+					VNI: 201, VXLanPort: new(int32(4789)),
+					HostMaster: &v1alpha1.HostMaster{Type: "LinuxBridge", LinuxBridge: &v1alpha1.LinuxBridgeConfig{Name: new("br0")}},
+					GatewayIPs: []string{"192.168.100.1/24"},
+				}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       300,
+						VXLanPort: new(int32(4789)),
+					},
+					Name: "gw-l3",
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       201,
+						VXLanPort: new(int32(4789)),
+					},
+					L2GatewayIPs: []string{"192.168.100.1/24"},
+					HostMaster:   &hostnetwork.HostMaster{Name: new("br0"), Type: "LinuxBridge", AutoCreate: new(false)},
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
+		{
+			name:      "l2 vnis with vlan trunk hostmaster",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{
+					VNI: 201, VXLanPort: new(int32(4789)),
+					HostMaster: &v1alpha1.HostMaster{Type: "VLANTrunk", VLANTrunk: &v1alpha1.VLANTrunkConfig{VLANID: 10}},
+				}},
+				{Spec: v1alpha1.L2VNISpec{
+					VNI: 202, VXLanPort: new(int32(4789)),
+					HostMaster: &v1alpha1.HostMaster{Type: "VLANTrunk", VLANTrunk: &v1alpha1.VLANTrunkConfig{VLANID: 11, Bridge: new("br0")}},
+				}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       201,
+						VXLanPort: new(int32(4789)),
+					},
+					HostMaster: &hostnetwork.HostMaster{Type: "VLANTrunk", TrunkVLAN: 10},
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       202,
+						VXLanPort: new(int32(4789)),
+					},
+					HostMaster: &hostnetwork.HostMaster{Name: new("br0"), Type: "VLANTrunk", TrunkVLAN: 11},
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
+		{
+			name:      "l2 vnis with gateway mac",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{
+					Interfaces:     []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}},
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					EVPN:           &v1alpha1.EVPNConfig{GatewayMAC: new("00:00:5e:00:01:01")},
+				}},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{ObjectMeta: metav1.ObjectMeta{Name: "gw-l3"}, Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 300}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{
+					RoutingDomain: &v1alpha1.RoutingDomain{
+						Type:  v1alpha1.RoutingDomainTypeL3VNI,
+						L3VNI: &v1alpha1.L3VNIReference{Name: "gw-l3"},
+					},
+					VNI: 201, VXLanPort: new(int32(4789)),
+					GatewayIPs: []string{"192.168.100.1/24"},
+				}},
+				{Spec: v1alpha1.L2VNISpec{
+					RoutingDomain: &v1alpha1.RoutingDomain{
+						Type:  v1alpha1.RoutingDomainTypeL3VNI,
+						L3VNI: &v1alpha1.L3VNIReference{Name: "gw-l3"},
+					},
+					VNI: 202, VXLanPort: new(int32(4789)),
+					GatewayIPs: []string{"192.168.101.1/24"},
+					GatewayMAC: new("00:00:5e:00:01:02"),
+				}},
+				{Spec: v1alpha1.L2VNISpec{VNI: 203, VXLanPort: new(int32(4789))}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+					IPv4CIDR: "10.0.0.0/32",
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       300,
+						VXLanPort: new(int32(4789)),
+					},
+					Name: "gw-l3",
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       201,
+						VXLanPort: new(int32(4789)),
+					},
+					L2GatewayIPs: []string{"192.168.100.1/24"},
+					GatewayMAC:   "00:00:5e:00:01:01",
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       202,
+						VXLanPort: new(int32(4789)),
+					},
+					L2GatewayIPs: []string{"192.168.101.1/24"},
+					GatewayMAC:   "00:00:5e:00:01:02",
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       203,
+						VXLanPort: new(int32(4789)),
+					},
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
+		{
+			name:      "l2 vnis with vlan aware bridge",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{
+					Interfaces:     []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}},
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					EVPN:           &v1alpha1.EVPNConfig{BridgeMode: new(v1alpha1.EVPNBridgeModeVLANAware)},
+				}},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{ObjectMeta: metav1.ObjectMeta{Name: "gw-l3"}, Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 300}},
+			},
 			l2vnis: []v1alpha1.L2VNI{
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "my-l2vni"},
-					Spec:       v1alpha1.L2VNISpec{VNI: 200, VXLanPort: new(int32(4789))},
-				},
+				{Spec: v1alpha1.L2VNISpec{
+					RoutingDomain: &v1alpha1.RoutingDomain{
+						Type:  v1alpha1.RoutingDomainTypeL3VNI,
+						L3VNI: &v1alpha1.L3VNIReference{Name: "gw-l3"},
+					},
+					VNI: 10201, VXLanPort: new(int32(4789)),
+					VLAN:       new(int32(201)),
+					GatewayIPs: []string{"192.168.100.1/24"},
+				}},
+				{Spec: v1alpha1.L2VNISpec{VNI: 203, VXLanPort: new(int32(4789))}},
 			},
 			l3Passthrough: []v1alpha1.L3Passthrough{},
 			wantUnderlay: hostnetwork.UnderlayParams{
@@ -402,19 +805,38 @@ func TestAPItoHostConfig(t *testing.T) {
 					IPv4CIDR: "10.0.0.0/32",
 				},
 			},
-			wantL3VNIParams: []hostnetwork.L3VNIParams{},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       300,
+						VXLanPort: new(int32(4789)),
+					},
+					Name: "gw-l3",
+				},
+			},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{
 				{
-					Name: "my-l2vni",
 					VNIParams: hostnetwork.VNIParams{
-						VRF:       "",
+						VRF:       "red",
 						TargetNS:  "namespace",
 						VTEPIP:    "10.0.0.0/32",
-						VNI:       200,
+						VNI:       10201,
 						VXLanPort: new(int32(4789)),
+						VLAN:      201,
+					},
+					L2GatewayIPs: []string{"192.168.100.1/24"},
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       203,
+						VXLanPort: new(int32(4789)),
+						VLAN:      203,
 					},
-					L2GatewayIPs: nil,
-					HostMaster:   nil,
 				},
 			},
 			wantL3VPNParams: []hostnetwork.L3VPNParams{},
@@ -422,24 +844,35 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantErr:         false,
 		},
 		{
-			name:      "l2 vni with hostmaster and gatewayips",
+			name:      "vnis with vxlan tunnel settings",
 			nodeIndex: 0,
 			targetNS:  "namespace",
 			underlays: []v1alpha1.Underlay{
-				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
+				{Spec: v1alpha1.UnderlaySpec{
+					Interfaces:     []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}},
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					EVPN: &v1alpha1.EVPNConfig{VXLanTunnel: &v1alpha1.VXLanTunnelConfig{
+						DSCP:            new(int32(46)),
+						DontFragment:    new(v1alpha1.VXLanDontFragmentSet),
+						SourcePortRange: &v1alpha1.PortRange{Min: 49152, Max: 65535},
+					}},
+				}},
 			},
 			vnis: []v1alpha1.L3VNI{
-				{ObjectMeta: metav1.ObjectMeta{Name: "gw-l3"}, Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 300}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "l3"}, Spec: v1alpha1.L3VNISpec{
+					VRF: "red", VNI: 300,
+					// Skipping the checksum is the default with an IPv4 vtep.
+					VXLanTunnel: &v1alpha1.VXLanTunnelConfig{UDPChecksum: new(false)},
+				}},
 			},
 			l2vnis: []v1alpha1.L2VNI{
 				{Spec: v1alpha1.L2VNISpec{
-					RoutingDomain: &v1alpha1.RoutingDomain{
-						Type:  v1alpha1.RoutingDomainTypeL3VNI,
-						L3VNI: &v1alpha1.L3VNIReference{Name: "gw-l3"},
-					},
 					VNI: 201, VXLanPort: new(int32(4789)),
-					HostMaster: &v1alpha1.HostMaster{Type: "LinuxBridge", LinuxBridge: &v1alpha1.LinuxBridgeConfig{Name: new("br0")}},
-					GatewayIPs: []string{"192.168.100.1/24"},
+					VXLanTunnel: &v1alpha1.VXLanTunnelConfig{
+						TTL:          new(int32(32)),
+						DontFragment: new(v1alpha1.VXLanDontFragmentInherit),
+						UDPChecksum:  new(true),
+					},
 				}},
 			},
 			l3Passthrough: []v1alpha1.L3Passthrough{},
@@ -458,21 +891,33 @@ func TestAPItoHostConfig(t *testing.T) {
 						VTEPIP:    "10.0.0.0/32",
 						VNI:       300,
 						VXLanPort: new(int32(4789)),
+						Tunnel: hostnetwork.VXLanTunnelParams{
+							TOS:            184,
+							DF:             hostnetwork.VXLanDFSet,
+							SourcePortLow:  49152,
+							SourcePortHigh: 65535,
+							UDPChecksum:    new(false),
+						},
 					},
-					Name: "gw-l3",
+					Name: "l3",
 				},
 			},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{
 				{
 					VNIParams: hostnetwork.VNIParams{
-						VRF:       "red",
 						TargetNS:  "namespace",
 						VTEPIP:    "10.0.0.0/32",
 						VNI:       201,
 						VXLanPort: new(int32(4789)),
+						Tunnel: hostnetwork.VXLanTunnelParams{
+							TTL:            32,
+							TOS:            184,
+							DF:             hostnetwork.VXLanDFInherit,
+							SourcePortLow:  49152,
+							SourcePortHigh: 65535,
+							UDPChecksum:    new(true),
+						},
 					},
-					L2GatewayIPs: []string{"192.168.100.1/24"},
-					HostMaster:   &hostnetwork.HostMaster{Name: new("br0"), Type: "LinuxBridge", AutoCreate: new(false)},
 				},
 			},
 			wantL3VPNParams: []hostnetwork.L3VPNParams{},
@@ -705,6 +1150,10 @@ func TestAPItoHostConfig(t *testing.T) {
 			if !reflect.DeepEqual(gotHostConfig.Underlay, tt.wantUnderlay) {
 				t.Errorf("APItoHostConfig() gotUnderlay = %s, want %s", mustMarshal(gotHostConfig.Underlay), mustMarshal(tt.wantUnderlay))
 			}
+			if !reflect.DeepEqual(gotHostConfig.AdditionalUnderlays, tt.wantAdditionalUnderlays) {
+				t.Errorf("APItoHostConfig() gotAdditionalUnderlays = %s, want %s",
+					mustMarshal(gotHostConfig.AdditionalUnderlays), mustMarshal(tt.wantAdditionalUnderlays))
+			}
 			if !reflect.DeepEqual(gotHostConfig.L3VNIs, tt.wantL3VNIParams) {
 				t.Errorf("APItoHostConfig() gotL3VNIParams = %+v, want %+v", gotHostConfig.L3VNIs, tt.wantL3VNIParams)
 			}
@@ -1123,3 +1572,376 @@ func TestAPItoHostConfigCNIInterfaces(t *testing.T) {
 		})
 	}
 }
+
+func TestAPItoHostConfigBondInterfaces(t *testing.T) {
+	underlayWithInterfaces := func(interfaces ...v1alpha1.UnderlayInterface) []v1alpha1.Underlay {
+		return []v1alpha1.Underlay{{Spec: v1alpha1.UnderlaySpec{Interfaces: interfaces}}}
+	}
+
+	tests := []struct {
+		name         string
+		underlays    []v1alpha1.Underlay
+		wantUnderlay hostnetwork.UnderlayParams
+		wantErr      string
+	}{
+		{
+			name: "bond with defaults",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeBond,
+				Bond: &v1alpha1.BondDevice{
+					InterfaceName: "bond0",
+					Members:       []string{"eth1", "eth2"},
+				},
+			}),
+			wantUnderlay: hostnetwork.UnderlayParams{
+				TargetNS: "namespace",
+				UnderlayInterfaces: []hostnetwork.UnderlayInterface{
+					{
+						InterfaceName: "bond0",
+						Kind:          hostnetwork.UnderlayInterfaceBond,
+						Bond: &hostnetwork.BondParams{
+							Members: []string{"eth1", "eth2"},
+							Mode:    "802.3ad",
+							MIIMon:  100,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "bond with all the options",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeBond,
+				Bond: &v1alpha1.BondDevice{
+					InterfaceName:      "bond0",
+					Members:            []string{"eth1", "eth2"},
+					Mode:               new(v1alpha1.BondModeLACP),
+					MIIMonInterval:     new(int32(50)),
+					LACPRate:           new(v1alpha1.BondLACPRateFast),
+					TransmitHashPolicy: new(v1alpha1.BondTransmitHashPolicyLayer34),
+					Addresses:          []string{"192.168.11.3/24", "2001:DB8::3/64"},
+				},
+			}),
+			wantUnderlay: hostnetwork.UnderlayParams{
+				TargetNS: "namespace",
+				UnderlayInterfaces: []hostnetwork.UnderlayInterface{
+					{
+						InterfaceName: "bond0",
+						Kind:          hostnetwork.UnderlayInterfaceBond,
+						Bond: &hostnetwork.BondParams{
+							Members:        []string{"eth1", "eth2"},
+							Mode:           "802.3ad",
+							MIIMon:         50,
+							LACPRate:       "fast",
+							XmitHashPolicy: "layer3+4",
+							Addresses:      []string{"192.168.11.3/24", "2001:db8::3/64"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "bond with an invalid address",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeBond,
+				Bond: &v1alpha1.BondDevice{
+					InterfaceName: "bond0",
+					Members:       []string{"eth1", "eth2"},
+					Addresses:     []string{"192.168.11.3"},
+				},
+			}),
+			wantErr: "invalid address",
+		},
+		{
+			name: "bond without bond configuration",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeBond,
+			}),
+			wantErr: "bond configuration is missing",
+		},
+		{
+			name: "bond with lacpRate and active-backup mode",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeBond,
+				Bond: &v1alpha1.BondDevice{
+					InterfaceName: "bond0",
+					Members:       []string{"eth1", "eth2"},
+					Mode:          new(v1alpha1.BondModeActiveBackup),
+					LACPRate:      new(v1alpha1.BondLACPRateFast),
+				},
+			}),
+			wantErr: "lacpRate can only be set with the LACP mode",
+		},
+		{
+			name: "bond with member named as the bond",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeBond,
+				Bond: &v1alpha1.BondDevice{
+					InterfaceName: "bond0",
+					Members:       []string{"eth1", "bond0"},
+				},
+			}),
+			wantErr: "duplicate underlay interface name bond0",
+		},
+		{
+			name: "bonds sharing a member",
+			underlays: underlayWithInterfaces(
+				v1alpha1.UnderlayInterface{
+					Type: v1alpha1.UnderlayInterfaceTypeBond,
+					Bond: &v1alpha1.BondDevice{InterfaceName: "bond0", Members: []string{"eth1", "eth2"}},
+				},
+				v1alpha1.UnderlayInterface{
+					Type: v1alpha1.UnderlayInterfaceTypeBond,
+					Bond: &v1alpha1.BondDevice{InterfaceName: "bond1", Members: []string{"eth2", "eth3"}},
+				},
+			),
+			wantErr: "duplicate underlay interface name eth2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiConfig := APIConfigData{
+				Underlays: tt.underlays,
+			}
+
+			got, err := APItoHostConfig(0, "namespace", apiConfig)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("APItoHostConfig() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Underlay, tt.wantUnderlay) {
+				t.Errorf("APItoHostConfig() gotUnderlay = %+v, want %+v", got.Underlay, tt.wantUnderlay)
+			}
+		})
+	}
+}
+
+func TestAPItoHostConfigVLANInterfaces(t *testing.T) {
+	underlayWithInterfaces := func(interfaces ...v1alpha1.UnderlayInterface) []v1alpha1.Underlay {
+		return []v1alpha1.Underlay{{Spec: v1alpha1.UnderlaySpec{Interfaces: interfaces}}}
+	}
+
+	tests := []struct {
+		name         string
+		underlays    []v1alpha1.Underlay
+		wantUnderlay hostnetwork.UnderlayParams
+		wantErr      string
+	}{
+		{
+			name: "vlan sub-interfaces",
+			underlays: underlayWithInterfaces(
+				v1alpha1.UnderlayInterface{
+					Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+					VLAN: &v1alpha1.VLANDevice{Parent: "eth1", VLANID: 100},
+				},
+				v1alpha1.UnderlayInterface{
+					Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+					VLAN: &v1alpha1.VLANDevice{Parent: "eth2", VLANID: 100},
+				},
+			),
+			wantUnderlay: hostnetwork.UnderlayParams{
+				TargetNS: "namespace",
+				UnderlayInterfaces: []hostnetwork.UnderlayInterface{
+					{
+						InterfaceName: "eth1.100",
+						Kind:          hostnetwork.UnderlayInterfaceVLAN,
+						VLAN:          &hostnetwork.VLANParams{Parent: "eth1", VLANID: 100},
+					},
+					{
+						InterfaceName: "eth2.100",
+						Kind:          hostnetwork.UnderlayInterfaceVLAN,
+						VLAN:          &hostnetwork.VLANParams{Parent: "eth2", VLANID: 100},
+					},
+				},
+			},
+		},
+		{
+			name: "vlan sub-interface with addresses",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+				VLAN: &v1alpha1.VLANDevice{Parent: "eth1", VLANID: 100,
+					Addresses: []string{"192.168.11.3/24", "2001:DB8::3/64"}},
+			}),
+			wantUnderlay: hostnetwork.UnderlayParams{
+				TargetNS: "namespace",
+				UnderlayInterfaces: []hostnetwork.UnderlayInterface{
+					{
+						InterfaceName: "eth1.100",
+						Kind:          hostnetwork.UnderlayInterfaceVLAN,
+						VLAN: &hostnetwork.VLANParams{Parent: "eth1", VLANID: 100,
+							Addresses: []string{"192.168.11.3/24", "2001:db8::3/64"}},
+					},
+				},
+			},
+		},
+		{
+			name: "vlan sub-interface with an invalid address",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+				VLAN: &v1alpha1.VLANDevice{Parent: "eth1", VLANID: 100, Addresses: []string{"192.168.11.3"}},
+			}),
+			wantErr: "invalid address",
+		},
+		{
+			name: "vlan without vlan configuration",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+			}),
+			wantErr: "vlan configuration is missing",
+		},
+		{
+			name: "vlan with a too long name",
+			underlays: underlayWithInterfaces(v1alpha1.UnderlayInterface{
+				Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+				VLAN: &v1alpha1.VLANDevice{Parent: "enp0s31f6abcd", VLANID: 100},
+			}),
+			wantErr: "can't be longer than 15 characters",
+		},
+		{
+			name: "duplicate vlan",
+			underlays: underlayWithInterfaces(
+				v1alpha1.UnderlayInterface{
+					Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+					VLAN: &v1alpha1.VLANDevice{Parent: "eth1", VLANID: 100},
+				},
+				v1alpha1.UnderlayInterface{
+					Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+					VLAN: &v1alpha1.VLANDevice{Parent: "eth1", VLANID: 100},
+				},
+			),
+			wantErr: "duplicate underlay interface name eth1.100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiConfig := APIConfigData{
+				Underlays: tt.underlays,
+			}
+
+			got, err := APItoHostConfig(0, "namespace", apiConfig)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("APItoHostConfig() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Underlay, tt.wantUnderlay) {
+				t.Errorf("APItoHostConfig() gotUnderlay = %+v, want %+v", got.Underlay, tt.wantUnderlay)
+			}
+		})
+	}
+}
+
+func TestAPItoHostConfigDHCPRelays(t *testing.T) {
+	routingDomain := &v1alpha1.RoutingDomain{
+		Type:  v1alpha1.RoutingDomainTypeL3VNI,
+		L3VNI: &v1alpha1.L3VNIReference{Name: "red"},
+	}
+	apiConfig := APIConfigData{
+		Underlays: []v1alpha1.Underlay{{
+			Spec: v1alpha1.UnderlaySpec{
+				Interfaces: []v1alpha1.UnderlayInterface{
+					{
+						Type:          "NetworkDevice",
+						NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+					},
+				},
+				TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+			},
+		}},
+		L3VNIs: []v1alpha1.L3VNI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "red"},
+			Spec:       v1alpha1.L3VNISpec{VRF: "red", VNI: 100},
+		}},
+		L2VNIs: []v1alpha1.L2VNI{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-relay"},
+				Spec: v1alpha1.L2VNISpec{
+					VNI: 200, RoutingDomain: routingDomain, GatewayIPs: []string{"192.168.20.1/24"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "relay"},
+				Spec: v1alpha1.L2VNISpec{
+					VNI: 210, RoutingDomain: routingDomain,
+					GatewayIPs: []string{"2001:db8::1/64", "192.168.21.1/24"},
+					DHCPRelay: &v1alpha1.DHCPRelayConfig{
+						Servers:    []string{"10.100.0.10", "10.100.0.11"},
+						SourceCIDR: "10.200.0.0/24",
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "relay-option82"},
+				Spec: v1alpha1.L2VNISpec{
+					VNI: 220, RoutingDomain: routingDomain, GatewayIPs: []string{"192.168.22.1/24"},
+					DHCPRelay: &v1alpha1.DHCPRelayConfig{
+						Servers:    []string{"10.100.0.10"},
+						SourceCIDR: "10.201.0.0/24",
+						Option82: &v1alpha1.DHCPRelayOption82{
+							RemoteID: new("node1"),
+							Policy:   new(v1alpha1.DHCPRelayOption82Replace),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := APItoHostConfig(1, "namespace", apiConfig)
+	if err != nil {
+		t.Fatalf("APItoHostConfig() unexpected error: %v", err)
+	}
+
+	want := []dhcprelay.Relay{
+		{
+			Name:      "relay",
+			TargetNS:  "namespace",
+			VRF:       "red",
+			Interface: "br-pe-210",
+			GatewayIP: "192.168.21.1",
+			SourceIP:  "10.200.0.1",
+			Servers:   []string{"10.100.0.10", "10.100.0.11"},
+		},
+		{
+			Name:      "relay-option82",
+			TargetNS:  "namespace",
+			VRF:       "red",
+			Interface: "br-pe-220",
+			GatewayIP: "192.168.22.1",
+			SourceIP:  "10.201.0.1",
+			Servers:   []string{"10.100.0.10"},
+			Option82: &dhcprelay.Option82{
+				CircuitID: "relay-option82",
+				RemoteID:  "node1",
+				Policy:    dhcprelay.Option82Replace,
+			},
+		},
+	}
+	if !reflect.DeepEqual(got.DHCPRelays, want) {
+		t.Errorf("APItoHostConfig() gotDHCPRelays = %+v, want %+v", got.DHCPRelays, want)
+	}
+	sourceIPs := []string{}
+	for _, l2vni := range got.L2VNIs {
+		sourceIPs = append(sourceIPs, l2vni.DHCPRelaySourceIP)
+	}
+	if wantSourceIPs := []string{"", "10.200.0.1/32", "10.201.0.1/32"}; !reflect.DeepEqual(sourceIPs, wantSourceIPs) {
+		t.Errorf("APItoHostConfig() got dhcp relay source ips %v, want %v", sourceIPs, wantSourceIPs)
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// sessionPassword returns the password of a BGP session, either set inline
+// or read from the basic-auth secret named by passwordSecret.
+func sessionPassword(password, passwordSecret *string, secrets map[string]corev1.Secret) (string, error) {
+	if password != nil && passwordSecret != nil {
+		return "", errors.New("password and passwordSecret are mutually exclusive")
+	}
+	if passwordSecret == nil {
+		return ptr.Deref(password, ""), nil
+	}
+
+	secret, ok := secrets[*passwordSecret]
+	if !ok {
+		return "", fmt.Errorf("password secret %q not found", *passwordSecret)
+	}
+	if secret.Type != corev1.SecretTypeBasicAuth {
+		return "", fmt.Errorf("password secret %q must be of type %q, got %q", *passwordSecret, corev1.SecretTypeBasicAuth, secret.Type)
+	}
+	res, ok := secret.Data[corev1.BasicAuthPasswordKey]
+	if !ok || len(res) == 0 {
+		return "", fmt.Errorf("password secret %q has no %q key", *passwordSecret, corev1.BasicAuthPasswordKey)
+	}
+	if strings.ContainsFunc(string(res), unicode.IsSpace) {
+		return "", fmt.Errorf("password in secret %q must not contain whitespaces", *passwordSecret)
+	}
+	return string(res), nil
+}
+
+// FilterValidHostSessionPasswords drops the L3VNIs, L3VPNs and L3Passthroughs
+// whose host sessions reference a password secret that is missing or
+// invalid, so that only the owning resource fails instead of the whole
+// node configuration.
+func FilterValidHostSessionPasswords(l3Vnis []v1alpha1.L3VNI, l3Vpns []v1alpha1.L3VPN,
+	l3Passthrough []v1alpha1.L3Passthrough, secrets map[string]corev1.Secret) (
+	[]v1alpha1.L3VNI, []v1alpha1.L3VPN, []v1alpha1.L3Passthrough, error) {
+	var allErrors []error
+	resourceError := func(kind v1alpha1.FailedResourceKind, name string, err error) {
+		allErrors = append(allErrors, &openpeerrors.ResourceError{
+			Obj: v1alpha1.FailedResource{
+				Kind:    kind,
+				Name:    name,
+				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+				Message: err.Error(),
+			},
+		})
+	}
+
+	var resultL3VNI []v1alpha1.L3VNI
+	for _, vni := range l3Vnis {
+		if err := hostSessionsPasswordsValid(vrfHostSessions(vni.Spec.HostSession, vni.Spec.HostSessions), secrets); err != nil {
+			resourceError(openpeerrors.KindL3VNI, vni.Name, err)
+			continue
+		}
+		resultL3VNI = append(resultL3VNI, vni)
+	}
+
+	var resultL3VPN []v1alpha1.L3VPN
+	for _, vpn := range l3Vpns {
+		if err := hostSessionsPasswordsValid(vrfHostSessions(vpn.Spec.HostSession, vpn.Spec.HostSessions), secrets); err != nil {
+			resourceError(openpeerrors.KindL3VPN, vpn.Name, err)
+			continue
+		}
+		resultL3VPN = append(resultL3VPN, vpn)
+	}
+
+	var resultPassthrough []v1alpha1.L3Passthrough
+	for _, pt := range l3Passthrough {
+		sessions := []v1alpha1.VRFHostSession{{HostSession: pt.Spec.HostSession}}
+		if err := hostSessionsPasswordsValid(sessions, secrets); err != nil {
+			resourceError(openpeerrors.KindL3Passthrough, pt.Name, err)
+			continue
+		}
+		resultPassthrough = append(resultPassthrough, pt)
+	}
+
+	return resultL3VNI, resultL3VPN, resultPassthrough, errors.Join(allErrors...)
+}
+
+// hostSessionsPasswordsValid returns an error if the password of any of the
+// given host sessions can't be resolved.
+func hostSessionsPasswordsValid(sessions []v1alpha1.VRFHostSession, secrets map[string]corev1.Secret) error {
+	for _, s := range sessions {
+		if _, err := sessionPassword(s.Password, s.PasswordSecret, secrets); err != nil {
+			if s.Name == "" {
+				return fmt.Errorf("invalid host session password: %w", err)
+			}
+			return fmt.Errorf("invalid password for host session %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
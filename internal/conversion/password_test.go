@@ -0,0 +1,135 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestSessionPassword(t *testing.T) {
+	secrets := map[string]corev1.Secret{
+		"valid": {
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3cret")},
+		},
+		"opaque": {
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3cret")},
+		},
+		"nopassword": {
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{corev1.BasicAuthUsernameKey: []byte("user")},
+		},
+		"whitespaces": {
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3 cret")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		password       *string
+		passwordSecret *string
+		want           string
+		wantErr        bool
+	}{
+		{name: "none", want: ""},
+		{name: "inline", password: new("inline"), want: "inline"},
+		{name: "from secret", passwordSecret: new("valid"), want: "s3cret"},
+		{name: "both", password: new("inline"), passwordSecret: new("valid"), wantErr: true},
+		{name: "missing secret", passwordSecret: new("missing"), wantErr: true},
+		{name: "wrong secret type", passwordSecret: new("opaque"), wantErr: true},
+		{name: "no password key", passwordSecret: new("nopassword"), wantErr: true},
+		{name: "password with whitespaces", passwordSecret: new("whitespaces"), wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sessionPassword(tc.password, tc.passwordSecret, secrets)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("sessionPassword() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("sessionPassword() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilterValidHostSessionPasswords(t *testing.T) {
+	secrets := map[string]corev1.Secret{
+		"valid": {
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{corev1.BasicAuthPasswordKey: []byte("s3cret")},
+		},
+		"empty": {
+			Type: corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{corev1.BasicAuthPasswordKey: {}},
+		},
+	}
+	l3vnis := []v1alpha1.L3VNI{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "test"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF:         "red",
+				HostSession: &v1alpha1.HostSession{ASN: 65001, PasswordSecret: new("valid")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "test"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF: "blue",
+				HostSessions: []v1alpha1.VRFHostSession{
+					{Name: "first", HostSession: v1alpha1.HostSession{ASN: 65001}},
+					{Name: "second", HostSession: v1alpha1.HostSession{ASN: 65001, PasswordSecret: new("missing")}},
+				},
+			},
+		},
+	}
+	l3vpns := []v1alpha1.L3VPN{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "yellow", Namespace: "test"},
+			Spec: v1alpha1.L3VPNSpec{
+				VRF:         "yellow",
+				HostSession: &v1alpha1.HostSession{ASN: 65001, PasswordSecret: new("empty")},
+			},
+		},
+	}
+	passthroughs := []v1alpha1.L3Passthrough{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "passthrough", Namespace: "test"},
+			Spec: v1alpha1.L3PassthroughSpec{
+				HostSession: v1alpha1.HostSession{ASN: 65001, Password: new("inline"), PasswordSecret: new("valid")},
+			},
+		},
+	}
+
+	validL3VNIs, validL3VPNs, validPassthroughs, err := FilterValidHostSessionPasswords(l3vnis, l3vpns, passthroughs, secrets)
+	if err == nil {
+		t.Fatal("expected error for invalid host session passwords")
+	}
+	for _, want := range []string{
+		`L3VNI/blue: invalid password for host session second: password secret "missing" not found`,
+		`L3VPN/yellow: invalid host session password: password secret "empty" has no "password" key`,
+		`L3Passthrough/passthrough: invalid host session password: password and passwordSecret are mutually exclusive`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want %q", err, want)
+		}
+	}
+	if len(validL3VNIs) != 1 || validL3VNIs[0].Name != "red" {
+		t.Errorf("expected red to be the only valid l3vni, got %v", validL3VNIs)
+	}
+	if len(validL3VPNs) != 0 {
+		t.Errorf("expected no valid l3vpns, got %v", validL3VPNs)
+	}
+	if len(validPassthroughs) != 0 {
+		t.Errorf("expected no valid passthroughs, got %v", validPassthroughs)
+	}
+}
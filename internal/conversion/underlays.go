@@ -0,0 +1,91 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+// nodeUnderlays are the underlays of a node, the primary one first.
+type nodeUnderlays []v1alpha1.Underlay
+
+// sortUnderlays returns the underlays of a node in name order. The first one
+// is the primary underlay: it provides the router ID and the AS number of the
+// BGP instance and the node-wide settings, and it carries the overlays not
+// referencing an underlay.
+func sortUnderlays(underlays []v1alpha1.Underlay) nodeUnderlays {
+	return slices.SortedStableFunc(slices.Values(underlays), func(a, b v1alpha1.Underlay) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// PrimaryUnderlay returns the primary underlay among the ones of a node.
+func PrimaryUnderlay(underlays []v1alpha1.Underlay) v1alpha1.Underlay {
+	return sortUnderlays(underlays).primary()
+}
+
+func (u nodeUnderlays) primary() v1alpha1.Underlay {
+	return u[0]
+}
+
+func (u nodeUnderlays) additional() []v1alpha1.Underlay {
+	return u[1:]
+}
+
+// neighbors returns the neighbors of all the underlays.
+func (u nodeUnderlays) neighbors() []v1alpha1.Neighbor {
+	res := []v1alpha1.Neighbor{}
+	for _, underlay := range u {
+		res = append(res, underlay.Spec.Neighbors...)
+	}
+	return res
+}
+
+// byName returns the underlay with the given name, the primary one when the
+// name is not set.
+func (u nodeUnderlays) byName(name *string) (v1alpha1.Underlay, error) {
+	if name == nil {
+		return u.primary(), nil
+	}
+	i := slices.IndexFunc(u, func(underlay v1alpha1.Underlay) bool {
+		return underlay.Name == *name
+	})
+	if i < 0 {
+		return v1alpha1.Underlay{}, fmt.Errorf("underlay %s not found", *name)
+	}
+	return u[i], nil
+}
+
+// ridesOn tells if the overlay referencing the given underlay name rides on
+// the underlay.
+func (u nodeUnderlays) ridesOn(ref *string, underlay v1alpha1.Underlay) bool {
+	return ptr.Deref(ref, u.primary().Name) == underlay.Name
+}
+
+// l3vnisOn returns the L3VNIs riding on the given underlay.
+func (u nodeUnderlays) l3vnisOn(underlay v1alpha1.Underlay, l3vnis []v1alpha1.L3VNI) []v1alpha1.L3VNI {
+	res := []v1alpha1.L3VNI{}
+	for _, l3vni := range l3vnis {
+		if u.ridesOn(l3vni.Spec.Underlay, underlay) {
+			res = append(res, l3vni)
+		}
+	}
+	return res
+}
+
+// l2vnisOn returns the L2VNIs riding on the given underlay.
+func (u nodeUnderlays) l2vnisOn(underlay v1alpha1.Underlay, l2vnis []v1alpha1.L2VNI) []v1alpha1.L2VNI {
+	res := []v1alpha1.L2VNI{}
+	for _, l2vni := range l2vnis {
+		if u.ridesOn(l2vni.Spec.Underlay, underlay) {
+			res = append(res, l2vni)
+		}
+	}
+	return res
+}
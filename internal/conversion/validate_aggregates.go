@@ -0,0 +1,141 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// validateAggregates validates the aggregates configured in a VRF.
+func validateAggregates(aggregates []v1alpha1.Aggregate) error {
+	seen := map[string]bool{}
+	for _, a := range aggregates {
+		_, prefix, err := net.ParseCIDR(a.Prefix)
+		if err != nil {
+			return fmt.Errorf("invalid aggregate prefix %q: %w", a.Prefix, err)
+		}
+		if seen[prefix.String()] {
+			return fmt.Errorf("duplicate aggregate %s", prefix.String())
+		}
+		seen[prefix.String()] = true
+	}
+	return nil
+}
+
+// FilterValidAggregates returns the L3VNIs and L3VPNs whose aggregates all
+// lie within a subnet configured in their VRF, alongside per-resource errors
+// for the others. The subnets of a VRF are the gateway subnets of the L2VNIs
+// attached to it, the local CIDRs of its host sessions and the prefixes of
+// its static routes.
+func FilterValidAggregates(l3Vnis []v1alpha1.L3VNI, l3Vpns []v1alpha1.L3VPN,
+	l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L3VNI, []v1alpha1.L3VPN, error) {
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	subnetsForVRF := vrfSubnets(l2Vnis, l3Vnis, l3Vpns)
+
+	var allErrors []error
+	var resultL3VNI []v1alpha1.L3VNI
+	for _, l3 := range l3Vnis {
+		if err := aggregatesWithinSubnets(l3.Spec.Aggregates, l3.Spec.VRF, subnetsForVRF[l3.Spec.VRF]); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L3VNI", Name: l3.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		resultL3VNI = append(resultL3VNI, l3)
+	}
+
+	var resultL3VPN []v1alpha1.L3VPN
+	for _, l3 := range l3Vpns {
+		if err := aggregatesWithinSubnets(l3.Spec.Aggregates, l3.Spec.VRF, subnetsForVRF[l3.Spec.VRF]); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L3VPN", Name: l3.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		resultL3VPN = append(resultL3VPN, l3)
+	}
+
+	return resultL3VNI, resultL3VPN, errors.Join(allErrors...)
+}
+
+// vrfSubnets returns the subnets configured in each VRF, of both families.
+func vrfSubnets(l2Vnis []v1alpha1.L2VNI, l3Vnis []v1alpha1.L3VNI, l3Vpns []v1alpha1.L3VPN) map[string][]*net.IPNet {
+	res := map[string][]*net.IPNet{}
+	vrfMap := createVRFMap(l3Vnis, l3Vpns)
+	for _, l2vni := range l2Vnis {
+		vrfName := resolveVRFForL2VNI(l2vni, vrfMap)
+		if vrfName == "" {
+			continue
+		}
+		if subnet := v4SubnetForL2(l2vni); subnet != nil {
+			res[vrfName] = append(res[vrfName], subnet)
+		}
+		if subnet := v6SubnetForL2(l2vni); subnet != nil {
+			res[vrfName] = append(res[vrfName], subnet)
+		}
+	}
+	for _, l3vni := range l3Vnis {
+		vrfName := l3vni.Spec.VRF
+		res[vrfName] = append(res[vrfName], v4SubnetsForL3(l3vni)...)
+		res[vrfName] = append(res[vrfName], v6SubnetsForL3(l3vni)...)
+		res[vrfName] = append(res[vrfName], staticRoutesSubnets(l3vni.Spec.StaticRoutes)...)
+	}
+	for _, l3vpn := range l3Vpns {
+		vrfName := l3vpn.Spec.VRF
+		res[vrfName] = append(res[vrfName], v4SubnetsForL3VPN(l3vpn)...)
+		res[vrfName] = append(res[vrfName], v6SubnetsForL3VPN(l3vpn)...)
+		res[vrfName] = append(res[vrfName], staticRoutesSubnets(l3vpn.Spec.StaticRoutes)...)
+	}
+	return res
+}
+
+// staticRoutesSubnets extracts the valid prefixes of the static routes.
+func staticRoutesSubnets(routes []v1alpha1.StaticRoute) []*net.IPNet {
+	var res []*net.IPNet
+	for _, r := range routes {
+		_, ipnet, err := net.ParseCIDR(r.Prefix)
+		if err != nil {
+			continue
+		}
+		res = append(res, ipnet)
+	}
+	return res
+}
+
+// aggregatesWithinSubnets checks that each aggregate is contained in one
+// of the given subnets.
+func aggregatesWithinSubnets(aggregates []v1alpha1.Aggregate, vrf string, subnets []*net.IPNet) error {
+	for _, a := range aggregates {
+		_, prefix, err := net.ParseCIDR(a.Prefix)
+		if err != nil {
+			return fmt.Errorf("invalid aggregate prefix %q: %w", a.Prefix, err)
+		}
+		if !subnetsContain(subnets, prefix) {
+			return fmt.Errorf("aggregate %s is not within any subnet configured in vrf %q", prefix.String(), vrf)
+		}
+	}
+	return nil
+}
+
+func subnetsContain(subnets []*net.IPNet, prefix *net.IPNet) bool {
+	prefixLen, bits := prefix.Mask.Size()
+	for _, s := range subnets {
+		subnetLen, subnetBits := s.Mask.Size()
+		if subnetBits != bits || subnetLen > prefixLen {
+			continue
+		}
+		if s.Contains(prefix.IP) {
+			return true
+		}
+	}
+	return false
+}
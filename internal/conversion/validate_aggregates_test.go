@@ -0,0 +1,136 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateAggregates(t *testing.T) {
+	tests := []struct {
+		name       string
+		aggregates []v1alpha1.Aggregate
+		wantErr    bool
+	}{
+		{
+			name:       "no aggregates",
+			aggregates: nil,
+			wantErr:    false,
+		},
+		{
+			name: "valid aggregates",
+			aggregates: []v1alpha1.Aggregate{
+				{Prefix: "10.100.0.0/16", SummaryOnly: new(true)},
+				{Prefix: "2001:db8:100::/48", ASSet: new(true)},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "invalid prefix",
+			aggregates: []v1alpha1.Aggregate{{Prefix: "10.100.0.0"}},
+			wantErr:    true,
+		},
+		{
+			name: "duplicate aggregates",
+			aggregates: []v1alpha1.Aggregate{
+				{Prefix: "10.100.0.0/16"},
+				{Prefix: "10.100.0.1/16", SummaryOnly: new(true)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAggregates(tc.aggregates)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateAggregates() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterValidAggregates(t *testing.T) {
+	l3vnis := []v1alpha1.L3VNI{
+		{
+			// red aggregates the gateway subnet of its L2VNI.
+			ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "test"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF:        "red",
+				Aggregates: []v1alpha1.Aggregate{{Prefix: "192.170.1.0/24"}},
+			},
+		},
+		{
+			// blue aggregates within the local CIDR of its host session
+			// and the prefix of its static route.
+			ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "test"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF: "blue",
+				HostSession: &v1alpha1.HostSession{
+					ASN: 65001, HostASN: new(int64(65002)),
+					LocalCIDR: v1alpha1.LocalCIDRConfig{IPv6: new("2001:db8::/64")},
+				},
+				StaticRoutes: []v1alpha1.StaticRoute{{Prefix: "10.100.0.0/16", Blackhole: new(true)}},
+				Aggregates: []v1alpha1.Aggregate{
+					{Prefix: "2001:db8::/96"},
+					{Prefix: "10.100.1.0/24"},
+				},
+			},
+		},
+		{
+			// green aggregates a prefix wider than its L2VNI subnet.
+			ObjectMeta: metav1.ObjectMeta{Name: "green", Namespace: "test"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF:        "green",
+				Aggregates: []v1alpha1.Aggregate{{Prefix: "192.171.0.0/16"}},
+			},
+		},
+	}
+	l3vpns := []v1alpha1.L3VPN{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "yellow", Namespace: "test"},
+			Spec: v1alpha1.L3VPNSpec{
+				VRF:        "yellow",
+				Aggregates: []v1alpha1.Aggregate{{Prefix: "10.200.0.0/24"}},
+			},
+		},
+	}
+	l2vni := func(name, l3vni, gatewayIP string) v1alpha1.L2VNI {
+		return v1alpha1.L2VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: v1alpha1.L2VNISpec{
+				RoutingDomain: &v1alpha1.RoutingDomain{
+					Type:  v1alpha1.RoutingDomainTypeL3VNI,
+					L3VNI: &v1alpha1.L3VNIReference{Name: l3vni},
+				},
+				GatewayIPs: []string{gatewayIP},
+			},
+		}
+	}
+	l2vnis := []v1alpha1.L2VNI{
+		l2vni("red-l2", "red", "192.170.1.1/24"),
+		l2vni("green-l2", "green", "192.171.1.1/24"),
+	}
+
+	validL3VNIs, validL3VPNs, err := FilterValidAggregates(l3vnis, l3vpns, l2vnis)
+	if err == nil {
+		t.Fatal("expected error for aggregates outside of the vrf subnets")
+	}
+	for _, want := range []string{`aggregate 192.171.0.0/16 is not within any subnet configured in vrf "green"`,
+		`aggregate 10.200.0.0/24 is not within any subnet configured in vrf "yellow"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want %q", err, want)
+		}
+	}
+	if len(validL3VNIs) != 2 || validL3VNIs[0].Name != "red" || validL3VNIs[1].Name != "blue" {
+		t.Errorf("expected red and blue to be valid, got %v", validL3VNIs)
+	}
+	if len(validL3VPNs) != 0 {
+		t.Errorf("expected no valid l3vpns, got %v", validL3VPNs)
+	}
+}
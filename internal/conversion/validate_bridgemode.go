@@ -0,0 +1,112 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+	"k8s.io/utils/ptr"
+)
+
+const maxVLAN = 4094
+
+// FilterValidVLANAwareL2VNIs returns the L2VNIs that can be mapped to a VLAN
+// of the VLAN-aware bridge when the underlay sets evpn.bridgeMode to
+// VLANAware, alongside per-resource errors for the others. As they share a
+// single VXLan device, they must be mapped to distinct VLANs and use the
+// vxlanPort, underlayAddressFamily and vxlanTunnel of the first one.
+func FilterValidVLANAwareL2VNIs(underlays []v1alpha1.Underlay, l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L2VNI, error) {
+	if len(underlays) == 0 || !isVLANAwareBridge(PrimaryUnderlay(underlays).Spec.EVPN) {
+		return l2Vnis, nil
+	}
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	vlans := map[uint16]string{}
+	var first *v1alpha1.L2VNI
+	var validL2 []v1alpha1.L2VNI
+	for _, l2 := range l2Vnis {
+		vlan, err := validateVLANAwareL2VNI(l2, first, vlans)
+		if err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L2VNI", Name: l2.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		vlans[vlan] = "L2VNI/" + l2.Name
+		if first == nil {
+			first = &l2
+		}
+		validL2 = append(validL2, l2)
+	}
+
+	return validL2, errors.Join(allErrors...)
+}
+
+// validateVLANAwareL2VNI checks that the L2VNI can share the VXLan device
+// of the VLAN-aware bridge with the first valid one, and returns its VLAN.
+func validateVLANAwareL2VNI(l2 v1alpha1.L2VNI, first *v1alpha1.L2VNI, vlans map[uint16]string) (uint16, error) {
+	vlan, err := l2vniVLAN(l2)
+	if err != nil {
+		return 0, err
+	}
+	if existing, ok := vlans[vlan]; ok {
+		return 0, fmt.Errorf("duplicate vlan %d:%s", vlan, existing)
+	}
+	if !ptr.Deref(l2.Spec.ARPNDSuppression, true) || ptr.Deref(l2.Spec.ProxyARP, false) {
+		return 0, errors.New("arpNDSuppression and proxyARP cannot be changed in VLANAware bridge mode")
+	}
+	if first == nil {
+		return vlan, nil
+	}
+	if port, firstPort := *vxlanPort(l2.Spec.VXLanPort), *vxlanPort(first.Spec.VXLanPort); port != firstPort {
+		return 0, fmt.Errorf("vxlanPort %d differs from the vxlanPort %d of L2VNI %s, sharing the same VXLan device in VLANAware bridge mode",
+			port, firstPort, first.Name)
+	}
+	if af, firstAF := ptr.Deref(l2.Spec.UnderlayAddressFamily, ""), ptr.Deref(first.Spec.UnderlayAddressFamily, ""); af != firstAF {
+		return 0, fmt.Errorf("underlayAddressFamily %q differs from the underlayAddressFamily %q of L2VNI %s, sharing the same VXLan device in VLANAware bridge mode",
+			af, firstAF, first.Name)
+	}
+	if !reflect.DeepEqual(l2.Spec.VXLanTunnel, first.Spec.VXLanTunnel) {
+		return 0, fmt.Errorf("vxlanTunnel differs from the vxlanTunnel of L2VNI %s, sharing the same VXLan device in VLANAware bridge mode",
+			first.Name)
+	}
+	return vlan, nil
+}
+
+// isVLANAwareBridge tells if the L2VNIs are mapped to the VLANs of a
+// VLAN-aware bridge instead of getting their own bridge.
+func isVLANAwareBridge(evpn *v1alpha1.EVPNConfig) bool {
+	if evpn == nil {
+		return false
+	}
+	return ptr.Deref(evpn.BridgeMode, v1alpha1.EVPNBridgeModePerVNI) == v1alpha1.EVPNBridgeModeVLANAware
+}
+
+// l2vniVLAN returns the VLAN the L2VNI is mapped to on the VLAN-aware bridge,
+// falling back to the VNI.
+func l2vniVLAN(l2vni v1alpha1.L2VNI) (uint16, error) {
+	if l2vni.Spec.VLAN != nil {
+		if err := validateVLAN(*l2vni.Spec.VLAN); err != nil {
+			return 0, err
+		}
+		return uint16(*l2vni.Spec.VLAN), nil
+	}
+	if validateVLAN(l2vni.Spec.VNI) != nil {
+		return 0, fmt.Errorf("vni %d is not a valid vlan, vlan must be set in VLANAware bridge mode", l2vni.Spec.VNI)
+	}
+	return uint16(l2vni.Spec.VNI), nil
+}
+
+func validateVLAN(vlan int32) error {
+	if vlan < 1 || vlan > maxVLAN {
+		return fmt.Errorf("invalid vlan %d, must be between 1 and %d", vlan, maxVLAN)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestFilterValidVLANAwareL2VNIs(t *testing.T) {
+	l2vni := func(name string, vni int32, spec v1alpha1.L2VNISpec) v1alpha1.L2VNI {
+		spec.VNI = vni
+		return v1alpha1.L2VNI{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}, Spec: spec}
+	}
+	underlay := func(mode *v1alpha1.EVPNBridgeMode) []v1alpha1.Underlay {
+		return []v1alpha1.Underlay{{Spec: v1alpha1.UnderlaySpec{EVPN: &v1alpha1.EVPNConfig{BridgeMode: mode}}}}
+	}
+
+	tests := []struct {
+		name       string
+		underlays  []v1alpha1.Underlay
+		l2vnis     []v1alpha1.L2VNI
+		wantValid  []string
+		wantErrors []string
+	}{
+		{
+			name:      "per vni bridge mode ignores the vlans",
+			underlays: underlay(nil),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 10000, v1alpha1.L2VNISpec{}),
+				l2vni("b", 10001, v1alpha1.L2VNISpec{VXLanPort: new(int32(4790))}),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name:      "valid vlans",
+			underlays: underlay(new(v1alpha1.EVPNBridgeModeVLANAware)),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 100, v1alpha1.L2VNISpec{}),
+				l2vni("b", 10001, v1alpha1.L2VNISpec{VLAN: new(int32(101)), ARPNDSuppression: new(true)}),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name:      "vni not usable as vlan",
+			underlays: underlay(new(v1alpha1.EVPNBridgeModeVLANAware)),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 10000, v1alpha1.L2VNISpec{}),
+			},
+			wantErrors: []string{"vni 10000 is not a valid vlan"},
+		},
+		{
+			name:      "duplicate vlan",
+			underlays: underlay(new(v1alpha1.EVPNBridgeModeVLANAware)),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 100, v1alpha1.L2VNISpec{}),
+				l2vni("b", 10001, v1alpha1.L2VNISpec{VLAN: new(int32(100))}),
+			},
+			wantValid:  []string{"a"},
+			wantErrors: []string{"duplicate vlan 100:L2VNI/a"},
+		},
+		{
+			name:      "port flags changed",
+			underlays: underlay(new(v1alpha1.EVPNBridgeModeVLANAware)),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 100, v1alpha1.L2VNISpec{ARPNDSuppression: new(false)}),
+				l2vni("b", 101, v1alpha1.L2VNISpec{ProxyARP: new(true)}),
+			},
+			wantErrors: []string{"arpNDSuppression and proxyARP cannot be changed"},
+		},
+		{
+			name:      "different vxlan port and address family",
+			underlays: underlay(new(v1alpha1.EVPNBridgeModeVLANAware)),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 100, v1alpha1.L2VNISpec{VXLanPort: new(int32(4789))}),
+				l2vni("b", 101, v1alpha1.L2VNISpec{}),
+				l2vni("c", 102, v1alpha1.L2VNISpec{VXLanPort: new(int32(4790))}),
+				l2vni("d", 103, v1alpha1.L2VNISpec{UnderlayAddressFamily: new("IPv6")}),
+			},
+			wantValid: []string{"a", "b"},
+			wantErrors: []string{
+				"vxlanPort 4790 differs from the vxlanPort 4789 of L2VNI a",
+				`underlayAddressFamily "IPv6" differs from the underlayAddressFamily "" of L2VNI a`,
+			},
+		},
+		{
+			name:      "different vxlan tunnel",
+			underlays: underlay(new(v1alpha1.EVPNBridgeModeVLANAware)),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", 100, v1alpha1.L2VNISpec{VXLanTunnel: &v1alpha1.VXLanTunnelConfig{DSCP: new(int32(46))}}),
+				l2vni("b", 101, v1alpha1.L2VNISpec{VXLanTunnel: &v1alpha1.VXLanTunnelConfig{DSCP: new(int32(46))}}),
+				l2vni("c", 102, v1alpha1.L2VNISpec{}),
+			},
+			wantValid:  []string{"a", "b"},
+			wantErrors: []string{"vxlanTunnel differs from the vxlanTunnel of L2VNI a"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := FilterValidVLANAwareL2VNIs(tc.underlays, tc.l2vnis)
+			var validNames []string
+			for _, l2 := range valid {
+				validNames = append(validNames, l2.Name)
+			}
+			if strings.Join(validNames, ",") != strings.Join(tc.wantValid, ",") {
+				t.Errorf("valid L2VNIs = %v, want %v", validNames, tc.wantValid)
+			}
+			if len(tc.wantErrors) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tc.wantErrors {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
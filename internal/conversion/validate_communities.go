@@ -0,0 +1,105 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+// wellKnownCommunities are the standard communities that can be referenced
+// by name instead of the AA:NN format.
+var wellKnownCommunities = map[string]bool{
+	"no-export":    true,
+	"no-advertise": true,
+	"local-AS":     true,
+	"no-peer":      true,
+}
+
+// validateVRFCommunities validates the communities set on the routes
+// exported from a VRF, and the ones matched on import.
+func validateVRFCommunities(communities *v1alpha1.VRFCommunities) error {
+	if communities == nil {
+		return nil
+	}
+	if communities.Export == nil && len(communities.ExportPrefixes) == 0 && communities.ImportMatch == nil {
+		return fmt.Errorf("at least one of export, exportPrefixes or importMatch must be set")
+	}
+	if communities.Export != nil {
+		if err := validateCommunities(*communities.Export); err != nil {
+			return fmt.Errorf("invalid export communities: %w", err)
+		}
+	}
+	for _, p := range communities.ExportPrefixes {
+		if _, _, err := net.ParseCIDR(p.Prefix); err != nil {
+			return fmt.Errorf("invalid export prefix %q: %w", p.Prefix, err)
+		}
+		if err := validateCommunities(p.Communities); err != nil {
+			return fmt.Errorf("invalid communities for export prefix %s: %w", p.Prefix, err)
+		}
+	}
+	if communities.ImportMatch != nil {
+		if err := validateCommunities(*communities.ImportMatch); err != nil {
+			return fmt.Errorf("invalid import communities: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateCommunities(communities v1alpha1.Communities) error {
+	if len(communities.Standard) == 0 && len(communities.Large) == 0 && len(communities.Extended) == 0 {
+		return fmt.Errorf("at least one community must be set")
+	}
+	for _, c := range communities.Standard {
+		if err := validateStandardCommunity(c); err != nil {
+			return err
+		}
+	}
+	for _, c := range communities.Large {
+		if err := validateLargeCommunity(c); err != nil {
+			return err
+		}
+	}
+	for _, c := range communities.Extended {
+		if c.Type != v1alpha1.ExtendedCommunityRouteTarget && c.Type != v1alpha1.ExtendedCommunitySiteOfOrigin {
+			return fmt.Errorf("invalid extended community type %q", c.Type)
+		}
+		if err := validateRouteTarget(c.Value); err != nil {
+			return fmt.Errorf("invalid extended community %q: %w", c.Value, err)
+		}
+	}
+	return nil
+}
+
+func validateStandardCommunity(c string) error {
+	if wellKnownCommunities[c] {
+		return nil
+	}
+	parts := strings.Split(c, ":")
+	if len(parts) != 2 {
+		return fmt.Errorf("community %q must have the AA:NN format or be a well known community", c)
+	}
+	for _, p := range parts {
+		if _, err := strconv.ParseUint(p, 10, 16); err != nil {
+			return fmt.Errorf("community %q must have the AA:NN format where AA and NN are <= 65535", c)
+		}
+	}
+	return nil
+}
+
+func validateLargeCommunity(c string) error {
+	parts := strings.Split(c, ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("large community %q must have the GA:LD1:LD2 format", c)
+	}
+	for _, p := range parts {
+		if _, err := strconv.ParseUint(p, 10, 32); err != nil {
+			return fmt.Errorf("large community %q must have the GA:LD1:LD2 format where each field is <= 4294967295", c)
+		}
+	}
+	return nil
+}
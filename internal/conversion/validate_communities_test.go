@@ -0,0 +1,114 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateVRFCommunities(t *testing.T) {
+	tests := []struct {
+		name        string
+		communities *v1alpha1.VRFCommunities
+		wantErr     bool
+	}{
+		{
+			name:        "no communities",
+			communities: nil,
+			wantErr:     false,
+		},
+		{
+			name: "valid communities",
+			communities: &v1alpha1.VRFCommunities{
+				Export: &v1alpha1.Communities{
+					Standard: []string{"64512:100", "no-export"},
+					Large:    []string{"64512:1:100"},
+					Extended: []v1alpha1.ExtendedCommunity{
+						{Type: v1alpha1.ExtendedCommunitySiteOfOrigin, Value: "64512:1"},
+						{Type: v1alpha1.ExtendedCommunityRouteTarget, Value: "10.0.0.1:100"},
+					},
+				},
+				ExportPrefixes: []v1alpha1.PrefixCommunities{
+					{Prefix: "192.168.10.0/24", Communities: v1alpha1.Communities{Standard: []string{"64512:200"}}},
+					{Prefix: "2001:db8::/64", Communities: v1alpha1.Communities{Large: []string{"4200000000:1:1"}}},
+				},
+				ImportMatch: &v1alpha1.Communities{Standard: []string{"64512:300"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "nothing set",
+			communities: &v1alpha1.VRFCommunities{},
+			wantErr:     true,
+		},
+		{
+			name: "empty communities",
+			communities: &v1alpha1.VRFCommunities{
+				ImportMatch: &v1alpha1.Communities{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "standard community out of range",
+			communities: &v1alpha1.VRFCommunities{
+				Export: &v1alpha1.Communities{Standard: []string{"65536:100"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown well known community",
+			communities: &v1alpha1.VRFCommunities{
+				Export: &v1alpha1.Communities{Standard: []string{"internet-reachable"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "large community with two fields",
+			communities: &v1alpha1.VRFCommunities{
+				ImportMatch: &v1alpha1.Communities{Large: []string{"64512:100"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid extended community type",
+			communities: &v1alpha1.VRFCommunities{
+				Export: &v1alpha1.Communities{Extended: []v1alpha1.ExtendedCommunity{
+					{Type: "Color", Value: "64512:1"},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid extended community value",
+			communities: &v1alpha1.VRFCommunities{
+				Export: &v1alpha1.Communities{Extended: []v1alpha1.ExtendedCommunity{
+					{Type: v1alpha1.ExtendedCommunityRouteTarget, Value: "64512"},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid export prefix",
+			communities: &v1alpha1.VRFCommunities{
+				ExportPrefixes: []v1alpha1.PrefixCommunities{
+					{Prefix: "192.168.10.0", Communities: v1alpha1.Communities{Standard: []string{"64512:200"}}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVRFCommunities(tt.communities)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateVRFCommunities() expected error but got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateVRFCommunities() unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -27,6 +27,10 @@ func (g *GroutDatapathConfigValidator) Validate(apiConfig APIConfigData) error {
 	for _, underlay := range apiConfig.Underlays {
 		resourceErrors = append(resourceErrors, ValidateGroutUnderlay(underlay))
 	}
+	if len(apiConfig.Underlays) > 1 {
+		resourceErrors = append(resourceErrors,
+			errors.New("more than one underlay per node is not supported when grout datapath is enabled"))
+	}
 	return errors.Join(resourceErrors...)
 }
 
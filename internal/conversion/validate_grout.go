@@ -27,6 +27,15 @@ func ValidateGroutUnderlay(underlay v1alpha1.Underlay) error {
 		if iface.Type == v1alpha1.UnderlayInterfaceTypeCNIDevice {
 			return fmt.Errorf("CNI dev underlays are not supported with the grout datapath")
 		}
+		if iface.Type == v1alpha1.UnderlayInterfaceTypeBond {
+			return fmt.Errorf("bond underlays are not supported with the grout datapath")
+		}
+		if iface.Type == v1alpha1.UnderlayInterfaceTypeVLAN {
+			return fmt.Errorf("VLAN underlays are not supported with the grout datapath")
+		}
+	}
+	if underlay.Spec.Multipath != nil && underlay.Spec.Multipath.HashPolicy != nil {
+		return fmt.Errorf("multipath hash policy is not supported with the grout datapath")
 	}
 
 	// The grout port name is the interface name with the underlay prefix,
@@ -76,6 +76,45 @@ func TestValidateGroutUnderlayCNI(t *testing.T) {
 	}
 }
 
+func TestValidateGroutUnderlayMultipath(t *testing.T) {
+	tests := []struct {
+		name      string
+		multipath *v1alpha1.UnderlayMultipathConfig
+		wantErr   string
+	}{
+		{
+			name: "multipath without hash policy should be accepted on grout",
+			multipath: &v1alpha1.UnderlayMultipathConfig{
+				MultipathConfig: v1alpha1.MultipathConfig{ASPathRelax: new(true)},
+			},
+		},
+		{
+			name: "multipath hash policy should be rejected on grout",
+			multipath: &v1alpha1.UnderlayMultipathConfig{
+				HashPolicy: new(v1alpha1.MultipathHashPolicyLayer4),
+			},
+			wantErr: "multipath hash policy is not supported with the grout datapath",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			underlay := v1alpha1.Underlay{
+				Spec: v1alpha1.UnderlaySpec{
+					Multipath: tt.multipath,
+				},
+			}
+			obtainedErr := ""
+			err := ValidateGroutUnderlay(underlay)
+			if err != nil {
+				obtainedErr = err.Error()
+			}
+			if obtainedErr != tt.wantErr {
+				t.Errorf("ValidateGroutUnderlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateGroutUnderlay(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -4,12 +4,14 @@ package conversion
 
 import (
 	"fmt"
+	"net"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/filter"
+	"github.com/openperouter/openperouter/internal/hostnetwork"
 )
 
 type hostSessionInfo struct {
@@ -17,6 +19,24 @@ type hostSessionInfo struct {
 	name string
 }
 
+// vrfHostSessions returns the host sessions of an L3VNI or L3VPN. The
+// single hostSession is returned as an unnamed session.
+func vrfHostSessions(hostSession *v1alpha1.HostSession, hostSessions []v1alpha1.VRFHostSession) []v1alpha1.VRFHostSession {
+	if hostSession != nil {
+		return []v1alpha1.VRFHostSession{{HostSession: *hostSession}}
+	}
+	return hostSessions
+}
+
+// hostSessionInfoName returns the name used to refer to the given host
+// session of the resource in the validation errors.
+func hostSessionInfoName(resource string, session v1alpha1.VRFHostSession) string {
+	if session.Name == "" {
+		return resource
+	}
+	return fmt.Sprintf("%s session %s", resource, session.Name)
+}
+
 func ValidateHostSessionsForNodes(nodes []corev1.Node, l3VNIs []v1alpha1.L3VNI, l3Passthrough []v1alpha1.L3Passthrough) error {
 	for _, node := range nodes {
 		filteredL3VNIs, err := filter.L3VNIsForNode(&node, l3VNIs)
@@ -37,10 +57,9 @@ func ValidateHostSessionsForNodes(nodes []corev1.Node, l3VNIs []v1alpha1.L3VNI,
 func ValidateHostSessions(l3VNIs []v1alpha1.L3VNI, l3Passthrough []v1alpha1.L3Passthrough) error {
 	hostSessions := []hostSessionInfo{}
 	for _, vni := range l3VNIs {
-		if vni.Spec.HostSession == nil {
-			continue
+		for _, s := range vrfHostSessions(vni.Spec.HostSession, vni.Spec.HostSessions) {
+			hostSessions = append(hostSessions, hostSessionInfo{HostSession: s.HostSession, name: hostSessionInfoName("l3vni "+vni.Name, s)})
 		}
-		hostSessions = append(hostSessions, hostSessionInfo{HostSession: *vni.Spec.HostSession, name: "l3vni " + vni.Name})
 	}
 	for _, passthrough := range l3Passthrough {
 		hostSessions = append(hostSessions, hostSessionInfo{HostSession: passthrough.Spec.HostSession, name: "l3passthrough " + passthrough.Name})
@@ -86,3 +105,117 @@ func validateCIDR(session hostSessionInfo, cidr string, existingCIDRs map[string
 	}
 	return nil
 }
+
+// validateVRFHostSessions validates the host sessions of an L3VNI or L3VPN,
+// and their import and export policies.
+func validateVRFHostSessions(hostSession *v1alpha1.HostSession, hostSessions []v1alpha1.VRFHostSession) error {
+	if hostSession != nil && len(hostSessions) > 0 {
+		return fmt.Errorf("hostSession and hostSessions cannot be set together")
+	}
+	names := map[string]bool{}
+	vethIDs := map[string]string{}
+	for i, s := range vrfHostSessions(hostSession, hostSessions) {
+		if hostSession == nil && s.Name == "" {
+			return fmt.Errorf("host session name must be set")
+		}
+		if names[s.Name] {
+			return fmt.Errorf("duplicate host session name %q", s.Name)
+		}
+		names[s.Name] = true
+		// The veths of the sessions following the first one are named after
+		// a short hash of the session name, which must not collide.
+		if i > 0 {
+			vethID := hostnetwork.HostSessionVethID(s.Name)
+			if other, ok := vethIDs[vethID]; ok {
+				return fmt.Errorf("host sessions %q and %q map to the same veth name, rename one of them", other, s.Name)
+			}
+			vethIDs[vethID] = s.Name
+		}
+		if err := validateHostSessionPolicies(&s.HostSession); err != nil {
+			if s.Name == "" {
+				return err
+			}
+			return fmt.Errorf("session %q: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateHostSessionPolicies validates the import and export policies of
+// the given host session.
+func validateHostSessionPolicies(hostSession *v1alpha1.HostSession) error {
+	if hostSession == nil {
+		return nil
+	}
+	if err := validatePrefixPolicy(hostSession.ImportPolicy); err != nil {
+		return fmt.Errorf("invalid import policy: %w", err)
+	}
+	if err := validatePrefixPolicy(hostSession.ExportPolicy); err != nil {
+		return fmt.Errorf("invalid export policy: %w", err)
+	}
+	if err := validateDefaultOriginate(hostSession.DefaultOriginate); err != nil {
+		return fmt.Errorf("invalid default originate: %w", err)
+	}
+	if hostSession.ExportPolicy != nil && onlyDefaultRoute(*hostSession) {
+		return fmt.Errorf("export policy cannot be set when only the default route is advertised")
+	}
+	return nil
+}
+
+func validateDefaultOriginate(defaultOriginate *v1alpha1.DefaultOriginate) error {
+	if defaultOriginate == nil {
+		return nil
+	}
+	switch ptr.Deref(defaultOriginate.IPFamily, v1alpha1.IPFamilyDualStack) {
+	case v1alpha1.IPFamilyIPv4, v1alpha1.IPFamilyIPv6, v1alpha1.IPFamilyDualStack:
+	default:
+		return fmt.Errorf("invalid ip family %q", *defaultOriginate.IPFamily)
+	}
+	for _, p := range defaultOriginate.ConditionPrefixes {
+		if _, _, err := net.ParseCIDR(p); err != nil {
+			return fmt.Errorf("invalid condition prefix %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+func validatePrefixPolicy(policy *v1alpha1.PrefixPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if len(policy.Rules) == 0 {
+		return fmt.Errorf("at least one rule must be provided")
+	}
+	for i, rule := range policy.Rules {
+		if err := validatePrefixRule(rule); err != nil {
+			return fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validatePrefixRule checks the rule can be rendered as a prefix-list entry,
+// which requires prefix length < ge <= le <= address length.
+func validatePrefixRule(rule v1alpha1.PrefixRule) error {
+	_, ipnet, err := net.ParseCIDR(rule.Prefix)
+	if err != nil {
+		return fmt.Errorf("invalid prefix %q: %w", rule.Prefix, err)
+	}
+	if rule.Action != nil &&
+		*rule.Action != v1alpha1.PrefixRuleActionPermit && *rule.Action != v1alpha1.PrefixRuleActionDeny {
+		return fmt.Errorf("invalid action %q for prefix %s", *rule.Action, rule.Prefix)
+	}
+	ones, bits := ipnet.Mask.Size()
+	if rule.GE != nil && (int(*rule.GE) <= ones || int(*rule.GE) > bits) {
+		return fmt.Errorf("ge %d for prefix %s must be greater than %d and lower than or equal to %d",
+			*rule.GE, rule.Prefix, ones, bits)
+	}
+	if rule.LE != nil && (int(*rule.LE) <= ones || int(*rule.LE) > bits) {
+		return fmt.Errorf("le %d for prefix %s must be greater than %d and lower than or equal to %d",
+			*rule.LE, rule.Prefix, ones, bits)
+	}
+	if rule.GE != nil && rule.LE != nil && *rule.GE > *rule.LE {
+		return fmt.Errorf("ge %d for prefix %s must be lower than or equal to le %d", *rule.GE, rule.Prefix, *rule.LE)
+	}
+	return nil
+}
@@ -274,6 +274,61 @@ func TestValidateHostSessions(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "multiple host sessions",
+			l3VNIs: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI: 1001,
+						HostSessions: []v1alpha1.VRFHostSession{
+							{Name: "metallb", HostSession: v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.0/24")}}},
+							{Name: "calico", HostSession: v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65003)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.168.2.0/24")}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "overlapping CIDRs between host sessions of the same l3vni",
+			l3VNIs: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI: 1001,
+						HostSessions: []v1alpha1.VRFHostSession{
+							{Name: "metallb", HostSession: v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv6: new("2001:db8::/64")}}},
+							{Name: "calico", HostSession: v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65003)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv6: new("2001:db8::/80")}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "overlapping CIDRs between host sessions of different l3vnis",
+			l3VNIs: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI:         1001,
+						HostSession: &v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.0/24")}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni2"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI: 1002,
+						HostSessions: []v1alpha1.VRFHostSession{
+							{Name: "metallb", HostSession: v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.168.2.0/24")}}},
+							{Name: "calico", HostSession: v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65003)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.128/25")}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -288,3 +343,217 @@ func TestValidateHostSessions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHostSessionPolicies(t *testing.T) {
+	deny := v1alpha1.PrefixRuleActionDeny
+	tests := []struct {
+		name        string
+		hostSession *v1alpha1.HostSession
+		wantErr     bool
+	}{
+		{
+			name:        "no host session",
+			hostSession: nil,
+			wantErr:     false,
+		},
+		{
+			name:        "no policies",
+			hostSession: &v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002))},
+			wantErr:     false,
+		},
+		{
+			name: "valid import and export policies",
+			hostSession: &v1alpha1.HostSession{
+				ImportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "10.100.10.0/24", Action: &deny, LE: new(int32(32))},
+					{Prefix: "10.100.0.0/16", GE: new(int32(24)), LE: new(int32(28))},
+					{Prefix: "2001:db8:100::/48", GE: new(int32(64))},
+				}},
+				ExportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "0.0.0.0/0"},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty rules",
+			hostSession: &v1alpha1.HostSession{
+				ImportPolicy: &v1alpha1.PrefixPolicy{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid prefix",
+			hostSession: &v1alpha1.HostSession{
+				ExportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "10.100.0.0"},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid action",
+			hostSession: &v1alpha1.HostSession{
+				ImportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "10.100.0.0/16", Action: new(v1alpha1.PrefixRuleAction("Reject"))},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ge not greater than the prefix length",
+			hostSession: &v1alpha1.HostSession{
+				ImportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "10.100.0.0/16", GE: new(int32(16))},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "le greater than the address length",
+			hostSession: &v1alpha1.HostSession{
+				ImportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "10.100.0.0/16", LE: new(int32(33))},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ge greater than le",
+			hostSession: &v1alpha1.HostSession{
+				ImportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "2001:db8::/32", GE: new(int32(64)), LE: new(int32(48))},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid default originate",
+			hostSession: &v1alpha1.HostSession{
+				DefaultOriginate: &v1alpha1.DefaultOriginate{
+					IPFamily:          new(v1alpha1.IPFamilyIPv4),
+					ConditionPrefixes: []string{"10.0.0.0/8"},
+					OnlyDefaultRoute:  new(true),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "default originate with invalid condition prefix",
+			hostSession: &v1alpha1.HostSession{
+				DefaultOriginate: &v1alpha1.DefaultOriginate{
+					ConditionPrefixes: []string{"10.0.0.0"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "only default route with export policy",
+			hostSession: &v1alpha1.HostSession{
+				ExportPolicy: &v1alpha1.PrefixPolicy{Rules: []v1alpha1.PrefixRule{
+					{Prefix: "10.100.0.0/16"},
+				}},
+				DefaultOriginate: &v1alpha1.DefaultOriginate{
+					OnlyDefaultRoute: new(true),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHostSessionPolicies(tt.hostSession)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateHostSessionPolicies() expected error but got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateHostSessionPolicies() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateVRFHostSessions(t *testing.T) {
+	hostSession := v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002)), LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.0/24")}}
+	tests := []struct {
+		name         string
+		hostSession  *v1alpha1.HostSession
+		hostSessions []v1alpha1.VRFHostSession
+		wantErr      bool
+	}{
+		{
+			name:        "single host session",
+			hostSession: &hostSession,
+			wantErr:     false,
+		},
+		{
+			name: "multiple host sessions",
+			hostSessions: []v1alpha1.VRFHostSession{
+				{Name: "metallb", HostSession: hostSession},
+				{Name: "calico", HostSession: hostSession},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "both hostSession and hostSessions",
+			hostSession: &hostSession,
+			hostSessions: []v1alpha1.VRFHostSession{
+				{Name: "metallb", HostSession: hostSession},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unnamed host session",
+			hostSessions: []v1alpha1.VRFHostSession{
+				{HostSession: hostSession},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			hostSessions: []v1alpha1.VRFHostSession{
+				{Name: "metallb", HostSession: hostSession},
+				{Name: "metallb", HostSession: hostSession},
+			},
+			wantErr: true,
+		},
+		{
+			name: "names mapping to the same veth name",
+			hostSessions: []v1alpha1.VRFHostSession{
+				{Name: "metallb", HostSession: hostSession},
+				{Name: "session-138", HostSession: hostSession},
+				{Name: "session-220", HostSession: hostSession},
+			},
+			wantErr: true,
+		},
+		{
+			name: "first session name mapping to the same veth name as another one",
+			hostSessions: []v1alpha1.VRFHostSession{
+				{Name: "session-138", HostSession: hostSession},
+				{Name: "session-220", HostSession: hostSession},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid policy",
+			hostSessions: []v1alpha1.VRFHostSession{
+				{Name: "metallb", HostSession: hostSession},
+				{Name: "calico", HostSession: v1alpha1.HostSession{ImportPolicy: &v1alpha1.PrefixPolicy{}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVRFHostSessions(tt.hostSession, tt.hostSessions)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateVRFHostSessions() expected error but got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateVRFHostSessions() unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/utils/ptr"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// FilterValidIRBModeL2VNIs returns the L2VNIs whose IRB mode can be applied,
+// alongside per-resource errors for the others. As the L3VNI either carries
+// the routed traffic between its L2VNIs or only the prefix routes, the
+// L2VNIs of an L3VNI must use the IRB mode of the first one.
+func FilterValidIRBModeL2VNIs(l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L2VNI, error) {
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	first := map[string]v1alpha1.L2VNI{}
+	var validL2 []v1alpha1.L2VNI
+	for _, l2 := range l2Vnis {
+		l3vni := routingDomainL3VNI(l2)
+		if l3vni == "" {
+			validL2 = append(validL2, l2)
+			continue
+		}
+		f, ok := first[l3vni]
+		if ok && isAsymmetricIRB(l2) != isAsymmetricIRB(f) {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L2VNI", Name: l2.Name, Reason: reason,
+					Message: fmt.Sprintf("irbMode differs from the one of L2VNI %s, all the L2VNIs of L3VNI %s must use the same irbMode",
+						f.Name, l3vni),
+				},
+			})
+			continue
+		}
+		if !ok {
+			first[l3vni] = l2
+		}
+		validL2 = append(validL2, l2)
+	}
+
+	return validL2, errors.Join(allErrors...)
+}
+
+// validateAsymmetricIRBForNode checks that the asymmetric L2VNIs are selected
+// on the node when their L3VNI is: with asymmetric IRB, the ingress VTEP
+// routes the traffic straight into the destination L2VNI, which must then be
+// present on all the nodes of the L3VNI.
+func validateAsymmetricIRBForNode(l2vnis, nodeL2VNIs []v1alpha1.L2VNI, nodeL3VNIs []v1alpha1.L3VNI) error {
+	var errs []error
+	for _, l2 := range l2vnis {
+		l3vni := routingDomainL3VNI(l2)
+		if l3vni == "" || !isAsymmetricIRB(l2) {
+			continue
+		}
+		if !slices.ContainsFunc(nodeL3VNIs, func(l3 v1alpha1.L3VNI) bool {
+			return l3.Name == l3vni && l3.Namespace == l2.Namespace
+		}) {
+			continue
+		}
+		if slices.ContainsFunc(nodeL2VNIs, func(n v1alpha1.L2VNI) bool {
+			return n.Name == l2.Name && n.Namespace == l2.Namespace
+		}) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("L2VNI %s uses asymmetric IRB but is not selected on all the nodes of L3VNI %s",
+			l2.Name, l3vni))
+	}
+	return errors.Join(errs...)
+}
+
+// isAsymmetricIRB tells if the traffic of the L2VNI is routed with
+// asymmetric IRB.
+func isAsymmetricIRB(l2 v1alpha1.L2VNI) bool {
+	return hasRoutingDomain(l2) &&
+		ptr.Deref(l2.Spec.RoutingDomain.IRBMode, v1alpha1.IRBModeSymmetric) == v1alpha1.IRBModeAsymmetric
+}
+
+// routingDomainL3VNI returns the name of the L3VNI providing the routing
+// domain of the L2VNI, if any.
+func routingDomainL3VNI(l2 v1alpha1.L2VNI) string {
+	if !hasRoutingDomain(l2) || l2.Spec.RoutingDomain.L3VNI == nil {
+		return ""
+	}
+	return l2.Spec.RoutingDomain.L3VNI.Name
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestFilterValidIRBModeL2VNIs(t *testing.T) {
+	l2vni := func(name, l3vni string, mode *v1alpha1.IRBMode) v1alpha1.L2VNI {
+		spec := v1alpha1.L2VNISpec{VNI: 100}
+		if l3vni != "" {
+			spec.RoutingDomain = &v1alpha1.RoutingDomain{
+				Type:    v1alpha1.RoutingDomainTypeL3VNI,
+				L3VNI:   &v1alpha1.L3VNIReference{Name: l3vni},
+				IRBMode: mode,
+			}
+		}
+		return v1alpha1.L2VNI{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}, Spec: spec}
+	}
+	asymmetric := new(v1alpha1.IRBModeAsymmetric)
+	symmetric := new(v1alpha1.IRBModeSymmetric)
+
+	tests := []struct {
+		name       string
+		l2vnis     []v1alpha1.L2VNI
+		wantValid  []string
+		wantErrors []string
+	}{
+		{
+			name: "symmetric by default",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", "red", nil),
+				l2vni("b", "red", symmetric),
+				l2vni("c", "", nil),
+			},
+			wantValid: []string{"a", "b", "c"},
+		},
+		{
+			name: "asymmetric",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", "red", asymmetric),
+				l2vni("b", "red", asymmetric),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name: "different modes in different L3VNIs",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", "red", asymmetric),
+				l2vni("b", "blue", nil),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name: "mixed modes in an L3VNI",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", "red", nil),
+				l2vni("b", "red", asymmetric),
+				l2vni("c", "red", symmetric),
+			},
+			wantValid:  []string{"a", "c"},
+			wantErrors: []string{"irbMode differs from the one of L2VNI a, all the L2VNIs of L3VNI red must use the same irbMode"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := FilterValidIRBModeL2VNIs(tc.l2vnis)
+			var validNames []string
+			for _, l2 := range valid {
+				validNames = append(validNames, l2.Name)
+			}
+			if strings.Join(validNames, ",") != strings.Join(tc.wantValid, ",") {
+				t.Errorf("valid L2VNIs = %v, want %v", validNames, tc.wantValid)
+			}
+			if len(tc.wantErrors) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tc.wantErrors {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -10,9 +10,9 @@ import (
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	"github.com/openperouter/openperouter/internal/filter"
+	"github.com/openperouter/openperouter/internal/ipfamily"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/utils/ptr"
 )
 
 // FilterValidL3VPNs validates L3VPNs per-field and returns the valid resources
@@ -149,7 +149,7 @@ func HasMissingSRv6ForL3VPNs(underlays []v1alpha1.Underlay, l3vpns []v1alpha1.L3
 	if len(underlays) == 0 {
 		return true
 	}
-	if underlays[0].Spec.SRV6 == nil {
+	if PrimaryUnderlay(underlays).Spec.SRV6 == nil {
 		return true
 	}
 	return false
@@ -179,7 +179,8 @@ func MissingSRv6ForL3VPNErrors(l3vpns []v1alpha1.L3VPN, node *corev1.Node) error
 	return errors.Join(errs...)
 }
 
-// validateL3VPN validates a single L3VPN's fields (VRF name, route targets).
+// validateL3VPN validates a single L3VPN's fields (VRF name, route targets,
+// host session policies, communities, static routes).
 func validateL3VPN(l3Vni v1alpha1.L3VPN) error {
 	vni := vniFromL3VPN(l3Vni)
 	if err := isValidInterfaceName(vni.vrfName); err != nil {
@@ -192,6 +193,18 @@ func validateL3VPN(l3Vni v1alpha1.L3VPN) error {
 	if err := ValidateRouteTargets(vni); err != nil {
 		return fmt.Errorf("invalid route targets for vpn %q: %w", vni.name, err)
 	}
+	if err := validateVRFHostSessions(l3Vni.Spec.HostSession, l3Vni.Spec.HostSessions); err != nil {
+		return fmt.Errorf("invalid host session for vpn %q: %w", vni.name, err)
+	}
+	if err := validateVRFCommunities(l3Vni.Spec.Communities); err != nil {
+		return fmt.Errorf("invalid communities for vpn %q: %w", vni.name, err)
+	}
+	if err := validateStaticRoutes(l3Vni.Spec.StaticRoutes); err != nil {
+		return fmt.Errorf("invalid static routes for vpn %q: %w", vni.name, err)
+	}
+	if err := validateAggregates(l3Vni.Spec.Aggregates); err != nil {
+		return fmt.Errorf("invalid aggregates for vpn %q: %w", vni.name, err)
+	}
 	return nil
 }
 
@@ -210,34 +223,12 @@ func vniFromL3VPN(l3vpn v1alpha1.L3VPN) VNI {
 	}
 }
 
-// v4SubnetForL3VPN extracts the valid IPv4 subnet from the l3vni, or returns nil.
-func v4SubnetForL3VPN(l3vni v1alpha1.L3VPN) *net.IPNet {
-	if l3vni.Spec.HostSession == nil {
-		return nil
-	}
-	ipv4 := ptr.Deref(l3vni.Spec.HostSession.LocalCIDR.IPv4, "")
-	if ipv4 == "" {
-		return nil
-	}
-	_, ipnet, err := net.ParseCIDR(ipv4)
-	if err != nil {
-		return nil
-	}
-	return ipnet
+// v4SubnetsForL3VPN extracts the valid IPv4 subnets of the host sessions of the l3vpn.
+func v4SubnetsForL3VPN(l3vpn v1alpha1.L3VPN) []*net.IPNet {
+	return hostSessionsSubnets(vrfHostSessions(l3vpn.Spec.HostSession, l3vpn.Spec.HostSessions), ipfamily.IPv4)
 }
 
-// v6SubnetForL3VPN extracts the valid IPv6 subnet from the l3vni, or returns nil.
-func v6SubnetForL3VPN(l3vni v1alpha1.L3VPN) *net.IPNet {
-	if l3vni.Spec.HostSession == nil {
-		return nil
-	}
-	ipv6 := ptr.Deref(l3vni.Spec.HostSession.LocalCIDR.IPv6, "")
-	if ipv6 == "" {
-		return nil
-	}
-	_, ipnet, err := net.ParseCIDR(ipv6)
-	if err != nil {
-		return nil
-	}
-	return ipnet
+// v6SubnetsForL3VPN extracts the valid IPv6 subnets of the host sessions of the l3vpn.
+func v6SubnetsForL3VPN(l3vpn v1alpha1.L3VPN) []*net.IPNet {
+	return hostSessionsSubnets(vrfHostSessions(l3vpn.Spec.HostSession, l3vpn.Spec.HostSessions), ipfamily.IPv6)
 }
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// FilterValidMulticastL2VNIs returns the L2VNIs whose BUM traffic can be
+// replicated as configured, alongside per-resource errors for the others.
+// The L2VNIs setting a multicastGroup require the underlay to run PIM and to
+// have an IPv4 VTEP. As disabling the head-end replication applies to all the
+// VNIs of the router, the L2VNIs must use the replication mode of the first
+// one.
+func FilterValidMulticastL2VNIs(underlays []v1alpha1.Underlay, l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L2VNI, error) {
+	var underlay *v1alpha1.Underlay
+	if len(underlays) > 0 {
+		underlay = new(PrimaryUnderlay(underlays))
+	}
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	var first *v1alpha1.L2VNI
+	var validL2 []v1alpha1.L2VNI
+	for _, l2 := range l2Vnis {
+		if err := validateMulticastL2VNI(l2, first, underlay); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L2VNI", Name: l2.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		if first == nil {
+			first = &l2
+		}
+		validL2 = append(validL2, l2)
+	}
+
+	return validL2, errors.Join(allErrors...)
+}
+
+// validateMulticastL2VNI checks that the underlay can replicate the BUM
+// traffic of the L2VNI as configured, and that it uses the replication mode
+// of the first valid one.
+func validateMulticastL2VNI(l2 v1alpha1.L2VNI, first *v1alpha1.L2VNI, underlay *v1alpha1.Underlay) error {
+	if first != nil && isMulticastL2VNI(l2) != isMulticastL2VNI(*first) {
+		return fmt.Errorf("replication mode differs from the one of L2VNI %s, either all or none of the L2VNIs must set multicastGroup",
+			first.Name)
+	}
+	if !isMulticastL2VNI(l2) {
+		return nil
+	}
+	if underlay == nil || underlay.Spec.EVPN == nil || underlay.Spec.EVPN.Multicast == nil {
+		return errors.New("multicastGroup requires the underlay to set evpn.multicast")
+	}
+	if isVLANAwareBridge(underlay.Spec.EVPN) {
+		return errors.New("multicastGroup is not supported in VLANAware bridge mode")
+	}
+	if l2.Spec.UnderlayAddressFamily != nil && *l2.Spec.UnderlayAddressFamily != "IPv4" {
+		return fmt.Errorf("multicastGroup is not supported with the %s underlayAddressFamily", *l2.Spec.UnderlayAddressFamily)
+	}
+	if !hasIPv4TunnelEndpoint(underlay.Spec.TunnelEndpoint) {
+		return errors.New("multicastGroup requires an IPv4 tunnel endpoint")
+	}
+	return nil
+}
+
+// isMulticastL2VNI tells if the BUM traffic of the L2VNI is replicated
+// through an underlay multicast group.
+func isMulticastL2VNI(l2 v1alpha1.L2VNI) bool {
+	return l2.Spec.MulticastGroup != nil
+}
+
+func hasIPv4TunnelEndpoint(tunnelEndpoint *v1alpha1.TunnelEndpointConfig) bool {
+	if tunnelEndpoint == nil {
+		return false
+	}
+	for _, cidr := range tunnelEndpoint.CIDRs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err == nil && ip.To4() != nil {
+			return true
+		}
+	}
+	return false
+}
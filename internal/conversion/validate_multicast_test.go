@@ -0,0 +1,111 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestFilterValidMulticastL2VNIs(t *testing.T) {
+	l2vni := func(name string, spec v1alpha1.L2VNISpec) v1alpha1.L2VNI {
+		spec.VNI = 100
+		return v1alpha1.L2VNI{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}, Spec: spec}
+	}
+	underlay := func(evpn *v1alpha1.EVPNConfig, cidrs ...string) []v1alpha1.Underlay {
+		return []v1alpha1.Underlay{{Spec: v1alpha1.UnderlaySpec{
+			EVPN:           evpn,
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: cidrs},
+		}}}
+	}
+	multicast := &v1alpha1.EVPNConfig{Multicast: &v1alpha1.EVPNMulticastConfig{RendezvousPoint: "10.0.0.1"}}
+
+	tests := []struct {
+		name       string
+		underlays  []v1alpha1.Underlay
+		l2vnis     []v1alpha1.L2VNI
+		wantValid  []string
+		wantErrors []string
+	}{
+		{
+			name:      "head-end replication",
+			underlays: underlay(nil, "100.65.0.0/24"),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", v1alpha1.L2VNISpec{}),
+				l2vni("b", v1alpha1.L2VNISpec{}),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name:      "multicast replication",
+			underlays: underlay(multicast, "100.65.0.0/24", "fd00::/64"),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", v1alpha1.L2VNISpec{MulticastGroup: new("239.1.1.1")}),
+				l2vni("b", v1alpha1.L2VNISpec{MulticastGroup: new("239.1.1.2"), UnderlayAddressFamily: new("IPv4")}),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name:      "mixed replication modes",
+			underlays: underlay(multicast, "100.65.0.0/24"),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", v1alpha1.L2VNISpec{MulticastGroup: new("239.1.1.1")}),
+				l2vni("b", v1alpha1.L2VNISpec{}),
+			},
+			wantValid:  []string{"a"},
+			wantErrors: []string{"replication mode differs from the one of L2VNI a"},
+		},
+		{
+			name:      "multicast not enabled on the underlay",
+			underlays: underlay(nil, "100.65.0.0/24"),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", v1alpha1.L2VNISpec{MulticastGroup: new("239.1.1.1")}),
+			},
+			wantErrors: []string{"multicastGroup requires the underlay to set evpn.multicast"},
+		},
+		{
+			name: "vlan aware bridge mode",
+			underlays: underlay(&v1alpha1.EVPNConfig{
+				Multicast:  multicast.Multicast,
+				BridgeMode: new(v1alpha1.EVPNBridgeModeVLANAware),
+			}, "100.65.0.0/24"),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", v1alpha1.L2VNISpec{MulticastGroup: new("239.1.1.1")}),
+			},
+			wantErrors: []string{"multicastGroup is not supported in VLANAware bridge mode"},
+		},
+		{
+			name:      "ipv6 vtep",
+			underlays: underlay(multicast, "fd00::/64"),
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", v1alpha1.L2VNISpec{MulticastGroup: new("239.1.1.1")}),
+			},
+			wantErrors: []string{"multicastGroup requires an IPv4 tunnel endpoint"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := FilterValidMulticastL2VNIs(tc.underlays, tc.l2vnis)
+			var validNames []string
+			for _, l2 := range valid {
+				validNames = append(validNames, l2.Name)
+			}
+			if strings.Join(validNames, ",") != strings.Join(tc.wantValid, ",") {
+				t.Errorf("valid L2VNIs = %v, want %v", validNames, tc.wantValid)
+			}
+			if len(tc.wantErrors) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tc.wantErrors {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
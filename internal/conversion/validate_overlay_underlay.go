@@ -0,0 +1,102 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/utils/ptr"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// FilterValidUnderlayL3VNIs returns the L3VNIs riding on one of the
+// underlays of the node, alongside per-resource errors for the others.
+func FilterValidUnderlayL3VNIs(underlays []v1alpha1.Underlay, l3Vnis []v1alpha1.L3VNI) ([]v1alpha1.L3VNI, error) {
+	if len(underlays) == 0 {
+		return l3Vnis, nil
+	}
+	sorted := sortUnderlays(underlays)
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	var validL3 []v1alpha1.L3VNI
+	for _, l3 := range l3Vnis {
+		if _, err := sorted.byName(l3.Spec.Underlay); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L3VNI", Name: l3.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		validL3 = append(validL3, l3)
+	}
+
+	return validL3, errors.Join(allErrors...)
+}
+
+// FilterValidUnderlayL2VNIs returns the L2VNIs riding on one of the
+// underlays of the node, alongside per-resource errors for the others. An
+// L2VNI must ride on the underlay of the L3VNI it is routed through, and on
+// the primary underlay when it relies on the node-wide settings of the
+// latter.
+func FilterValidUnderlayL2VNIs(underlays []v1alpha1.Underlay, l3Vnis []v1alpha1.L3VNI,
+	l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L2VNI, error) {
+	if len(underlays) == 0 {
+		return l2Vnis, nil
+	}
+	sorted := sortUnderlays(underlays)
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	var validL2 []v1alpha1.L2VNI
+	for _, l2 := range l2Vnis {
+		if err := validateL2VNIUnderlay(sorted, l3Vnis, l2); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L2VNI", Name: l2.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		validL2 = append(validL2, l2)
+	}
+
+	return validL2, errors.Join(allErrors...)
+}
+
+func validateL2VNIUnderlay(underlays nodeUnderlays, l3Vnis []v1alpha1.L3VNI, l2 v1alpha1.L2VNI) error {
+	underlay, err := underlays.byName(l2.Spec.Underlay)
+	if err != nil {
+		return err
+	}
+
+	if l3vni := routingDomainL3VNI(l2); l3vni != "" {
+		i := slices.IndexFunc(l3Vnis, func(l3 v1alpha1.L3VNI) bool {
+			return l3.Name == l3vni
+		})
+		if i >= 0 && !underlays.ridesOn(l3Vnis[i].Spec.Underlay, underlay) {
+			return fmt.Errorf("underlay %s differs from underlay %s of L3VNI %s",
+				underlay.Name, ptr.Deref(l3Vnis[i].Spec.Underlay, underlays.primary().Name), l3vni)
+		}
+	}
+
+	if underlay.Name == underlays.primary().Name {
+		return nil
+	}
+	if hasRoutingDomain(l2) && l2.Spec.RoutingDomain.L3VPN != nil {
+		return fmt.Errorf("routingDomain type L3VPN requires the primary underlay %s", underlays.primary().Name)
+	}
+	if isMulticastL2VNI(l2) {
+		return fmt.Errorf("multicastGroup requires the primary underlay %s", underlays.primary().Name)
+	}
+	if isVLANAwareBridge(underlays.primary().Spec.EVPN) {
+		return fmt.Errorf("the VLANAware bridge mode of the primary underlay %s requires all the L2VNIs to ride on it",
+			underlays.primary().Name)
+	}
+	return nil
+}
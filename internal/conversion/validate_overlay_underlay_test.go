@@ -0,0 +1,145 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestFilterValidUnderlayL3VNIs(t *testing.T) {
+	underlays := []v1alpha1.Underlay{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"}},
+	}
+	l3vni := func(name string, underlay *string) v1alpha1.L3VNI {
+		return v1alpha1.L3VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec:       v1alpha1.L3VNISpec{VRF: name, VNI: 100, Underlay: underlay},
+		}
+	}
+
+	valid, err := FilterValidUnderlayL3VNIs(underlays, []v1alpha1.L3VNI{
+		l3vni("red", nil),
+		l3vni("blue", new("fabric-b")),
+		l3vni("green", new("fabric-c")),
+	})
+	var validNames []string
+	for _, l3 := range valid {
+		validNames = append(validNames, l3.Name)
+	}
+	if strings.Join(validNames, ",") != "red,blue" {
+		t.Errorf("valid L3VNIs = %v, want [red blue]", validNames)
+	}
+	if err == nil || !strings.Contains(err.Error(), "underlay fabric-c not found") {
+		t.Errorf("error = %v, want %q", err, "underlay fabric-c not found")
+	}
+}
+
+func TestFilterValidUnderlayL2VNIs(t *testing.T) {
+	underlay := func(name string, evpn *v1alpha1.EVPNConfig) v1alpha1.Underlay {
+		return v1alpha1.Underlay{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.UnderlaySpec{EVPN: evpn},
+		}
+	}
+	l3vnis := []v1alpha1.L3VNI{
+		{ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "test"}, Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 100}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "test"}, Spec: v1alpha1.L3VNISpec{VRF: "blue", VNI: 101, Underlay: new("fabric-b")}},
+	}
+	l2vni := func(name string, underlay *string, l3vni string) v1alpha1.L2VNI {
+		spec := v1alpha1.L2VNISpec{VNI: 200, Underlay: underlay}
+		if l3vni != "" {
+			spec.RoutingDomain = &v1alpha1.RoutingDomain{
+				Type:  v1alpha1.RoutingDomainTypeL3VNI,
+				L3VNI: &v1alpha1.L3VNIReference{Name: l3vni},
+			}
+		}
+		return v1alpha1.L2VNI{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}, Spec: spec}
+	}
+
+	tests := []struct {
+		name       string
+		underlays  []v1alpha1.Underlay
+		l2vnis     []v1alpha1.L2VNI
+		wantValid  []string
+		wantErrors []string
+	}{
+		{
+			name:      "no underlays",
+			l2vnis:    []v1alpha1.L2VNI{l2vni("a", new("fabric-c"), "")},
+			wantValid: []string{"a"},
+		},
+		{
+			name:      "riding on the underlay of their L3VNI",
+			underlays: []v1alpha1.Underlay{underlay("fabric-a", nil), underlay("fabric-b", nil)},
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", nil, "red"),
+				l2vni("b", new("fabric-a"), "red"),
+				l2vni("c", new("fabric-b"), "blue"),
+				l2vni("d", new("fabric-b"), ""),
+			},
+			wantValid: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:      "missing underlay",
+			underlays: []v1alpha1.Underlay{underlay("fabric-a", nil), underlay("fabric-b", nil)},
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", nil, ""),
+				l2vni("b", new("fabric-c"), ""),
+			},
+			wantValid:  []string{"a"},
+			wantErrors: []string{"underlay fabric-c not found"},
+		},
+		{
+			name:      "riding on a different underlay than their L3VNI",
+			underlays: []v1alpha1.Underlay{underlay("fabric-a", nil), underlay("fabric-b", nil)},
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", new("fabric-b"), "red"),
+				l2vni("b", nil, "blue"),
+			},
+			wantErrors: []string{
+				"underlay fabric-b differs from underlay fabric-a of L3VNI red",
+				"underlay fabric-a differs from underlay fabric-b of L3VNI blue",
+			},
+		},
+		{
+			name: "VLANAware bridge mode on the primary underlay",
+			underlays: []v1alpha1.Underlay{
+				underlay("fabric-a", &v1alpha1.EVPNConfig{BridgeMode: new(v1alpha1.EVPNBridgeModeVLANAware)}),
+				underlay("fabric-b", nil),
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", nil, ""),
+				l2vni("b", new("fabric-b"), ""),
+			},
+			wantValid:  []string{"a"},
+			wantErrors: []string{"the VLANAware bridge mode of the primary underlay fabric-a requires all the L2VNIs to ride on it"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := FilterValidUnderlayL2VNIs(tc.underlays, l3vnis, tc.l2vnis)
+			var validNames []string
+			for _, l2 := range valid {
+				validNames = append(validNames, l2.Name)
+			}
+			if strings.Join(validNames, ",") != strings.Join(tc.wantValid, ",") {
+				t.Errorf("valid L2VNIs = %v, want %v", validNames, tc.wantValid)
+			}
+			if len(tc.wantErrors) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tc.wantErrors {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
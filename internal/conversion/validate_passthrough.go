@@ -41,5 +41,17 @@ func FilterValidPassthroughs(l3Passthrough []v1alpha1.L3Passthrough) ([]v1alpha1
 		}
 		return nil, errors.Join(allErrors...)
 	}
+	for _, pt := range l3Passthrough {
+		if err := validateHostSessionPolicies(&pt.Spec.HostSession); err != nil {
+			return nil, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind:    v1alpha1.FailedResourceKind("L3Passthrough"),
+					Name:    pt.Name,
+					Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+					Message: fmt.Sprintf("invalid host session: %s", err),
+				},
+			}
+		}
+	}
 	return l3Passthrough, nil
 }
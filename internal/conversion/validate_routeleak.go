@@ -0,0 +1,83 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// validateVRFImports validates the route leaking configuration of the
+// given vrf.
+func validateVRFImports(vrf string, imports []v1alpha1.VRFImport, exportToDefault *v1alpha1.DefaultVRFExport) error {
+	seen := sets.New[string]()
+	for _, i := range imports {
+		if i.VRF != v1alpha1.DefaultVRFName {
+			if err := isValidInterfaceName(i.VRF); err != nil {
+				return fmt.Errorf("invalid imported vrf name %q: %w", i.VRF, err)
+			}
+		}
+		if i.VRF == vrf {
+			return fmt.Errorf("vrf %q cannot import its own routes", vrf)
+		}
+		if seen.Has(i.VRF) {
+			return fmt.Errorf("vrf %q imported more than once", i.VRF)
+		}
+		seen.Insert(i.VRF)
+		if err := validatePrefixPolicy(i.Policy); err != nil {
+			return fmt.Errorf("invalid policy for imported vrf %q: %w", i.VRF, err)
+		}
+	}
+	if exportToDefault != nil {
+		if err := validatePrefixPolicy(exportToDefault.Policy); err != nil {
+			return fmt.Errorf("invalid policy for the export to the default vrf: %w", err)
+		}
+	}
+	return nil
+}
+
+// FilterValidVRFImports returns the L3VNIs whose imported VRFs are all
+// configured on the node, alongside per-resource errors for the others.
+// Discarding an L3VNI may invalidate the ones importing its VRF, so the
+// check is repeated until no more L3VNIs are discarded.
+func FilterValidVRFImports(l3Vnis []v1alpha1.L3VNI) ([]v1alpha1.L3VNI, error) {
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+	valid := l3Vnis
+	for {
+		vrfs := sets.New(v1alpha1.DefaultVRFName)
+		for _, l3 := range valid {
+			vrfs.Insert(l3.Spec.VRF)
+		}
+
+		var next []v1alpha1.L3VNI
+		for _, l3 := range valid {
+			missing := ""
+			for _, i := range l3.Spec.ImportVRFs {
+				if !vrfs.Has(i.VRF) {
+					missing = i.VRF
+					break
+				}
+			}
+			if missing != "" {
+				allErrors = append(allErrors, &openpeerrors.ResourceError{
+					Obj: v1alpha1.FailedResource{
+						Kind: "L3VNI", Name: l3.Name, Reason: reason,
+						Message: fmt.Sprintf("imported vrf %q is not configured on the node", missing),
+					},
+				})
+				continue
+			}
+			next = append(next, l3)
+		}
+		if len(next) == len(valid) {
+			return valid, errors.Join(allErrors...)
+		}
+		valid = next
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateVRFImports(t *testing.T) {
+	tests := []struct {
+		name            string
+		imports         []v1alpha1.VRFImport
+		exportToDefault *v1alpha1.DefaultVRFExport
+		wantErr         bool
+	}{
+		{
+			name:    "no imports",
+			wantErr: false,
+		},
+		{
+			name: "valid imports",
+			imports: []v1alpha1.VRFImport{
+				{VRF: "blue"},
+				{VRF: "default", Policy: &v1alpha1.PrefixPolicy{
+					Rules: []v1alpha1.PrefixRule{{Prefix: "10.0.0.0/8", LE: new(int32(32))}},
+				}},
+			},
+			exportToDefault: &v1alpha1.DefaultVRFExport{},
+			wantErr:         false,
+		},
+		{
+			name:    "own vrf",
+			imports: []v1alpha1.VRFImport{{VRF: "red"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid vrf name",
+			imports: []v1alpha1.VRFImport{{VRF: "averyveryverylongname"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate vrf",
+			imports: []v1alpha1.VRFImport{{VRF: "blue"}, {VRF: "blue"}},
+			wantErr: true,
+		},
+		{
+			name: "invalid import policy",
+			imports: []v1alpha1.VRFImport{{VRF: "blue", Policy: &v1alpha1.PrefixPolicy{
+				Rules: []v1alpha1.PrefixRule{{Prefix: "10.0.0.0/8", GE: new(int32(4))}},
+			}}},
+			wantErr: true,
+		},
+		{
+			name:            "invalid export policy",
+			exportToDefault: &v1alpha1.DefaultVRFExport{Policy: &v1alpha1.PrefixPolicy{}},
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateVRFImports("red", tc.imports, tc.exportToDefault)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateVRFImports() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterValidVRFImports(t *testing.T) {
+	l3vni := func(name string, imports ...string) v1alpha1.L3VNI {
+		res := v1alpha1.L3VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec:       v1alpha1.L3VNISpec{VRF: name},
+		}
+		for _, i := range imports {
+			res.Spec.ImportVRFs = append(res.Spec.ImportVRFs, v1alpha1.VRFImport{VRF: i})
+		}
+		return res
+	}
+
+	l3vnis := []v1alpha1.L3VNI{
+		l3vni("red", "blue", "default"),
+		l3vni("blue"),
+		// green imports from a VRF that does not exist on the node, and
+		// yellow from green, which is discarded.
+		l3vni("green", "missing"),
+		l3vni("yellow", "green"),
+	}
+
+	valid, err := FilterValidVRFImports(l3vnis)
+	if err == nil {
+		t.Fatal("expected error for missing vrf")
+	}
+	for _, want := range []string{`imported vrf "missing"`, `imported vrf "green"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want %q", err, want)
+		}
+	}
+	if len(valid) != 2 || valid[0].Name != "red" || valid[1].Name != "blue" {
+		t.Errorf("expected red and blue to be valid, got %v", valid)
+	}
+}
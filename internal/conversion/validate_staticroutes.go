@@ -0,0 +1,58 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+	"k8s.io/utils/ptr"
+)
+
+// validateStaticRoutes validates the static routes configured in a VRF.
+func validateStaticRoutes(routes []v1alpha1.StaticRoute) error {
+	seen := map[string]bool{}
+	for _, r := range routes {
+		_, prefix, err := net.ParseCIDR(r.Prefix)
+		if err != nil {
+			return fmt.Errorf("invalid static route prefix %q: %w", r.Prefix, err)
+		}
+
+		nextHops := 0
+		nextHop := ""
+		if r.NextHop != nil {
+			nextHops++
+			nextHop = *r.NextHop
+			ip := net.ParseIP(*r.NextHop)
+			if ip == nil {
+				return fmt.Errorf("invalid next hop %q for static route %s", *r.NextHop, r.Prefix)
+			}
+			if ipfamily.ForAddress(ip) != ipfamily.ForCIDR(prefix) {
+				return fmt.Errorf("next hop %s and static route %s must belong to the same ip family", *r.NextHop, r.Prefix)
+			}
+		}
+		if r.Interface != nil {
+			nextHops++
+			nextHop = *r.Interface
+			if err := isValidInterfaceName(*r.Interface); err != nil {
+				return fmt.Errorf("invalid interface for static route %s: %w", r.Prefix, err)
+			}
+		}
+		if ptr.Deref(r.Blackhole, false) {
+			nextHops++
+			nextHop = "blackhole"
+		}
+		if nextHops != 1 {
+			return fmt.Errorf("static route %s must have exactly one of nextHop, interface or blackhole", r.Prefix)
+		}
+
+		key := prefix.String() + " " + nextHop
+		if seen[key] {
+			return fmt.Errorf("duplicate static route %s via %s", prefix.String(), nextHop)
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateStaticRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		routes  []v1alpha1.StaticRoute
+		wantErr bool
+	}{
+		{
+			name:    "no routes",
+			routes:  nil,
+			wantErr: false,
+		},
+		{
+			name: "valid routes",
+			routes: []v1alpha1.StaticRoute{
+				{Prefix: "10.100.0.0/16", NextHop: new("192.168.1.10")},
+				{Prefix: "10.100.0.0/16", NextHop: new("192.168.1.11")},
+				{Prefix: "2001:db8:100::/48", NextHop: new("2001:db8::10")},
+				{Prefix: "10.200.0.0/16", Interface: new("eth0")},
+				{Prefix: "10.0.0.0/8", Blackhole: new(true), Advertise: new(true)},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid prefix",
+			routes:  []v1alpha1.StaticRoute{{Prefix: "10.100.0.0", NextHop: new("192.168.1.10")}},
+			wantErr: true,
+		},
+		{
+			name:    "no next hop",
+			routes:  []v1alpha1.StaticRoute{{Prefix: "10.100.0.0/16"}},
+			wantErr: true,
+		},
+		{
+			name:    "blackhole set to false",
+			routes:  []v1alpha1.StaticRoute{{Prefix: "10.100.0.0/16", Blackhole: new(false)}},
+			wantErr: true,
+		},
+		{
+			name:    "next hop and blackhole",
+			routes:  []v1alpha1.StaticRoute{{Prefix: "10.100.0.0/16", NextHop: new("192.168.1.10"), Blackhole: new(true)}},
+			wantErr: true,
+		},
+		{
+			name:    "next hop family mismatch",
+			routes:  []v1alpha1.StaticRoute{{Prefix: "10.100.0.0/16", NextHop: new("2001:db8::10")}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid interface",
+			routes:  []v1alpha1.StaticRoute{{Prefix: "10.100.0.0/16", Interface: new("averyveryverylongname")}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate routes",
+			routes: []v1alpha1.StaticRoute{
+				{Prefix: "10.100.0.0/16", NextHop: new("192.168.1.10")},
+				{Prefix: "10.100.0.1/16", NextHop: new("192.168.1.10")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateStaticRoutes(tc.routes)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateStaticRoutes() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
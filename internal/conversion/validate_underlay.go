@@ -3,8 +3,10 @@
 package conversion
 
 import (
+	"errors"
 	"fmt"
 	"net/netip"
+	"regexp"
 	"slices"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,29 +34,126 @@ func ValidateUnderlays(underlays []v1alpha1.Underlay) error {
 	if len(underlays) == 0 {
 		return nil
 	}
-	if len(underlays) > 1 {
-		return &openpeerrors.ResourceError{
-			Obj: v1alpha1.FailedResource{
-				Kind:    v1alpha1.FailedResourceKind("Underlay"),
-				Name:    underlays[0].Name,
-				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-				Message: "can't have more than one underlay per node",
-			},
+	sorted := sortUnderlays(underlays)
+	for i, underlay := range sorted {
+		err := validateUnderlay(underlay)
+		if err == nil && len(sorted) > 1 {
+			err = validateUnderlayAlongside(underlay, i == 0, sorted[:i])
+		}
+		if err != nil {
+			return &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind:    v1alpha1.FailedResourceKind("Underlay"),
+					Name:    underlay.Name,
+					Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+					Message: err.Error(),
+				},
+			}
 		}
 	}
-	if err := validateUnderlay(underlays[0]); err != nil {
-		return &openpeerrors.ResourceError{
-			Obj: v1alpha1.FailedResource{
-				Kind:    v1alpha1.FailedResourceKind("Underlay"),
-				Name:    underlays[0].Name,
-				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-				Message: err.Error(),
-			},
+	return nil
+}
+
+// validateUnderlayAlongside checks that the underlay can run on a node
+// together with the others. Each underlay must bring its own VTEP pool,
+// neighbors and interfaces, while the node-wide settings can only be set on
+// the primary underlay.
+func validateUnderlayAlongside(underlay v1alpha1.Underlay, primary bool, previous []v1alpha1.Underlay) error {
+	if underlay.Spec.TunnelEndpoint == nil {
+		return fmt.Errorf("underlay %s must set tunnelEndpoint when the node has more than one underlay", underlay.Name)
+	}
+	if !primary {
+		if err := validateAdditionalUnderlay(underlay); err != nil {
+			return fmt.Errorf("underlay %s is not the primary underlay of the node: %w", underlay.Name, err)
+		}
+	}
+
+	for _, other := range previous {
+		for _, addr := range neighborAddressesOf(underlay.Spec.Neighbors) {
+			if slices.Contains(neighborAddressesOf(other.Spec.Neighbors), addr) {
+				return fmt.Errorf("underlay %s has neighbor address %s already used by underlay %s", underlay.Name, addr, other.Name)
+			}
+		}
+		for _, name := range interfaceNamesOf(underlay.Spec.Neighbors) {
+			if slices.Contains(interfaceNamesOf(other.Spec.Neighbors), name) {
+				return fmt.Errorf("underlay %s has neighbor interface %s already used by underlay %s", underlay.Name, name, other.Name)
+			}
+		}
+		for _, name := range underlayInterfaceNamesOf(underlay.Spec.Interfaces) {
+			if slices.Contains(underlayInterfaceNamesOf(other.Spec.Interfaces), name) {
+				return fmt.Errorf("underlay %s has interface %s already used by underlay %s", underlay.Name, name, other.Name)
+			}
+		}
+		for _, parent := range underlayVLANParentsOf(underlay.Spec.Interfaces) {
+			if slices.Contains(underlayInterfaceNamesOf(other.Spec.Interfaces), parent) {
+				return fmt.Errorf("underlay %s has vlan parent %s moved into the router by underlay %s",
+					underlay.Name, parent, other.Name)
+			}
+		}
+		for _, parent := range underlayVLANParentsOf(other.Spec.Interfaces) {
+			if slices.Contains(underlayInterfaceNamesOf(underlay.Spec.Interfaces), parent) {
+				return fmt.Errorf("underlay %s moves into the router the vlan parent %s of underlay %s",
+					underlay.Name, parent, other.Name)
+			}
 		}
+		for _, cidr := range underlay.Spec.TunnelEndpoint.CIDRs {
+			for _, otherCIDR := range other.Spec.TunnelEndpoint.CIDRs {
+				if overlap, err := cidrsOverlap(cidr, otherCIDR); err == nil && overlap {
+					return fmt.Errorf("underlay %s has tunnel endpoint CIDR %s overlapping with %s of underlay %s",
+						underlay.Name, cidr, otherCIDR, other.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateAdditionalUnderlay checks that an underlay other than the primary
+// one doesn't set any of the settings applying to the whole router.
+func validateAdditionalUnderlay(underlay v1alpha1.Underlay) error {
+	switch {
+	case underlay.Spec.ISIS != nil:
+		return errors.New("isis can only be set on the primary underlay")
+	case underlay.Spec.OSPF != nil:
+		return errors.New("ospf can only be set on the primary underlay")
+	case underlay.Spec.SRV6 != nil:
+		return errors.New("srv6 can only be set on the primary underlay")
+	case underlay.Spec.RouteReflector != nil:
+		return errors.New("routeReflector can only be set on the primary underlay")
+	case underlay.Spec.GracefulRestart != nil:
+		return errors.New("gracefulRestart can only be set on the primary underlay")
+	case underlay.Spec.Multipath != nil:
+		return errors.New("multipath can only be set on the primary underlay")
+	}
+	evpn := underlay.Spec.EVPN
+	if evpn == nil {
+		return nil
+	}
+	switch {
+	case evpn.BridgeMode != nil:
+		return errors.New("evpn bridgeMode can only be set on the primary underlay")
+	case evpn.Multicast != nil:
+		return errors.New("evpn multicast can only be set on the primary underlay")
+	case evpn.DuplicateAddressDetection != nil:
+		return errors.New("evpn duplicateAddressDetection can only be set on the primary underlay")
 	}
 	return nil
 }
 
+// underlayInterfaceNamesOf returns the names of the underlay interfaces,
+// and of the members of the bonds, inside the router namespace.
+func underlayInterfaceNamesOf(interfaces []v1alpha1.UnderlayInterface) []string {
+	names := []string{}
+	for _, iface := range interfaces {
+		hostIface, err := underlayInterfaceToHost(iface)
+		if err != nil { // already validated
+			continue
+		}
+		names = append(names, hostInterfaceNames(hostIface)...)
+	}
+	return names
+}
+
 func validateUnderlay(underlay v1alpha1.Underlay) error {
 	if underlay.Spec.ASN == 0 {
 		return fmt.Errorf("underlay %s must have a valid ASN", underlay.Name)
@@ -78,6 +177,10 @@ func validateUnderlay(underlay v1alpha1.Underlay) error {
 		return fmt.Errorf("underlay %s: %w", underlay.Name, err)
 	}
 
+	if underlay.Spec.ISIS != nil && underlay.Spec.OSPF != nil {
+		return fmt.Errorf("underlay %s: isis and ospf are mutually exclusive", underlay.Name)
+	}
+
 	// do a no-op conversion to catch validation errors
 	if _, err := underlayInterfacesToHost(underlay.Spec.Interfaces); err != nil {
 		return fmt.Errorf("underlay %s has invalid interfaces: %w", underlay.Name, err)
@@ -89,6 +192,21 @@ func validateUnderlay(underlay v1alpha1.Underlay) error {
 		}
 	}
 
+	if underlay.Spec.EVPN != nil && underlay.Spec.EVPN.GatewayMAC != nil {
+		if err := validateGatewayMAC(*underlay.Spec.EVPN.GatewayMAC); err != nil {
+			return fmt.Errorf("underlay %s has invalid evpn gatewayMAC: %w", underlay.Name, err)
+		}
+	}
+
+	if underlay.Spec.EVPN != nil {
+		if err := validateVXLanTunnel(underlay.Spec.EVPN.VXLanTunnel); err != nil {
+			return fmt.Errorf("underlay %s has invalid evpn vxlanTunnel: %w", underlay.Name, err)
+		}
+		if err := validateDupAddrDetection(underlay.Spec.EVPN.DuplicateAddressDetection); err != nil {
+			return fmt.Errorf("underlay %s has invalid evpn duplicateAddressDetection: %w", underlay.Name, err)
+		}
+	}
+
 	srv6Config := underlay.Spec.SRV6
 	if srv6Config == nil {
 		return nil
@@ -181,6 +299,37 @@ func validateListenRanges(neighbors []v1alpha1.Neighbor) error {
 	return nil
 }
 
+var clearFrozenTokenRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,63}$`)
+
+func validateDupAddrDetection(detection *v1alpha1.EVPNDuplicateAddressDetection) error {
+	if detection == nil {
+		return nil
+	}
+	if detection.Enabled != nil && !*detection.Enabled &&
+		(detection.MaxMoves != nil || detection.TimeSeconds != nil || detection.Freeze != nil) {
+		return fmt.Errorf("maxMoves, timeSeconds and freeze can't be set when the detection is disabled")
+	}
+	if detection.MaxMoves != nil && (*detection.MaxMoves < 2 || *detection.MaxMoves > 1000) {
+		return fmt.Errorf("invalid maxMoves %d, must be between 2 and 1000", *detection.MaxMoves)
+	}
+	if detection.TimeSeconds != nil && (*detection.TimeSeconds < 2 || *detection.TimeSeconds > 1800) {
+		return fmt.Errorf("invalid timeSeconds %d, must be between 2 and 1800", *detection.TimeSeconds)
+	}
+	if freeze := detection.Freeze; freeze != nil {
+		permanent := freeze.Permanent != nil && *freeze.Permanent
+		if permanent == (freeze.TimeSeconds != nil) {
+			return fmt.Errorf("exactly one of freeze permanent or timeSeconds must be set")
+		}
+		if freeze.TimeSeconds != nil && (*freeze.TimeSeconds < 30 || *freeze.TimeSeconds > 3600) {
+			return fmt.Errorf("invalid freeze timeSeconds %d, must be between 30 and 3600", *freeze.TimeSeconds)
+		}
+	}
+	if detection.ClearFrozenToken != nil && !clearFrozenTokenRegexp.MatchString(*detection.ClearFrozenToken) {
+		return fmt.Errorf("invalid clearFrozenToken %q", *detection.ClearFrozenToken)
+	}
+	return nil
+}
+
 func validateNoDuplicates(items []string) error {
 	seen := make(map[string]struct{}, len(items))
 	for _, item := range items {
@@ -191,3 +340,15 @@ func validateNoDuplicates(items []string) error {
 	}
 	return nil
 }
+
+// underlayVLANParentsOf returns the names of the host network devices the
+// VLAN sub-interfaces are created on, which stay on the host.
+func underlayVLANParentsOf(interfaces []v1alpha1.UnderlayInterface) []string {
+	parents := []string{}
+	for _, iface := range interfaces {
+		if iface.Type == v1alpha1.UnderlayInterfaceTypeVLAN && iface.VLAN != nil {
+			parents = append(parents, iface.VLAN.Parent)
+		}
+	}
+	return parents
+}
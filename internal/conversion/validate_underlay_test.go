@@ -43,6 +43,131 @@ func TestValidateUnderlay(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multicast evpn gateway mac",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						EVPN: &v1alpha1.EVPNConfig{GatewayMAC: new("01:00:5e:00:01:01")},
+					},
+				},
+			},
+			wantErrStr: "underlay underlay has invalid evpn gatewayMAC: 01:00:5e:00:01:01 is not a unicast MAC address",
+		},
+		{
+			name: "duplicate address detection disabled with settings",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						EVPN: &v1alpha1.EVPNConfig{
+							DuplicateAddressDetection: &v1alpha1.EVPNDuplicateAddressDetection{
+								Enabled:  new(false),
+								MaxMoves: new(int32(3)),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay underlay has invalid evpn duplicateAddressDetection: maxMoves, timeSeconds and freeze can't be set when the detection is disabled",
+		},
+		{
+			name: "duplicate address freeze without duration",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						EVPN: &v1alpha1.EVPNConfig{
+							DuplicateAddressDetection: &v1alpha1.EVPNDuplicateAddressDetection{
+								Freeze: &v1alpha1.EVPNDuplicateAddressFreeze{},
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay underlay has invalid evpn duplicateAddressDetection: exactly one of freeze permanent or timeSeconds must be set",
+		},
+		{
+			name: "invalid duplicate address clear token",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						EVPN: &v1alpha1.EVPNConfig{
+							DuplicateAddressDetection: &v1alpha1.EVPNDuplicateAddressDetection{
+								ClearFrozenToken: new("a b"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay underlay has invalid evpn duplicateAddressDetection: invalid clearFrozenToken \"a b\"",
+		},
 		{
 			name: "missing tunnel endpoint configuration",
 			underlay: []v1alpha1.Underlay{
@@ -766,6 +891,55 @@ func TestValidateUnderlay(t *testing.T) {
 			name: "multiple underlays",
 			underlay: []v1alpha1.Underlay{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.2.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "",
+		},
+		{
+			name: "multiple underlays, missing tunnel endpoint",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
 					Spec: v1alpha1.UnderlaySpec{
 						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
 							CIDRs: []string{"192.168.1.0/24"},
@@ -786,6 +960,52 @@ func TestValidateUnderlay(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay fabric-b must set tunnelEndpoint when the node has more than one underlay",
+		},
+		{
+			name: "multiple underlays, isis on the additional underlay",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
 					Spec: v1alpha1.UnderlaySpec{
 						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
 							CIDRs: []string{"192.168.2.0/24"},
@@ -803,10 +1023,283 @@ func TestValidateUnderlay(t *testing.T) {
 								Address: new("192.168.2.1"),
 							},
 						},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+							Level:   new(int32(1)),
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay fabric-b is not the primary underlay of the node: isis can only be set on the primary underlay",
+		},
+		{
+			name: "multiple underlays, ospf on the additional underlay",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.2.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.2.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{},
+					},
+				},
+			},
+			wantErrStr: "underlay fabric-b is not the primary underlay of the node: ospf can only be set on the primary underlay",
+		},
+		{
+			name: "isis and ospf",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+					Spec: v1alpha1.UnderlaySpec{
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+						},
+						OSPF: &v1alpha1.OSPFConfig{},
+					},
+				},
+			},
+			wantErrStr: "underlay underlay: isis and ospf are mutually exclusive",
+		},
+		{
+			name: "multiple underlays, shared neighbor address",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.2.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay fabric-b has neighbor address 192.168.1.1 already used by underlay fabric-a",
+		},
+		{
+			name: "multiple underlays, shared interface",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.2.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay fabric-b has interface eth0 already used by underlay fabric-a",
+		},
+		{
+			name: "multiple underlays, vlan parent moved by another underlay",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.2.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+								VLAN: &v1alpha1.VLANDevice{Parent: "eth0", VLANID: 100},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay fabric-b has vlan parent eth0 moved into the router by underlay fabric-a",
+		},
+		{
+			name: "multiple underlays, overlapping tunnel endpoints",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "fabric-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.128/25"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65002,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65003)),
+								Address: new("192.168.2.1"),
+							},
+						},
 					},
 				},
 			},
-			wantErrStr: "can't have more than one underlay per node",
+			wantErrStr: "underlay fabric-b has tunnel endpoint CIDR 192.168.1.128/25 overlapping with 192.168.1.0/24 of underlay fabric-a",
 		},
 		{
 			name: "duplicate listen range",
@@ -1017,7 +1510,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "single node matching multiple underlays - should error",
+			name: "single node matching multiple underlays without tunnel endpoints - should error",
 			nodes: []corev1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1061,7 +1554,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "can't have more than one underlay per node",
+			errMsg:  "must set tunnelEndpoint when the node has more than one underlay",
 		},
 		{
 			name: "multiple nodes each with one matching underlay",
@@ -1297,7 +1790,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "can't have more than one underlay per node",
+			errMsg:  "must set tunnelEndpoint when the node has more than one underlay",
 		},
 		{
 			name:  "no nodes",
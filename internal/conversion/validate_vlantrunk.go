@@ -0,0 +1,71 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+	"k8s.io/utils/ptr"
+)
+
+// FilterValidVLANTrunkL2VNIs returns the L2VNIs that can be exposed to the
+// host as VLANs of the trunk, alongside per-resource errors for the others.
+// As they share a single veth pair, they must be tagged with distinct VLAN
+// IDs and attach the trunk to the bridge of the first one.
+// L2VNIs with a different host master are returned unchanged.
+func FilterValidVLANTrunkL2VNIs(l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L2VNI, error) {
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	vlans := map[int32]string{}
+	var first *v1alpha1.L2VNI
+	var validL2 []v1alpha1.L2VNI
+	for _, l2 := range l2Vnis {
+		if !isVLANTrunk(l2.Spec.HostMaster) {
+			validL2 = append(validL2, l2)
+			continue
+		}
+		if err := validateVLANTrunkL2VNI(l2, first, vlans); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L2VNI", Name: l2.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			continue
+		}
+		vlans[l2.Spec.HostMaster.VLANTrunk.VLANID] = "L2VNI/" + l2.Name
+		if first == nil {
+			first = &l2
+		}
+		validL2 = append(validL2, l2)
+	}
+
+	return validL2, errors.Join(allErrors...)
+}
+
+// validateVLANTrunkL2VNI checks that the L2VNI can share the trunk with the
+// first valid one.
+func validateVLANTrunkL2VNI(l2 v1alpha1.L2VNI, first *v1alpha1.L2VNI, vlans map[int32]string) error {
+	trunk := l2.Spec.HostMaster.VLANTrunk
+	if existing, ok := vlans[trunk.VLANID]; ok {
+		return fmt.Errorf("duplicate trunk vlanID %d:%s", trunk.VLANID, existing)
+	}
+	if first == nil {
+		return nil
+	}
+	bridge, firstBridge := ptr.Deref(trunk.Bridge, ""), ptr.Deref(first.Spec.HostMaster.VLANTrunk.Bridge, "")
+	if bridge != firstBridge {
+		return fmt.Errorf("trunk bridge %q differs from the trunk bridge %q of L2VNI %s, sharing the same trunk",
+			bridge, firstBridge, first.Name)
+	}
+	return nil
+}
+
+// isVLANTrunk tells if the L2VNI is exposed to the host as a VLAN of the
+// trunk.
+func isVLANTrunk(hostMaster *v1alpha1.HostMaster) bool {
+	return hostMaster != nil && hostMaster.Type == v1alpha1.VLANTrunk && hostMaster.VLANTrunk != nil
+}
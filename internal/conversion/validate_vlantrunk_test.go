@@ -0,0 +1,95 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestFilterValidVLANTrunkL2VNIs(t *testing.T) {
+	l2vni := func(name string, hostMaster *v1alpha1.HostMaster) v1alpha1.L2VNI {
+		return v1alpha1.L2VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec:       v1alpha1.L2VNISpec{VNI: 100, HostMaster: hostMaster},
+		}
+	}
+	trunk := func(vlanID int32, bridge *string) *v1alpha1.HostMaster {
+		return &v1alpha1.HostMaster{
+			Type:      v1alpha1.VLANTrunk,
+			VLANTrunk: &v1alpha1.VLANTrunkConfig{VLANID: vlanID, Bridge: bridge},
+		}
+	}
+	linuxBridge := &v1alpha1.HostMaster{
+		Type:        v1alpha1.LinuxBridge,
+		LinuxBridge: &v1alpha1.LinuxBridgeConfig{Lifecycle: v1alpha1.BridgeLifecycleManaged},
+	}
+
+	tests := []struct {
+		name       string
+		l2vnis     []v1alpha1.L2VNI
+		wantValid  []string
+		wantErrors []string
+	}{
+		{
+			name: "no trunk",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", nil),
+				l2vni("b", linuxBridge),
+			},
+			wantValid: []string{"a", "b"},
+		},
+		{
+			name: "valid trunk vlans",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", trunk(10, new("br-vms"))),
+				l2vni("b", linuxBridge),
+				l2vni("c", trunk(11, new("br-vms"))),
+			},
+			wantValid: []string{"a", "b", "c"},
+		},
+		{
+			name: "duplicate trunk vlan",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", trunk(10, nil)),
+				l2vni("b", trunk(10, nil)),
+			},
+			wantValid:  []string{"a"},
+			wantErrors: []string{"duplicate trunk vlanID 10:L2VNI/a"},
+		},
+		{
+			name: "different trunk bridge",
+			l2vnis: []v1alpha1.L2VNI{
+				l2vni("a", trunk(10, nil)),
+				l2vni("b", trunk(11, new("br-vms"))),
+			},
+			wantValid:  []string{"a"},
+			wantErrors: []string{`trunk bridge "br-vms" differs from the trunk bridge "" of L2VNI a`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, err := FilterValidVLANTrunkL2VNIs(tc.l2vnis)
+			var validNames []string
+			for _, l2 := range valid {
+				validNames = append(validNames, l2.Name)
+			}
+			if strings.Join(validNames, ",") != strings.Join(tc.wantValid, ",") {
+				t.Errorf("valid L2VNIs = %v, want %v", validNames, tc.wantValid)
+			}
+			if len(tc.wantErrors) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tc.wantErrors {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
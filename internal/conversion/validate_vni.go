@@ -53,7 +53,8 @@ func FilterValidL3VNIs(l3Vnis []v1alpha1.L3VNI) ([]v1alpha1.L3VNI, error) {
 	return valid, errors.Join(allErrors...)
 }
 
-// validateL3VNI validates a single L3VNI's fields (VRF name, route targets).
+// validateL3VNI validates a single L3VNI's fields (VRF name, route targets,
+// host session policies, communities, static routes, vrf imports).
 func validateL3VNI(l3Vni v1alpha1.L3VNI) error {
 	vni := vniFromL3VNI(l3Vni)
 	if err := isValidInterfaceName(vni.vrfName); err != nil {
@@ -62,6 +63,24 @@ func validateL3VNI(l3Vni v1alpha1.L3VNI) error {
 	if err := ValidateRouteTargets(vni); err != nil {
 		return fmt.Errorf("invalid route targets for vni %q: %w", vni.name, err)
 	}
+	if err := validateVRFHostSessions(l3Vni.Spec.HostSession, l3Vni.Spec.HostSessions); err != nil {
+		return fmt.Errorf("invalid host session for vni %q: %w", vni.name, err)
+	}
+	if err := validateVRFCommunities(l3Vni.Spec.Communities); err != nil {
+		return fmt.Errorf("invalid communities for vni %q: %w", vni.name, err)
+	}
+	if err := validateStaticRoutes(l3Vni.Spec.StaticRoutes); err != nil {
+		return fmt.Errorf("invalid static routes for vni %q: %w", vni.name, err)
+	}
+	if err := validateAggregates(l3Vni.Spec.Aggregates); err != nil {
+		return fmt.Errorf("invalid aggregates for vni %q: %w", vni.name, err)
+	}
+	if err := validateVRFImports(vni.vrfName, l3Vni.Spec.ImportVRFs, l3Vni.Spec.ExportToDefaultVRF); err != nil {
+		return fmt.Errorf("invalid vrf imports for vni %q: %w", vni.name, err)
+	}
+	if err := validateVXLanTunnel(l3Vni.Spec.VXLanTunnel); err != nil {
+		return fmt.Errorf("invalid vxlanTunnel for vni %q: %w", vni.name, err)
+	}
 	return nil
 }
 
@@ -135,7 +154,8 @@ func FilterUniqueL2VNIs(l2Vnis []v1alpha1.L2VNI, existingVNIs map[int32]string)
 	return validL2, errors.Join(allErrors...)
 }
 
-// validateL2VNI validates a single L2VNI's fields (HostMaster, GatewayIPs).
+// validateL2VNI validates a single L2VNI's fields (HostMaster, GatewayIPs, VLAN, MulticastGroup, VXLanTunnel,
+// RouterAdvertisement, DHCPRelay, EVPNAdvertisement, RoutingDomain IRBMode).
 func validateL2VNI(l2Vni v1alpha1.L2VNI) error {
 	if l2Vni.Spec.HostMaster != nil {
 		if err := validateHostMaster(l2Vni.Name, l2Vni.Spec.HostMaster); err != nil {
@@ -147,6 +167,191 @@ func validateL2VNI(l2Vni v1alpha1.L2VNI) error {
 			return fmt.Errorf("invalid gatewayIPs for vni %q = %v: %w", l2Vni.Name, l2Vni.Spec.GatewayIPs, err)
 		}
 	}
+	if l2Vni.Spec.VLAN != nil {
+		if err := validateVLAN(*l2Vni.Spec.VLAN); err != nil {
+			return fmt.Errorf("invalid vlan for vni %q: %w", l2Vni.Name, err)
+		}
+	}
+	if l2Vni.Spec.GatewayMAC != nil {
+		if len(l2Vni.Spec.GatewayIPs) == 0 {
+			return fmt.Errorf("gatewayMAC cannot be set without gatewayIPs for vni %q", l2Vni.Name)
+		}
+		if err := validateGatewayMAC(*l2Vni.Spec.GatewayMAC); err != nil {
+			return fmt.Errorf("invalid gatewayMAC for vni %q: %w", l2Vni.Name, err)
+		}
+	}
+	if l2Vni.Spec.MulticastGroup != nil {
+		if err := validateMulticastGroup(*l2Vni.Spec.MulticastGroup); err != nil {
+			return fmt.Errorf("invalid multicastGroup for vni %q: %w", l2Vni.Name, err)
+		}
+	}
+	if err := validateVXLanTunnel(l2Vni.Spec.VXLanTunnel); err != nil {
+		return fmt.Errorf("invalid vxlanTunnel for vni %q: %w", l2Vni.Name, err)
+	}
+	if l2Vni.Spec.RouterAdvertisement != nil {
+		if err := validateRouterAdvertisement(l2Vni.Spec.RouterAdvertisement, l2Vni.Spec.GatewayIPs); err != nil {
+			return fmt.Errorf("invalid routerAdvertisement for vni %q: %w", l2Vni.Name, err)
+		}
+	}
+	if l2Vni.Spec.DHCPRelay != nil {
+		if err := validateDHCPRelay(l2Vni); err != nil {
+			return fmt.Errorf("invalid dhcpRelay for vni %q: %w", l2Vni.Name, err)
+		}
+	}
+	if adv := l2Vni.Spec.EVPNAdvertisement; adv != nil {
+		if len(l2Vni.Spec.GatewayIPs) == 0 {
+			return fmt.Errorf("evpnAdvertisement cannot be set without gatewayIPs for vni %q", l2Vni.Name)
+		}
+		if ptr.Deref(adv.DefaultGateway, false) && ptr.Deref(adv.SVIIP, false) {
+			return fmt.Errorf("invalid evpnAdvertisement for vni %q: defaultGateway and sviIP are mutually exclusive", l2Vni.Name)
+		}
+	}
+	if isAsymmetricIRB(l2Vni) && l2Vni.Spec.RoutingDomain.Type != v1alpha1.RoutingDomainTypeL3VNI {
+		return fmt.Errorf("irbMode %s requires routingDomain type %s for vni %q",
+			v1alpha1.IRBModeAsymmetric, v1alpha1.RoutingDomainTypeL3VNI, l2Vni.Name)
+	}
+	return nil
+}
+
+// defaultRAInterval is FRR's default maximum interval between two router
+// advertisements, in seconds.
+const defaultRAInterval = 600
+
+// validateRouterAdvertisement checks that the router advertisements are sent
+// on a gateway interface with an IPv6 address, and that their parameters are
+// accepted by FRR.
+func validateRouterAdvertisement(ra *v1alpha1.RouterAdvertisementConfig, gatewayIPs []string) error {
+	hasIPv6Gateway := slices.ContainsFunc(gatewayIPs, func(cidr string) bool {
+		ip, _, err := net.ParseCIDR(cidr)
+		return err == nil && ip.To4() == nil
+	})
+	if !hasIPv6Gateway {
+		return fmt.Errorf("requires an IPv6 address in gatewayIPs")
+	}
+	interval := ptr.Deref(ra.IntervalSeconds, defaultRAInterval)
+	if interval < 1 || interval > 1800 {
+		return fmt.Errorf("invalid intervalSeconds %d, must be between 1 and 1800", interval)
+	}
+	if ra.LifetimeSeconds != nil {
+		lifetime := *ra.LifetimeSeconds
+		if lifetime < 0 || lifetime > 9000 {
+			return fmt.Errorf("invalid lifetimeSeconds %d, must be between 0 and 9000", lifetime)
+		}
+		if lifetime != 0 && lifetime < interval {
+			return fmt.Errorf("lifetimeSeconds %d must be 0 or not lower than intervalSeconds %d", lifetime, interval)
+		}
+	}
+	for _, p := range ra.Prefixes {
+		ip, _, err := net.ParseCIDR(p.Prefix)
+		if err != nil || ip.To4() != nil {
+			return fmt.Errorf("invalid prefix %s, must be an IPv6 CIDR", p.Prefix)
+		}
+	}
+	for _, server := range ra.DNSServers {
+		ip := net.ParseIP(server)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid dnsServer %s, must be an IPv6 address", server)
+		}
+	}
+	return nil
+}
+
+// validateDHCPRelay checks that the DHCP requests are relayed from a gateway
+// interface with an IPv4 address, from an IPv4 source address of the node,
+// and that the relay agent information option fits in a DHCP option.
+func validateDHCPRelay(l2Vni v1alpha1.L2VNI) error {
+	relay := l2Vni.Spec.DHCPRelay
+	if _, ok := ipv4GatewayIP(l2Vni.Spec.GatewayIPs); !ok {
+		return fmt.Errorf("requires an IPv4 address in gatewayIPs")
+	}
+	if len(relay.Servers) == 0 {
+		return fmt.Errorf("at least one server is required")
+	}
+	for _, server := range relay.Servers {
+		ip := net.ParseIP(server)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid server %s, must be an IPv4 address", server)
+		}
+	}
+	ip, _, err := net.ParseCIDR(relay.SourceCIDR)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("invalid sourceCIDR %q, must be an IPv4 CIDR", relay.SourceCIDR)
+	}
+	if relay.Option82 == nil {
+		return nil
+	}
+	option82 := dhcpRelayOption82ToHost(l2Vni)
+	// Each sub-option takes two bytes on top of its value, and the whole
+	// option must fit in 255 bytes, the link selection and server
+	// identifier override sub-options included.
+	size := 2 + len(option82.CircuitID) + 2*(2+net.IPv4len)
+	if option82.RemoteID != "" {
+		size += 2 + len(option82.RemoteID)
+	}
+	if size > 255 {
+		return fmt.Errorf("option82 circuitID and remoteID too long, %d bytes over 255", size)
+	}
+	return nil
+}
+
+// validateVXLanTunnel checks that the properties of the outer headers of the
+// encapsulated packets are in the range accepted by the kernel.
+func validateVXLanTunnel(tunnel *v1alpha1.VXLanTunnelConfig) error {
+	if tunnel == nil {
+		return nil
+	}
+	if tunnel.TTL != nil && (*tunnel.TTL < 1 || *tunnel.TTL > 255) {
+		return fmt.Errorf("invalid ttl %d, must be between 1 and 255", *tunnel.TTL)
+	}
+	if tunnel.DSCP != nil && (*tunnel.DSCP < 0 || *tunnel.DSCP > 63) {
+		return fmt.Errorf("invalid dscp %d, must be between 0 and 63", *tunnel.DSCP)
+	}
+	if tunnel.DontFragment != nil {
+		switch *tunnel.DontFragment {
+		case v1alpha1.VXLanDontFragmentUnset, v1alpha1.VXLanDontFragmentSet, v1alpha1.VXLanDontFragmentInherit:
+		default:
+			return fmt.Errorf("invalid dontFragment %q", *tunnel.DontFragment)
+		}
+	}
+	if r := tunnel.SourcePortRange; r != nil {
+		if r.Min < 1 || r.Max > 65535 || r.Min >= r.Max {
+			return fmt.Errorf("invalid sourcePortRange %d-%d, min must be less than max, between 1 and 65535", r.Min, r.Max)
+		}
+	}
+	return nil
+}
+
+// validateMulticastGroup checks that the given address is an IPv4 multicast
+// group that can be routed on the underlay.
+func validateMulticastGroup(group string) error {
+	ip := net.ParseIP(group)
+	if ip == nil || ip.To4() == nil {
+		return fmt.Errorf("%s is not a valid IPv4 address", group)
+	}
+	if !ip.IsMulticast() {
+		return fmt.Errorf("%s is not a multicast address", group)
+	}
+	if ip.IsLinkLocalMulticast() {
+		return fmt.Errorf("%s is a link local multicast address", group)
+	}
+	return nil
+}
+
+// validateGatewayMAC checks that the given address is a valid unicast MAC address.
+func validateGatewayMAC(mac string) error {
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return err
+	}
+	if len(hwAddr) != 6 {
+		return fmt.Errorf("%s is not a 48 bit MAC address", mac)
+	}
+	if hwAddr[0]&0x01 != 0 {
+		return fmt.Errorf("%s is not a unicast MAC address", mac)
+	}
+	if bytes.Equal(hwAddr, make(net.HardwareAddr, 6)) {
+		return fmt.Errorf("%s is not a valid MAC address", mac)
+	}
 	return nil
 }
 
@@ -212,6 +417,15 @@ func validateOverlayResourcesForNode(node corev1.Node, l2vnis []v1alpha1.L2VNI,
 		return fmt.Errorf("duplicate VNIs found in L2VNIs for node %q: %w", node.Name, err)
 	}
 
+	validL2VNIs, err = FilterValidIRBModeL2VNIs(validL2VNIs)
+	if err != nil {
+		return fmt.Errorf("inconsistent irbMode found in L2VNIs for node %q: %w", node.Name, err)
+	}
+
+	if err := validateAsymmetricIRBForNode(l2vnis, filteredL2VNIs, filteredL3VNIs); err != nil {
+		return fmt.Errorf("asymmetric IRB L2VNIs missing on node %q: %w", node.Name, err)
+	}
+
 	validL3VNIs, err = FilterUniqueVRFsForL3VNIs(validL3VNIs)
 	if err != nil {
 		return fmt.Errorf("duplicate L3VNI VRFs found for node %q: %w", node.Name, err)
@@ -335,20 +549,20 @@ func ValidateVRFSubnets(l2Vnis []v1alpha1.L2VNI, l3Vnis []v1alpha1.L3VNI, l3Vpns
 	for _, l3vni := range l3Vnis {
 		vrfName := l3vni.Spec.VRF
 		source := fmt.Sprintf("L3VNI %s", types.NamespacedName{Namespace: l3vni.Namespace, Name: l3vni.Name})
-		if subnet := v4SubnetForL3(l3vni); subnet != nil {
+		for _, subnet := range v4SubnetsForL3(l3vni) {
 			v4SubnetsForVRF[vrfName] = append(v4SubnetsForVRF[vrfName], subnetWithSource{source, subnet})
 		}
-		if subnet := v6SubnetForL3(l3vni); subnet != nil {
+		for _, subnet := range v6SubnetsForL3(l3vni) {
 			v6SubnetsForVRF[vrfName] = append(v6SubnetsForVRF[vrfName], subnetWithSource{source, subnet})
 		}
 	}
 	for _, l3vpn := range l3Vpns {
 		vrfName := l3vpn.Spec.VRF
 		source := fmt.Sprintf("L3VPN %s", types.NamespacedName{Namespace: l3vpn.Namespace, Name: l3vpn.Name})
-		if subnet := v4SubnetForL3VPN(l3vpn); subnet != nil {
+		for _, subnet := range v4SubnetsForL3VPN(l3vpn) {
 			v4SubnetsForVRF[vrfName] = append(v4SubnetsForVRF[vrfName], subnetWithSource{source, subnet})
 		}
-		if subnet := v6SubnetForL3VPN(l3vpn); subnet != nil {
+		for _, subnet := range v6SubnetsForL3VPN(l3vpn) {
 			v6SubnetsForVRF[vrfName] = append(v6SubnetsForVRF[vrfName], subnetWithSource{source, subnet})
 		}
 	}
@@ -469,6 +683,13 @@ func validateHostMaster(vniName string, hostConfig *v1alpha1.HostMaster) error {
 		if hostConfig.OVSBridge != nil {
 			name = ptr.Deref(hostConfig.OVSBridge.Name, "")
 		}
+	case v1alpha1.VLANTrunk:
+		if hostConfig.VLANTrunk != nil {
+			if err := validateVLAN(hostConfig.VLANTrunk.VLANID); err != nil {
+				return fmt.Errorf("invalid hostmaster vlanID for vni %s: %w", vniName, err)
+			}
+			name = ptr.Deref(hostConfig.VLANTrunk.Bridge, "")
+		}
 	default:
 		return fmt.Errorf("invalid hostmaster type %q", hostConfig.Type)
 	}
@@ -512,36 +733,35 @@ func v6SubnetForL2(l2vni v1alpha1.L2VNI) *net.IPNet {
 	return nil
 }
 
-// v4SubnetForL3 extracts the valid IPv4 subnet from the l3vni, or returns nil.
-func v4SubnetForL3(l3vni v1alpha1.L3VNI) *net.IPNet {
-	if l3vni.Spec.HostSession == nil {
-		return nil
-	}
-	ipv4 := ptr.Deref(l3vni.Spec.HostSession.LocalCIDR.IPv4, "")
-	if ipv4 == "" {
-		return nil
-	}
-	_, ipnet, err := net.ParseCIDR(ipv4)
-	if err != nil {
-		return nil
-	}
-	return ipnet
+// v4SubnetsForL3 extracts the valid IPv4 subnets of the host sessions of the l3vni.
+func v4SubnetsForL3(l3vni v1alpha1.L3VNI) []*net.IPNet {
+	return hostSessionsSubnets(vrfHostSessions(l3vni.Spec.HostSession, l3vni.Spec.HostSessions), ipfamily.IPv4)
 }
 
-// v6SubnetForL3 extracts the valid IPv6 subnet from the l3vni, or returns nil.
-func v6SubnetForL3(l3vni v1alpha1.L3VNI) *net.IPNet {
-	if l3vni.Spec.HostSession == nil {
-		return nil
-	}
-	ipv6 := ptr.Deref(l3vni.Spec.HostSession.LocalCIDR.IPv6, "")
-	if ipv6 == "" {
-		return nil
-	}
-	_, ipnet, err := net.ParseCIDR(ipv6)
-	if err != nil {
-		return nil
+// v6SubnetsForL3 extracts the valid IPv6 subnets of the host sessions of the l3vni.
+func v6SubnetsForL3(l3vni v1alpha1.L3VNI) []*net.IPNet {
+	return hostSessionsSubnets(vrfHostSessions(l3vni.Spec.HostSession, l3vni.Spec.HostSessions), ipfamily.IPv6)
+}
+
+// hostSessionsSubnets extracts the valid local CIDRs of the given family
+// from the host sessions.
+func hostSessionsSubnets(sessions []v1alpha1.VRFHostSession, family ipfamily.Family) []*net.IPNet {
+	var res []*net.IPNet
+	for _, s := range sessions {
+		cidr := ptr.Deref(s.LocalCIDR.IPv4, "")
+		if family == ipfamily.IPv6 {
+			cidr = ptr.Deref(s.LocalCIDR.IPv6, "")
+		}
+		if cidr == "" {
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		res = append(res, ipnet)
 	}
-	return ipnet
+	return res
 }
 
 // subnetWithSource holds subnet information for a single IP address family
@@ -234,6 +234,40 @@ func TestFilterValidL2VNIs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid trunk vlanID",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type:      v1alpha1.VLANTrunk,
+							VLANTrunk: &v1alpha1.VLANTrunkConfig{VLANID: 4095},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid trunk",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type:      v1alpha1.VLANTrunk,
+							VLANTrunk: &v1alpha1.VLANTrunkConfig{VLANID: 10, Bridge: new("brvms")},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid GatewayIPs IPv4 CIDR",
 			vnis: []v1alpha1.L2VNI{
@@ -252,6 +286,95 @@ func TestFilterValidL2VNIs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid GatewayMAC",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayIPs: []string{"192.168.1.0/24"},
+						GatewayMAC: new("00:00:5e:00:01:01"),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GatewayMAC without GatewayIPs",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayMAC: new("00:00:5e:00:01:01"),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multicast GatewayMAC",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayIPs: []string{"192.168.1.0/24"},
+						GatewayMAC: new("01:00:5e:00:01:01"),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero GatewayMAC",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayIPs: []string{"192.168.1.0/24"},
+						GatewayMAC: new("00:00:00:00:00:00"),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid VLAN",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:  1001,
+						VLAN: new(int32(4095)),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "valid GatewayIPs IPv6 CIDR",
 			vnis: []v1alpha1.L2VNI{
@@ -354,6 +477,291 @@ func TestFilterValidL2VNIs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid multicastGroup",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:            1001,
+						MulticastGroup: new("239.1.1.1"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unicast multicastGroup",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:            1001,
+						MulticastGroup: new("192.168.1.1"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid vxlanTunnel",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						VXLanTunnel: &v1alpha1.VXLanTunnelConfig{
+							TTL:             new(int32(64)),
+							DSCP:            new(int32(46)),
+							DontFragment:    new(v1alpha1.VXLanDontFragmentSet),
+							SourcePortRange: &v1alpha1.PortRange{Min: 49152, Max: 65535},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "vxlanTunnel with an empty source port range",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						VXLanTunnel: &v1alpha1.VXLanTunnelConfig{
+							SourcePortRange: &v1alpha1.PortRange{Min: 50000, Max: 50000},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "vxlanTunnel with an invalid dscp",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:         1001,
+						VXLanTunnel: &v1alpha1.VXLanTunnelConfig{DSCP: new(int32(64))},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid routerAdvertisement",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24", "2001:db8::1/64"},
+						RouterAdvertisement: &v1alpha1.RouterAdvertisementConfig{
+							IntervalSeconds: new(int32(30)),
+							LifetimeSeconds: new(int32(90)),
+							Prefixes:        []v1alpha1.RouterAdvertisementPrefix{{Prefix: "2001:db8::/64", Autonomous: new(false)}},
+							DNSServers:      []string{"2001:db8::53"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "routerAdvertisement without an IPv6 gatewayIP",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:                 1001,
+						GatewayIPs:          []string{"192.168.1.1/24"},
+						RouterAdvertisement: &v1alpha1.RouterAdvertisementConfig{},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "routerAdvertisement lifetime lower than the default interval",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:                 1001,
+						GatewayIPs:          []string{"2001:db8::1/64"},
+						RouterAdvertisement: &v1alpha1.RouterAdvertisementConfig{LifetimeSeconds: new(int32(300))},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "routerAdvertisement with an IPv4 dnsServer",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:                 1001,
+						GatewayIPs:          []string{"2001:db8::1/64"},
+						RouterAdvertisement: &v1alpha1.RouterAdvertisementConfig{DNSServers: []string{"8.8.8.8"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid dhcpRelay",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24", "2001:db8::1/64"},
+						DHCPRelay: &v1alpha1.DHCPRelayConfig{
+							Servers:    []string{"10.100.0.10"},
+							SourceCIDR: "10.200.0.0/24",
+							Option82:   &v1alpha1.DHCPRelayOption82{RemoteID: new("node1")},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dhcpRelay without an IPv4 gatewayIP",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"2001:db8::1/64"},
+						DHCPRelay:  &v1alpha1.DHCPRelayConfig{Servers: []string{"10.100.0.10"}, SourceCIDR: "10.200.0.0/24"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dhcpRelay with an IPv6 server",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24"},
+						DHCPRelay:  &v1alpha1.DHCPRelayConfig{Servers: []string{"2001:db8::10"}, SourceCIDR: "10.200.0.0/24"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dhcpRelay without sourceCIDR",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24"},
+						DHCPRelay:  &v1alpha1.DHCPRelayConfig{Servers: []string{"10.100.0.10"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dhcpRelay with an IPv6 sourceCIDR",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24"},
+						DHCPRelay:  &v1alpha1.DHCPRelayConfig{Servers: []string{"10.100.0.10"}, SourceCIDR: "fd00:200::/64"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dhcpRelay option82 too long",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 200)},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24"},
+						DHCPRelay: &v1alpha1.DHCPRelayConfig{
+							Servers:    []string{"10.100.0.10"},
+							SourceCIDR: "10.200.0.0/24",
+							Option82:   &v1alpha1.DHCPRelayOption82{RemoteID: new(strings.Repeat("b", 64))},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "evpnAdvertisement without gatewayIPs",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:               1001,
+						EVPNAdvertisement: &v1alpha1.EVPNAdvertisementConfig{SVIIP: new(true)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "evpnAdvertisement with both defaultGateway and sviIP",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:        1001,
+						GatewayIPs: []string{"192.168.1.1/24"},
+						EVPNAdvertisement: &v1alpha1.EVPNAdvertisementConfig{
+							DefaultGateway: new(true),
+							SVIIP:          new(true),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "asymmetric irbMode with an L3VPN routing domain",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:    v1alpha1.RoutingDomainTypeL3VPN,
+							L3VPN:   &v1alpha1.L3VPNReference{Name: "vpn"},
+							IRBMode: new(v1alpha1.IRBModeAsymmetric),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "link local multicastGroup",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:            1001,
+						MulticastGroup: new("224.0.0.5"),
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1139,6 +1547,98 @@ func TestValidateOverlayResourcesForNodes(t *testing.T) {
 				"more than one L3VNI detected in VRF \"red\": " +
 				"\"test/l3vni1\" already exists",
 		},
+		{
+			name: "asymmetric L2VNI on all the nodes of its L3VNI",
+			nodes: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"rack": "a"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"rack": "b"}}},
+			},
+			l3vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l3vni1"},
+					Spec:       v1alpha1.L3VNISpec{VNI: 100, VRF: "red"},
+				},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 200,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:    v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI:   &v1alpha1.L3VNIReference{Name: "l3vni1"},
+							IRBMode: new(v1alpha1.IRBModeAsymmetric),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "asymmetric L2VNI missing on a node of its L3VNI",
+			nodes: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"rack": "a"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"rack": "b"}}},
+			},
+			l3vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l3vni1"},
+					Spec:       v1alpha1.L3VNISpec{VNI: 100, VRF: "red"},
+				},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 200,
+						NodeSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"rack": "a"},
+						},
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:    v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI:   &v1alpha1.L3VNIReference{Name: "l3vni1"},
+							IRBMode: new(v1alpha1.IRBModeAsymmetric),
+						},
+					},
+				},
+			},
+			wantErrStr: "asymmetric IRB L2VNIs missing on node \"node2\": " +
+				"L2VNI l2vni1 uses asymmetric IRB but is not selected on all the nodes of L3VNI l3vni1",
+		},
+		{
+			name:  "mixed irbModes in an L3VNI",
+			nodes: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			l3vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l3vni1"},
+					Spec:       v1alpha1.L3VNISpec{VNI: 100, VRF: "red"},
+				},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 200,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:    v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI:   &v1alpha1.L3VNIReference{Name: "l3vni1"},
+							IRBMode: new(v1alpha1.IRBModeAsymmetric),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni2"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 300,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "l3vni1"},
+						},
+					},
+				},
+			},
+			wantErrStr: "inconsistent irbMode found in L2VNIs for node \"node1\": L2VNI/l2vni2: " +
+				"irbMode differs from the one of L2VNI l2vni1, all the L2VNIs of L3VNI l3vni1 must use the same irbMode",
+		},
 		{
 			name:  "duplicate VRF across L3VPNs",
 			nodes: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
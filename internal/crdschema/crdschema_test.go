@@ -382,6 +382,55 @@ func TestValidateSuccessful(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "Underlay with bond interface",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type": "Bond",
+						"bond": map[string]any{
+							"interfaceName":      "bond0",
+							"members":            []any{"eth1", "eth2"},
+							"mode":               "LACP",
+							"lacpRate":           "Fast",
+							"transmitHashPolicy": "Layer34",
+							"addresses":          []any{"192.168.1.2/24"},
+						},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+		},
+		{
+			name: "Underlay with VLAN interface",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type": "VLAN",
+						"vlan": map[string]any{
+							"parent":    "eth1",
+							"vlanID":    int64(100),
+							"addresses": []any{"192.168.1.2/24", "2001:db8::2/64"},
+						},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+		},
 		{
 			name: "L2VNI with LinuxBridge External lifecycle and name set",
 			gvk:  l2vniGVK,
@@ -445,6 +494,107 @@ func TestValidateSuccessful(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "L3VNI with multiple host sessions",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "testvrf",
+				"vni": int64(200),
+				"hostSessions": []any{
+					map[string]any{
+						"name":    "metallb",
+						"asn":     int64(65000),
+						"hostASN": int64(65001),
+						"localCIDR": map[string]any{
+							"ipv4": "10.0.0.0/30",
+						},
+					},
+					map[string]any{
+						"name":    "calico",
+						"asn":     int64(65002),
+						"hostASN": int64(65003),
+						"localCIDR": map[string]any{
+							"ipv4": "10.0.1.0/30",
+						},
+					},
+				},
+			}),
+		},
+		{
+			name: "Underlay with a permanent duplicate address freeze",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type":          "NetworkDevice",
+						"networkDevice": map[string]any{"interfaceName": "eth0"},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+				"evpn": map[string]any{
+					"duplicateAddressDetection": map[string]any{
+						"maxMoves":         int64(10),
+						"timeSeconds":      int64(600),
+						"freeze":           map[string]any{"permanent": true},
+						"clearFrozenToken": "2026-10-17",
+					},
+				},
+			}),
+		},
+		{
+			name: "L2VNI with router advertisements",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"192.168.10.1/24", "2001:db8::1/64"},
+				"routerAdvertisement": map[string]any{
+					"intervalSeconds": int64(30),
+					"lifetimeSeconds": int64(90),
+					"prefixes": []any{
+						map[string]any{"prefix": "2001:db8::/64", "autonomous": false},
+					},
+					"dnsServers": []any{"2001:db8::53"},
+				},
+			}),
+		},
+		{
+			name: "L2VNI with a DHCP relay",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"192.168.10.1/24"},
+				"dhcpRelay": map[string]any{
+					"servers":    []any{"10.100.0.10", "10.100.0.11"},
+					"sourceCIDR": "10.200.0.0/24",
+					"option82": map[string]any{
+						"remoteID": "node1",
+						"policy":   "Replace",
+					},
+				},
+			}),
+		},
+		{
+			name: "L2VNI with asymmetric IRB and gateway advertisement",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni": int64(100),
+				"routingDomain": map[string]any{
+					"type":    "L3VNI",
+					"l3vni":   map[string]any{"name": "red"},
+					"irbMode": "Asymmetric",
+				},
+				"gatewayIPs":        []any{"192.168.10.1/24"},
+				"evpnAdvertisement": map[string]any{"defaultGateway": true, "sviIP": false},
+			}),
+		},
 		{
 			name: "valid L3Passthrough",
 			gvk:  l3passthroughGVK,
@@ -546,6 +696,32 @@ func TestValidateFailure(t *testing.T) {
 			}),
 			errSubstr: "type/config mismatch",
 		},
+		{
+			name: "HostMaster type VLANTrunk without vlanTrunk field",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"hostMaster": map[string]any{
+					"type": "VLANTrunk",
+					"linuxBridge": map[string]any{
+						"lifecycle": "Managed",
+					},
+				},
+			}),
+			errSubstr: "type/config mismatch",
+		},
+		{
+			name: "HostMaster VLANTrunk with an out of range vlanID",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"hostMaster": map[string]any{
+					"type": "VLANTrunk",
+					"vlanTrunk": map[string]any{
+						"vlanID": int64(4095),
+					},
+				},
+			}),
+			errSubstr: "should be less than or equal to 4094",
+		},
 		{
 			name: "HostMaster type ovs-bridge with linuxBridge field",
 			gvk:  l2vniGVK,
@@ -629,6 +805,142 @@ func TestValidateFailure(t *testing.T) {
 			}),
 			errSubstr: "all interfaces must be of the same type",
 		},
+		{
+			name: "Underlay interface type Bond without bond",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type":          "Bond",
+						"networkDevice": map[string]any{"interfaceName": "eth0"},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+			errSubstr: "bond must be set if and only if type is 'Bond'",
+		},
+		{
+			name: "Underlay bond with lacpRate and ActiveBackup mode",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type": "Bond",
+						"bond": map[string]any{
+							"interfaceName": "bond0",
+							"members":       []any{"eth1", "eth2"},
+							"mode":          "ActiveBackup",
+							"lacpRate":      "Fast",
+						},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+			errSubstr: "lacpRate can only be set with the LACP mode",
+		},
+		{
+			name: "Underlay bond with an invalid address",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type": "Bond",
+						"bond": map[string]any{
+							"interfaceName": "bond0",
+							"members":       []any{"eth1", "eth2"},
+							"addresses":     []any{"192.168.1.2"},
+						},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+			errSubstr: "addresses must be valid CIDRs",
+		},
+		{
+			name: "Underlay interface type VLAN without vlan",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type":          "VLAN",
+						"networkDevice": map[string]any{"interfaceName": "eth0"},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+			errSubstr: "vlan must be set if and only if type is 'VLAN'",
+		},
+		{
+			name: "Underlay VLAN with a too long sub-interface name",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type": "VLAN",
+						"vlan": map[string]any{
+							"parent": "enp0s31f6abcd",
+							"vlanID": int64(100),
+						},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+			errSubstr: "must be at most 15 characters long",
+		},
+		{
+			name: "Underlay VLAN with an invalid address",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"interfaces": []any{
+					map[string]any{
+						"type": "VLAN",
+						"vlan": map[string]any{
+							"parent":    "eth1",
+							"vlanID":    int64(100),
+							"addresses": []any{"192.168.1.2"},
+						},
+					},
+				},
+				"neighbors": []any{
+					map[string]any{
+						"address": "192.168.1.1",
+						"asn":     int64(65001),
+					},
+				},
+			}),
+			errSubstr: "addresses must be valid CIDRs",
+		},
 		{
 			name: "Underlay CNI device type RawConfig without rawConfig",
 			gvk:  underlayGVK,
@@ -699,6 +1011,559 @@ func TestValidateFailure(t *testing.T) {
 			}),
 			errSubstr: "should be less than or equal to 65535",
 		},
+		{
+			name: "HostSession policy rule with ge greater than le",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "192.168.10.0/24",
+					},
+					"importPolicy": map[string]any{
+						"rules": []any{
+							map[string]any{
+								"prefix": "10.100.0.0/16",
+								"ge":     int64(28),
+								"le":     int64(24),
+							},
+						},
+					},
+				},
+			}),
+			errSubstr: "ge must be lower than or equal to le",
+		},
+		{
+			name: "HostSession policy rule with invalid prefix",
+			gvk:  l3passthroughGVK,
+			obj: newUnstructured("L3Passthrough", map[string]any{
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "192.168.10.0/24",
+					},
+					"exportPolicy": map[string]any{
+						"rules": []any{
+							map[string]any{
+								"prefix": "10.100.0.0",
+							},
+						},
+					},
+				},
+			}),
+			errSubstr: "prefix must be a valid CIDR",
+		},
+		{
+			name: "L3VNI communities without any community",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"communities": map[string]any{
+					"importMatch": map[string]any{},
+				},
+			}),
+			errSubstr: "at least one community must be set",
+		},
+		{
+			name: "L3VNI communities with invalid extended community type",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"communities": map[string]any{
+					"export": map[string]any{
+						"extended": []any{
+							map[string]any{
+								"type":  "Color",
+								"value": "64512:1",
+							},
+						},
+					},
+				},
+			}),
+			errSubstr: "Unsupported value",
+		},
+		{
+			name: "L3VNI host session with both password and passwordSecret",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "10.0.0.0/30",
+					},
+					"password":       "secret",
+					"passwordSecret": "bgp-password",
+				},
+			}),
+			errSubstr: "password and passwordSecret cannot be set together",
+		},
+		{
+			name: "L3VNI host session with holdTimeSeconds only",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "10.0.0.0/30",
+					},
+					"holdTimeSeconds": int64(9),
+				},
+			}),
+			errSubstr: "holdTimeSeconds and keepaliveTimeSeconds must be both set or both unset",
+		},
+		{
+			name: "L3VNI host session with invalid graceful restart mode",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "10.0.0.0/30",
+					},
+					"gracefulRestart": "Always",
+				},
+			}),
+			errSubstr: "Unsupported value",
+		},
+		{
+			name: "L3VNI static route with both nextHop and blackhole",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"staticRoutes": []any{
+					map[string]any{
+						"prefix":    "10.100.0.0/16",
+						"nextHop":   "192.168.1.10",
+						"blackhole": true,
+					},
+				},
+			}),
+			errSubstr: "exactly one of nextHop, interface or blackhole must be set",
+		},
+		{
+			name: "L3VNI static route with nextHop of a different family",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"staticRoutes": []any{
+					map[string]any{
+						"prefix":  "10.100.0.0/16",
+						"nextHop": "2001:db8::10",
+					},
+				},
+			}),
+			errSubstr: "nextHop must be of the same IP family as prefix",
+		},
+		{
+			name: "L2VNI gatewayMAC without gatewayIPs",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":        int64(100),
+				"gatewayMAC": "00:00:5e:00:01:01",
+			}),
+			errSubstr: "gatewayMAC cannot be set without gatewayIPs",
+		},
+		{
+			name: "L2VNI with an invalid gatewayMAC",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni": int64(100),
+				"routingDomain": map[string]any{
+					"type":  "L3VNI",
+					"l3vni": map[string]any{"name": "red"},
+				},
+				"gatewayIPs": []any{"192.168.1.1/24"},
+				"gatewayMAC": "00:00:5e:00:01",
+			}),
+			errSubstr: "spec.gatewayMAC",
+		},
+		{
+			name: "L2VNI with an out of range vlan",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":  int64(100),
+				"vlan": int64(4095),
+			}),
+			errSubstr: "spec.vlan",
+		},
+		{
+			name: "L2VNI multicastGroup with the IPv6 underlayAddressFamily",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":                   int64(100),
+				"multicastGroup":        "239.1.1.1",
+				"underlayAddressFamily": "IPv6",
+			}),
+			errSubstr: "multicastGroup requires the IPv4 underlayAddressFamily",
+		},
+		{
+			name: "L2VNI with an IPv6 multicastGroup",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":            int64(100),
+				"multicastGroup": "ff0e::1",
+			}),
+			errSubstr: "multicastGroup must be a valid IPv4 address",
+		},
+		{
+			name: "Underlay multicast with an invalid rendezvousPoint",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"evpn": map[string]any{
+					"multicast": map[string]any{"rendezvousPoint": "10.0.0"},
+				},
+			}),
+			errSubstr: "rendezvousPoint must be a valid IPv4 address",
+		},
+		{
+			name: "L2VNI vxlanTunnel with an empty sourcePortRange",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni": int64(100),
+				"vxlanTunnel": map[string]any{
+					"sourcePortRange": map[string]any{"min": int64(50000), "max": int64(50000)},
+				},
+			}),
+			errSubstr: "min must be less than max",
+		},
+		{
+			name: "L3VNI vxlanTunnel with an out of range dscp",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf":         "red",
+				"vni":         int64(100),
+				"vxlanTunnel": map[string]any{"dscp": int64(64)},
+			}),
+			errSubstr: "should be less than or equal to 63",
+		},
+		{
+			name: "Underlay vxlanTunnel with an invalid dontFragment",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"evpn": map[string]any{
+					"vxlanTunnel": map[string]any{"dontFragment": "Always"},
+				},
+			}),
+			errSubstr: "spec.evpn.vxlanTunnel.dontFragment",
+		},
+		{
+			name: "L2VNI routerAdvertisement without an IPv6 gatewayIP",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":                 int64(100),
+				"routingDomain":       map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":          []any{"192.168.10.1/24"},
+				"routerAdvertisement": map[string]any{},
+			}),
+			errSubstr: "routerAdvertisement requires an IPv6 address in gatewayIPs",
+		},
+		{
+			name: "L2VNI routerAdvertisement lifetime lower than the interval",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"2001:db8::1/64"},
+				"routerAdvertisement": map[string]any{
+					"intervalSeconds": int64(60),
+					"lifetimeSeconds": int64(30),
+				},
+			}),
+			errSubstr: "lifetimeSeconds must be 0 or not lower than intervalSeconds",
+		},
+		{
+			name: "L2VNI routerAdvertisement with an IPv4 prefix",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"2001:db8::1/64"},
+				"routerAdvertisement": map[string]any{
+					"prefixes": []any{map[string]any{"prefix": "10.0.0.0/24"}},
+				},
+			}),
+			errSubstr: "prefix must be a valid IPv6 CIDR",
+		},
+		{
+			name: "L2VNI dhcpRelay without an IPv4 gatewayIP",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"2001:db8::1/64"},
+				"dhcpRelay":     map[string]any{"servers": []any{"10.100.0.10"}, "sourceCIDR": "10.200.0.0/24"},
+			}),
+			errSubstr: "dhcpRelay requires an IPv4 address in gatewayIPs",
+		},
+		{
+			name: "L2VNI dhcpRelay with an IPv6 server",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"192.168.10.1/24"},
+				"dhcpRelay":     map[string]any{"servers": []any{"2001:db8::10"}, "sourceCIDR": "10.200.0.0/24"},
+			}),
+			errSubstr: "servers must be valid IPv4 addresses",
+		},
+		{
+			name: "L2VNI dhcpRelay without sourceCIDR",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"192.168.10.1/24"},
+				"dhcpRelay":     map[string]any{"servers": []any{"10.100.0.10"}},
+			}),
+			errSubstr: "spec.dhcpRelay.sourceCIDR: Required value",
+		},
+		{
+			name: "L2VNI dhcpRelay with an IPv6 sourceCIDR",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"192.168.10.1/24"},
+				"dhcpRelay":     map[string]any{"servers": []any{"10.100.0.10"}, "sourceCIDR": "fd00:200::/64"},
+			}),
+			errSubstr: "sourceCIDR must be a valid IPv4 CIDR",
+		},
+		{
+			name: "L2VNI dhcpRelay with an invalid option82 policy",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":           int64(100),
+				"routingDomain": map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":    []any{"192.168.10.1/24"},
+				"dhcpRelay": map[string]any{
+					"servers":    []any{"10.100.0.10"},
+					"sourceCIDR": "10.200.0.0/24",
+					"option82":   map[string]any{"policy": "Append"},
+				},
+			}),
+			errSubstr: "spec.dhcpRelay.option82.policy",
+		},
+		{
+			name: "L2VNI asymmetric irbMode with an L3VPN routing domain",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni": int64(100),
+				"routingDomain": map[string]any{
+					"type":    "L3VPN",
+					"l3vpn":   map[string]any{"name": "red"},
+					"irbMode": "Asymmetric",
+				},
+			}),
+			errSubstr: "irbMode Asymmetric requires type L3VNI",
+		},
+		{
+			name: "L2VNI with an invalid irbMode",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni": int64(100),
+				"routingDomain": map[string]any{
+					"type":    "L3VNI",
+					"l3vni":   map[string]any{"name": "red"},
+					"irbMode": "Centralized",
+				},
+			}),
+			errSubstr: "spec.routingDomain.irbMode",
+		},
+		{
+			name: "L2VNI evpnAdvertisement without gatewayIPs",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":               int64(100),
+				"evpnAdvertisement": map[string]any{"sviIP": true},
+			}),
+			errSubstr: "evpnAdvertisement requires gatewayIPs",
+		},
+		{
+			name: "L2VNI evpnAdvertisement with both defaultGateway and sviIP",
+			gvk:  l2vniGVK,
+			obj: newUnstructured("L2VNI", map[string]any{
+				"vni":               int64(100),
+				"routingDomain":     map[string]any{"type": "L3VNI", "l3vni": map[string]any{"name": "red"}},
+				"gatewayIPs":        []any{"192.168.10.1/24"},
+				"evpnAdvertisement": map[string]any{"defaultGateway": true, "sviIP": true},
+			}),
+			errSubstr: "defaultGateway and sviIP are mutually exclusive",
+		},
+		{
+			name: "Underlay duplicateAddressDetection disabled with a maxMoves",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"evpn": map[string]any{
+					"duplicateAddressDetection": map[string]any{"enabled": false, "maxMoves": int64(3)},
+				},
+			}),
+			errSubstr: "maxMoves, timeSeconds and freeze can't be set when the detection is disabled",
+		},
+		{
+			name: "Underlay duplicateAddressDetection freeze both permanent and timed",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"evpn": map[string]any{
+					"duplicateAddressDetection": map[string]any{
+						"freeze": map[string]any{"permanent": true, "timeSeconds": int64(60)},
+					},
+				},
+			}),
+			errSubstr: "exactly one of permanent or timeSeconds must be set",
+		},
+		{
+			name: "Underlay duplicateAddressDetection freeze without duration",
+			gvk:  underlayGVK,
+			obj: newUnstructured("Underlay", map[string]any{
+				"asn": int64(65000),
+				"evpn": map[string]any{
+					"duplicateAddressDetection": map[string]any{"freeze": map[string]any{}},
+				},
+			}),
+			errSubstr: "exactly one of permanent or timeSeconds must be set",
+		},
+		{
+			name: "L3VNI aggregate with an invalid prefix",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"aggregates": []any{
+					map[string]any{
+						"prefix":      "10.100.0.0",
+						"summaryOnly": true,
+					},
+				},
+			}),
+			errSubstr: "prefix must be a valid CIDR",
+		},
+		{
+			name: "L3VNI importing its own vrf",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"importVRFs": []any{
+					map[string]any{"vrf": "red"},
+				},
+			}),
+			errSubstr: "a VRF cannot import its own routes",
+		},
+		{
+			name: "L3VNI host session with exportPolicy and onlyDefaultRoute",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "10.0.0.0/30",
+					},
+					"exportPolicy": map[string]any{
+						"rules": []any{map[string]any{"prefix": "10.1.0.0/16"}},
+					},
+					"defaultOriginate": map[string]any{
+						"onlyDefaultRoute": true,
+					},
+				},
+			}),
+			errSubstr: "exportPolicy cannot be set when defaultOriginate.onlyDefaultRoute is true",
+		},
+		{
+			name: "L3VNI host session with invalid default originate condition prefix",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "10.0.0.0/30",
+					},
+					"defaultOriginate": map[string]any{
+						"conditionPrefixes": []any{"10.0.0.1"},
+					},
+				},
+			}),
+			errSubstr: "conditionPrefixes must be valid CIDRs",
+		},
+		{
+			name: "L3VNI with both hostSession and hostSessions",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSession": map[string]any{
+					"asn":     int64(65000),
+					"hostASN": int64(65001),
+					"localCIDR": map[string]any{
+						"ipv4": "10.0.0.0/30",
+					},
+				},
+				"hostSessions": []any{
+					map[string]any{
+						"name":    "metallb",
+						"asn":     int64(65002),
+						"hostASN": int64(65003),
+						"localCIDR": map[string]any{
+							"ipv4": "10.0.1.0/30",
+						},
+					},
+				},
+			}),
+			errSubstr: "hostSession and hostSessions cannot be set together",
+		},
+		{
+			name: "L3VNI host sessions with the same asn and hostASN",
+			gvk:  l3vniGVK,
+			obj: newUnstructured("L3VNI", map[string]any{
+				"vrf": "red",
+				"vni": int64(100),
+				"hostSessions": []any{
+					map[string]any{
+						"name":    "metallb",
+						"asn":     int64(65000),
+						"hostASN": int64(65001),
+						"localCIDR": map[string]any{
+							"ipv4": "10.0.0.0/30",
+						},
+					},
+					map[string]any{
+						"name":    "calico",
+						"asn":     int64(65002),
+						"hostASN": int64(65002),
+						"localCIDR": map[string]any{
+							"ipv4": "10.0.1.0/30",
+						},
+					},
+				},
+			}),
+			errSubstr: "hostASN must be different from asn",
+		},
 	}
 
 	for _, tc := range tests {
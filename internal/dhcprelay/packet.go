@@ -0,0 +1,220 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package dhcprelay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+const (
+	opBootRequest = 1
+	opBootReply   = 2
+
+	// headerLen is the length of the fixed BOOTP header, options excluded.
+	headerLen    = 236
+	htypeOffset  = 1
+	hlenOffset   = 2
+	hopsOffset   = 3
+	flagsOffset  = 10
+	ciaddrOffset = 12
+	yiaddrOffset = 16
+	giaddrOffset = 24
+	chaddrOffset = 28
+
+	// flagBroadcast is the flag set by the clients that can't receive
+	// unicast replies before their address is configured.
+	flagBroadcast = 0x8000
+	// htypeEthernet is the hardware type of the Ethernet clients.
+	htypeEthernet = 1
+
+	// minMessageLen is the minimum length of a BOOTP message, which some
+	// clients still require from the replies.
+	minMessageLen = 300
+	// maxHops is the number of relays a request can cross before being
+	// discarded, as recommended by RFC 1542.
+	maxHops = 16
+
+	optionPad              = 0
+	optionAgentInformation = 82
+	optionEnd              = 255
+
+	subOptionCircuitID = 1
+	subOptionRemoteID  = 2
+	// subOptionLinkSelection carries the subnet of the client, as defined
+	// by RFC 3527, when the relay agent address is not part of it.
+	subOptionLinkSelection = 5
+	// subOptionServerIDOverride carries the address the client sends its
+	// renewals to, as defined by RFC 5107.
+	subOptionServerIDOverride = 11
+
+	// maxOptionLen is the maximum length of the data of an option.
+	maxOptionLen = 255
+)
+
+var magicCookie = []byte{99, 130, 83, 99}
+
+// message is a DHCP message, split into its fixed header, magic cookie
+// included, and its options.
+type message struct {
+	header  []byte
+	options []option
+}
+
+type option struct {
+	code byte
+	data []byte
+}
+
+func parseMessage(b []byte) (*message, error) {
+	if len(b) < headerLen+len(magicCookie) {
+		return nil, fmt.Errorf("message too short: %d bytes", len(b))
+	}
+	if !bytes.Equal(b[headerLen:headerLen+len(magicCookie)], magicCookie) {
+		return nil, errors.New("invalid magic cookie")
+	}
+
+	m := &message{header: bytes.Clone(b[:headerLen+len(magicCookie)])}
+	for i := headerLen + len(magicCookie); i < len(b); {
+		code := b[i]
+		if code == optionEnd {
+			return m, nil
+		}
+		if code == optionPad {
+			i++
+			continue
+		}
+		if i+1 >= len(b) || i+2+int(b[i+1]) > len(b) {
+			return nil, fmt.Errorf("option %d truncated", code)
+		}
+		size := int(b[i+1])
+		m.options = append(m.options, option{code: code, data: bytes.Clone(b[i+2 : i+2+size])})
+		i += 2 + size
+	}
+	return nil, errors.New("missing end option")
+}
+
+func (m *message) marshal() []byte {
+	b := bytes.Clone(m.header)
+	for _, o := range m.options {
+		b = append(b, o.code, byte(len(o.data)))
+		b = append(b, o.data...)
+	}
+	b = append(b, optionEnd)
+	if len(b) < minMessageLen {
+		b = append(b, make([]byte, minMessageLen-len(b))...)
+	}
+	return b
+}
+
+func (m *message) op() byte {
+	return m.header[0]
+}
+
+func (m *message) hops() byte {
+	return m.header[hopsOffset]
+}
+
+func (m *message) setHops(hops byte) {
+	m.header[hopsOffset] = hops
+}
+
+func (m *message) giaddr() net.IP {
+	return net.IP(m.header[giaddrOffset : giaddrOffset+net.IPv4len])
+}
+
+func (m *message) setGiaddr(ip net.IP) {
+	copy(m.header[giaddrOffset:giaddrOffset+net.IPv4len], ip.To4())
+}
+
+func (m *message) broadcast() bool {
+	return binary.BigEndian.Uint16(m.header[flagsOffset:])&flagBroadcast != 0
+}
+
+func (m *message) ciaddr() net.IP {
+	return net.IP(m.header[ciaddrOffset : ciaddrOffset+net.IPv4len])
+}
+
+func (m *message) yiaddr() net.IP {
+	return net.IP(m.header[yiaddrOffset : yiaddrOffset+net.IPv4len])
+}
+
+// chaddr returns the hardware address of an Ethernet client, or nil for
+// the other hardware types.
+func (m *message) chaddr() net.HardwareAddr {
+	if m.header[htypeOffset] != htypeEthernet || m.header[hlenOffset] != 6 {
+		return nil
+	}
+	return net.HardwareAddr(m.header[chaddrOffset : chaddrOffset+6])
+}
+
+func (m *message) hasOption(code byte) bool {
+	for _, o := range m.options {
+		if o.code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *message) removeOption(code byte) {
+	options := m.options[:0]
+	for _, o := range m.options {
+		if o.code != code {
+			options = append(options, o)
+		}
+	}
+	m.options = options
+}
+
+// agentInformation holds the sub-options of the relay agent information
+// option inserted by the relay. The empty ones are not inserted.
+type agentInformation struct {
+	circuitID        string
+	remoteID         string
+	linkSelection    net.IP
+	serverIDOverride net.IP
+}
+
+// marshal returns the sub-options of the relay agent information option.
+func (a agentInformation) marshal() []byte {
+	var data []byte
+	addSubOption := func(code byte, value []byte) {
+		if len(value) > 0 {
+			data = append(data, code, byte(len(value)))
+			data = append(data, value...)
+		}
+	}
+	addSubOption(subOptionCircuitID, []byte(a.circuitID))
+	addSubOption(subOptionRemoteID, []byte(a.remoteID))
+	addSubOption(subOptionLinkSelection, a.linkSelection.To4())
+	addSubOption(subOptionServerIDOverride, a.serverIDOverride.To4())
+	return data
+}
+
+// addAgentInformation appends the relay agent information option, which
+// RFC 3046 requires to be the last one.
+func (m *message) addAgentInformation(info agentInformation) {
+	m.options = append(m.options, option{code: optionAgentInformation, data: info.marshal()})
+}
+
+// extendAgentInformation appends the given sub-options to the relay agent
+// information option of the message, returning false if they don't fit.
+func (m *message) extendAgentInformation(info agentInformation) bool {
+	for i, o := range m.options {
+		if o.code != optionAgentInformation {
+			continue
+		}
+		data := append(bytes.Clone(o.data), info.marshal()...)
+		if len(data) > maxOptionLen {
+			return false
+		}
+		m.options[i].data = data
+		return true
+	}
+	m.addAgentInformation(info)
+	return true
+}
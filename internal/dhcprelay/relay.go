@@ -0,0 +1,379 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package dhcprelay
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	"github.com/openperouter/openperouter/internal/netnamespace"
+)
+
+const (
+	serverPort = 67
+	clientPort = 68
+)
+
+// Option82Policy is the handling of the requests already carrying the relay
+// agent information option.
+type Option82Policy string
+
+const (
+	Option82Keep    Option82Policy = "Keep"
+	Option82Replace Option82Policy = "Replace"
+	Option82Drop    Option82Policy = "Drop"
+)
+
+// Option82 is the relay agent information option inserted in the requests.
+type Option82 struct {
+	CircuitID string         `json:"circuitID,omitempty"`
+	RemoteID  string         `json:"remoteID,omitempty"`
+	Policy    Option82Policy `json:"policy"`
+}
+
+// Relay is the DHCP relay of the gateway interface of an L2VNI.
+type Relay struct {
+	// Name is the name of the L2VNI the relay belongs to.
+	Name     string `json:"name"`
+	TargetNS string `json:"targetns"`
+	// VRF is the VRF the servers are reached through.
+	VRF string `json:"vrf"`
+	// Interface is the gateway interface the requests are received on.
+	Interface string `json:"interface"`
+	// GatewayIP is the anycast IPv4 address of the gateway interface, sent
+	// to the servers as link selection and server identifier override.
+	GatewayIP string `json:"gatewayIP"`
+	// SourceIP is the IPv4 address of the node in the VRF the requests are
+	// relayed from, set as relay agent address of the requests so that the
+	// replies come back to the node.
+	SourceIP string    `json:"sourceIP"`
+	Servers  []string  `json:"servers"`
+	Option82 *Option82 `json:"option82,omitempty"`
+}
+
+// Args returns the command line arguments of the relay process, parsed
+// back by ParseArgs.
+func (r Relay) Args() []string {
+	args := []string{
+		"-name", r.Name,
+		"-netns", r.TargetNS,
+		"-vrf", r.VRF,
+		"-interface", r.Interface,
+		"-gateway-ip", r.GatewayIP,
+		"-source-ip", r.SourceIP,
+		"-servers", strings.Join(r.Servers, ","),
+	}
+	if r.Option82 != nil {
+		args = append(args,
+			"-option82-circuit-id", r.Option82.CircuitID,
+			"-option82-remote-id", r.Option82.RemoteID,
+			"-option82-policy", string(r.Option82.Policy))
+	}
+	return args
+}
+
+// ParseArgs parses the command line arguments of the relay process.
+func ParseArgs(args []string) (Relay, error) {
+	var r Relay
+	var servers, policy, circuitID, remoteID string
+	fs := flag.NewFlagSet("dhcprelay", flag.ContinueOnError)
+	fs.StringVar(&r.Name, "name", "", "name of the L2VNI the relay belongs to")
+	fs.StringVar(&r.TargetNS, "netns", "", "path of the network namespace of the router")
+	fs.StringVar(&r.VRF, "vrf", "", "VRF the servers are reached through")
+	fs.StringVar(&r.Interface, "interface", "", "gateway interface the requests are received on")
+	fs.StringVar(&r.GatewayIP, "gateway-ip", "", "anycast IPv4 address of the gateway interface")
+	fs.StringVar(&r.SourceIP, "source-ip", "", "IPv4 address of the node the requests are relayed from")
+	fs.StringVar(&servers, "servers", "", "comma separated IPv4 addresses of the servers")
+	fs.StringVar(&policy, "option82-policy", "", "handling of the requests already carrying option 82, enables the circuit ID and remote ID sub-options when set")
+	fs.StringVar(&circuitID, "option82-circuit-id", "", "circuit ID sub-option of option 82")
+	fs.StringVar(&remoteID, "option82-remote-id", "", "remote ID sub-option of option 82")
+	if err := fs.Parse(args); err != nil {
+		return Relay{}, err
+	}
+
+	if servers != "" {
+		r.Servers = strings.Split(servers, ",")
+	}
+	if policy != "" {
+		r.Option82 = &Option82{CircuitID: circuitID, RemoteID: remoteID, Policy: Option82Policy(policy)}
+	}
+	if err := r.validate(); err != nil {
+		return Relay{}, err
+	}
+	return r, nil
+}
+
+func (r Relay) validate() error {
+	if r.TargetNS == "" || r.VRF == "" || r.Interface == "" {
+		return errors.New("netns, vrf and interface are required")
+	}
+	if ip := net.ParseIP(r.GatewayIP); ip == nil || ip.To4() == nil {
+		return fmt.Errorf("invalid gateway ip %q", r.GatewayIP)
+	}
+	if ip := net.ParseIP(r.SourceIP); ip == nil || ip.To4() == nil {
+		return fmt.Errorf("invalid source ip %q", r.SourceIP)
+	}
+	if len(r.Servers) == 0 {
+		return errors.New("at least one server is required")
+	}
+	for _, s := range r.Servers {
+		if ip := net.ParseIP(s); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid server %q", s)
+		}
+	}
+	if r.Option82 != nil && !slices.Contains(
+		[]Option82Policy{Option82Keep, Option82Replace, Option82Drop}, r.Option82.Policy) {
+		return fmt.Errorf("invalid option82 policy %q", r.Option82.Policy)
+	}
+	return nil
+}
+
+// Run relays the DHCP messages between the workloads connected to the gateway
+// interface and the servers until the context is cancelled.
+// The requests are received on a socket bound to the gateway interface, and
+// relayed from a socket bound to the source IP in the VRF, where the servers
+// reply to. The gateway IP is shared by all the nodes, so it can't be used
+// as relay agent address: the servers wouldn't know which node to reply to.
+func Run(ctx context.Context, r Relay, logger *slog.Logger) error {
+	ns, err := netns.GetFromPath(r.TargetNS)
+	if err != nil {
+		return fmt.Errorf("failed to find network namespace %s: %w", r.TargetNS, err)
+	}
+	defer func() {
+		if err := ns.Close(); err != nil {
+			logger.Error("failed to close namespace", "namespace", r.TargetNS, "error", err)
+		}
+	}()
+
+	var clientConn, serverConn *net.UDPConn
+	err = netnamespace.In(ns, func() error {
+		var err error
+		clientConn, err = listen(ctx, r.Interface, fmt.Sprintf("0.0.0.0:%d", serverPort))
+		if err != nil {
+			return err
+		}
+		serverConn, err = listen(ctx, r.VRF, net.JoinHostPort(r.SourceIP, fmt.Sprint(serverPort)))
+		if err != nil {
+			_ = clientConn.Close()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+	}()
+
+	nlHandle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return fmt.Errorf("failed to get netlink handle for namespace %s: %w", r.TargetNS, err)
+	}
+	defer nlHandle.Close()
+
+	logger.Info("dhcp relay started", "relay", r)
+	errs := make(chan error, 2)
+	go func() { errs <- relayRequests(r, clientConn, serverConn, logger) }()
+	go func() { errs <- relayReplies(r, serverConn, clientConn, nlHandle, logger) }()
+	err = <-errs
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// listen opens an UDP socket bound to the given device, which in case of a
+// VRF makes the socket route through it.
+func listen(ctx context.Context, device, address string) (*net.UDPConn, error) {
+	lc := net.ListenConfig{
+		Control: func(_, _ string, c syscall.RawConn) error {
+			var sockErr error
+			err := c.Control(func(fd uintptr) {
+				if sockErr = unix.SetsockoptString(int(fd), unix.SOL_SOCKET, unix.SO_BINDTODEVICE, device); sockErr != nil {
+					return
+				}
+				if sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); sockErr != nil {
+					return
+				}
+				sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_BROADCAST, 1)
+			})
+			if err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}
+	conn, err := lc.ListenPacket(ctx, "udp4", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s dev %s: %w", address, device, err)
+	}
+	return conn.(*net.UDPConn), nil
+}
+
+func relayRequests(r Relay, clientConn, serverConn *net.UDPConn, logger *slog.Logger) error {
+	sourceIP := net.ParseIP(r.SourceIP)
+	gatewayIP := net.ParseIP(r.GatewayIP)
+	servers := make([]*net.UDPAddr, 0, len(r.Servers))
+	for _, s := range r.Servers {
+		servers = append(servers, &net.UDPAddr{IP: net.ParseIP(s), Port: serverPort})
+	}
+
+	buf := make([]byte, 65535)
+	for {
+		n, from, err := clientConn.ReadFromUDP(buf)
+		if err != nil {
+			return fmt.Errorf("failed to read request: %w", err)
+		}
+		request, err := parseMessage(buf[:n])
+		if err != nil {
+			logger.Debug("discarding invalid message", "from", from, "error", err)
+			continue
+		}
+		if !relayRequest(request, r, sourceIP, gatewayIP) {
+			logger.Debug("discarding request", "from", from)
+			continue
+		}
+		out := request.marshal()
+		for _, server := range servers {
+			if _, err := serverConn.WriteToUDP(out, server); err != nil {
+				logger.Warn("failed to relay request", "server", server, "error", err)
+			}
+		}
+	}
+}
+
+// relayRequest prepares the request to be relayed to the servers, returning
+// false if it must be discarded instead.
+// The relay agent address is the source IP of the node, not part of the
+// subnet of the workloads, so the subnet is sent as link selection. The
+// gateway IP is sent as server identifier override, for the workloads to
+// renew their leases through the gateway of any node.
+func relayRequest(request *message, r Relay, sourceIP, gatewayIP net.IP) bool {
+	if request.op() != opBootRequest || request.hops() >= maxHops {
+		return false
+	}
+	request.setHops(request.hops() + 1)
+	policy := Option82Keep
+	if r.Option82 != nil {
+		policy = r.Option82.Policy
+	}
+	hasOption82 := request.hasOption(optionAgentInformation)
+	if hasOption82 && policy == Option82Drop {
+		return false
+	}
+	// A request already relayed keeps the address and the relay agent
+	// information of the first relay, which the servers reply to.
+	if !request.giaddr().IsUnspecified() {
+		return true
+	}
+	request.setGiaddr(sourceIP)
+
+	info := agentInformation{linkSelection: gatewayIP, serverIDOverride: gatewayIP}
+	if hasOption82 && policy == Option82Keep {
+		// The option of the workload is kept, completed with the
+		// sub-options the servers need to reply.
+		return request.extendAgentInformation(info)
+	}
+	request.removeOption(optionAgentInformation)
+	if r.Option82 != nil {
+		info.circuitID = r.Option82.CircuitID
+		info.remoteID = r.Option82.RemoteID
+	}
+	request.addAgentInformation(info)
+	return true
+}
+
+func relayReplies(r Relay, serverConn, clientConn *net.UDPConn, nlHandle *netlink.Handle, logger *slog.Logger) error {
+	sourceIP := net.ParseIP(r.SourceIP)
+
+	buf := make([]byte, 65535)
+	for {
+		n, from, err := serverConn.ReadFromUDP(buf)
+		if err != nil {
+			return fmt.Errorf("failed to read reply: %w", err)
+		}
+		reply, err := parseMessage(buf[:n])
+		if err != nil {
+			logger.Debug("discarding invalid message", "from", from, "error", err)
+			continue
+		}
+		if !relayReply(reply, sourceIP) {
+			logger.Debug("discarding reply", "from", from)
+			continue
+		}
+		to, chaddr := replyDestination(reply)
+		if chaddr != nil {
+			if err := addClientNeighbor(nlHandle, r.Interface, to.IP, chaddr); err != nil {
+				logger.Warn("failed to add client neighbor, broadcasting the reply", "ip", to.IP, "mac", chaddr, "error", err)
+				to = &net.UDPAddr{IP: net.IPv4bcast, Port: clientPort}
+			}
+		}
+		if _, err := clientConn.WriteToUDP(reply.marshal(), to); err != nil {
+			logger.Warn("failed to relay reply", "from", from, "to", to, "error", err)
+		}
+	}
+}
+
+// relayReply prepares the reply to be relayed to the workloads, returning
+// false if it must be discarded instead.
+func relayReply(reply *message, sourceIP net.IP) bool {
+	if reply.op() != opBootReply || !reply.giaddr().Equal(sourceIP) {
+		return false
+	}
+	// The relay agent information is inserted in all the requests relayed
+	// from the source IP, and must not reach the workloads.
+	reply.removeOption(optionAgentInformation)
+	return true
+}
+
+// replyDestination returns where the reply is sent to, following RFC 2131
+// section 4.1: broadcast when the workload asked for it, unicast to its
+// current address when renewing, and unicast to the offered address
+// otherwise. In the last case, the returned hardware address of the
+// workload must be added as neighbor first, as the workload can't answer to
+// ARP before configuring the address.
+func replyDestination(reply *message) (*net.UDPAddr, net.HardwareAddr) {
+	broadcast := &net.UDPAddr{IP: net.IPv4bcast, Port: clientPort}
+	if reply.broadcast() {
+		return broadcast, nil
+	}
+	if ciaddr := reply.ciaddr(); !ciaddr.IsUnspecified() {
+		return &net.UDPAddr{IP: slices.Clone(ciaddr), Port: clientPort}, nil
+	}
+	yiaddr, chaddr := reply.yiaddr(), reply.chaddr()
+	if yiaddr.IsUnspecified() || chaddr == nil {
+		return broadcast, nil
+	}
+	return &net.UDPAddr{IP: slices.Clone(yiaddr), Port: clientPort}, slices.Clone(chaddr)
+}
+
+// addClientNeighbor adds the neighbor entry of the workload the reply is
+// unicast to. The entry is stale, so that the kernel probes it as soon as
+// it is used again and drops it if the workload doesn't take the address.
+func addClientNeighbor(nlHandle *netlink.Handle, iface string, ip net.IP, mac net.HardwareAddr) error {
+	link, err := nlHandle.LinkByName(iface)
+	if err != nil {
+		return fmt.Errorf("failed to find interface %s: %w", iface, err)
+	}
+	return nlHandle.NeighSet(&netlink.Neigh{
+		LinkIndex:    link.Attrs().Index,
+		Family:       netlink.FAMILY_V4,
+		State:        netlink.NUD_STALE,
+		IP:           ip,
+		HardwareAddr: mac,
+	})
+}
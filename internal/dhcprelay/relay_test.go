@@ -0,0 +1,274 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package dhcprelay
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestArgsRoundTrip(t *testing.T) {
+	relays := []Relay{
+		{
+			Name:      "l2vni",
+			TargetNS:  "/var/run/netns/perouter",
+			VRF:       "red",
+			Interface: "br-pe-110",
+			GatewayIP: "192.168.10.1",
+			SourceIP:  "10.200.0.1",
+			Servers:   []string{"10.100.0.10", "10.100.0.11"},
+		},
+		{
+			Name:      "l2vni",
+			TargetNS:  "/var/run/netns/perouter",
+			VRF:       "red",
+			Interface: "svi110",
+			GatewayIP: "192.168.10.1",
+			SourceIP:  "10.200.0.1",
+			Servers:   []string{"10.100.0.10"},
+			Option82:  &Option82{CircuitID: "l2vni", Policy: Option82Replace},
+		},
+	}
+	for _, r := range relays {
+		got, err := ParseArgs(r.Args())
+		if err != nil {
+			t.Fatalf("unexpected error parsing %v: %v", r.Args(), err)
+		}
+		if !reflect.DeepEqual(got, r) {
+			t.Fatalf("expecting %+v, got %+v", r, got)
+		}
+	}
+}
+
+// testMessage returns a DHCP message with the given op, relay agent address
+// and options, in wire format.
+func testMessage(op byte, giaddr string, options ...option) []byte {
+	m := &message{header: make([]byte, headerLen+len(magicCookie))}
+	m.header[0] = op
+	copy(m.header[headerLen:], magicCookie)
+	if giaddr != "" {
+		m.setGiaddr(net.ParseIP(giaddr))
+	}
+	m.options = options
+	return m.marshal()
+}
+
+func TestRelayRequest(t *testing.T) {
+	messageType := option{code: 53, data: []byte{1}}
+	// The link selection and the server identifier override sub-options
+	// carrying the gateway IP.
+	subnetSubOptions := []byte{subOptionLinkSelection, 4, 192, 168, 10, 1, subOptionServerIDOverride, 4, 192, 168, 10, 1}
+	relayOption := option{code: optionAgentInformation, data: subnetSubOptions}
+	clientOption82 := option{code: optionAgentInformation, data: []byte{subOptionCircuitID, 6, 'c', 'l', 'i', 'e', 'n', 't'}}
+	extendedClientOption82 := option{code: optionAgentInformation, data: append(bytes.Clone(clientOption82.data), subnetSubOptions...)}
+	relayOption82 := option{code: optionAgentInformation,
+		data: append([]byte{subOptionCircuitID, 2, 'c', '1', subOptionRemoteID, 2, 'r', '1'}, subnetSubOptions...)}
+	fullOption82 := option{code: optionAgentInformation, data: bytes.Repeat([]byte{'x'}, maxOptionLen-4)}
+	withOption82 := func(policy Option82Policy) Relay {
+		return Relay{Option82: &Option82{CircuitID: "c1", RemoteID: "r1", Policy: policy}}
+	}
+
+	tests := []struct {
+		name    string
+		relay   Relay
+		request []byte
+		want    []byte
+	}{
+		{
+			name:    "sets giaddr and the subnet sub-options",
+			request: testMessage(opBootRequest, "", messageType),
+			want:    testMessage(opBootRequest, "10.200.0.1", messageType, relayOption),
+		},
+		{
+			name:    "keeps giaddr and option 82 of the first relay",
+			request: testMessage(opBootRequest, "192.168.99.1", messageType, clientOption82),
+			want:    testMessage(opBootRequest, "192.168.99.1", messageType, clientOption82),
+		},
+		{
+			name:    "discards replies",
+			request: testMessage(opBootReply, "", messageType),
+		},
+		{
+			name:    "completes the option 82 of the request",
+			request: testMessage(opBootRequest, "", clientOption82, messageType),
+			want:    testMessage(opBootRequest, "10.200.0.1", extendedClientOption82, messageType),
+		},
+		{
+			name:    "discards the requests whose option 82 can't be completed",
+			request: testMessage(opBootRequest, "", fullOption82, messageType),
+		},
+		{
+			name:    "inserts option 82",
+			relay:   withOption82(Option82Keep),
+			request: testMessage(opBootRequest, "", messageType),
+			want:    testMessage(opBootRequest, "10.200.0.1", messageType, relayOption82),
+		},
+		{
+			name:    "keeps the option 82 of the request",
+			relay:   withOption82(Option82Keep),
+			request: testMessage(opBootRequest, "", clientOption82, messageType),
+			want:    testMessage(opBootRequest, "10.200.0.1", extendedClientOption82, messageType),
+		},
+		{
+			name:    "replaces the option 82 of the request",
+			relay:   withOption82(Option82Replace),
+			request: testMessage(opBootRequest, "", clientOption82, messageType),
+			want:    testMessage(opBootRequest, "10.200.0.1", messageType, relayOption82),
+		},
+		{
+			name:    "drops the requests with option 82",
+			relay:   withOption82(Option82Drop),
+			request: testMessage(opBootRequest, "", clientOption82, messageType),
+		},
+		{
+			name:    "drops the requests relayed with option 82",
+			relay:   withOption82(Option82Drop),
+			request: testMessage(opBootRequest, "192.168.99.1", clientOption82, messageType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := parseMessage(tc.request)
+			if err != nil {
+				t.Fatalf("failed to parse request: %v", err)
+			}
+			relayed := relayRequest(request, tc.relay, net.ParseIP("10.200.0.1"), net.ParseIP("192.168.10.1"))
+			if relayed != (tc.want != nil) {
+				t.Fatalf("expecting relayed %v, got %v", tc.want != nil, relayed)
+			}
+			if !relayed {
+				return
+			}
+			tc.want[hopsOffset] = 1
+			if got := request.marshal(); !bytes.Equal(got, tc.want) {
+				t.Fatalf("expecting\n%v\ngot\n%v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRelayReply(t *testing.T) {
+	messageType := option{code: 53, data: []byte{2}}
+	option82 := option{code: optionAgentInformation, data: []byte{subOptionCircuitID, 2, 'c', '1'}}
+
+	tests := []struct {
+		name  string
+		reply []byte
+		want  []byte
+	}{
+		{
+			name:  "relays the reply",
+			reply: testMessage(opBootReply, "10.200.0.1", messageType),
+			want:  testMessage(opBootReply, "10.200.0.1", messageType),
+		},
+		{
+			name:  "discards the replies to another relay",
+			reply: testMessage(opBootReply, "10.200.0.2", messageType),
+		},
+		{
+			name:  "discards the replies to the gateway ip",
+			reply: testMessage(opBootReply, "192.168.10.1", messageType),
+		},
+		{
+			name:  "discards requests",
+			reply: testMessage(opBootRequest, "10.200.0.1", messageType),
+		},
+		{
+			name:  "removes option 82",
+			reply: testMessage(opBootReply, "10.200.0.1", messageType, option82),
+			want:  testMessage(opBootReply, "10.200.0.1", messageType),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reply, err := parseMessage(tc.reply)
+			if err != nil {
+				t.Fatalf("failed to parse reply: %v", err)
+			}
+			relayed := relayReply(reply, net.ParseIP("10.200.0.1"))
+			if relayed != (tc.want != nil) {
+				t.Fatalf("expecting relayed %v, got %v", tc.want != nil, relayed)
+			}
+			if !relayed {
+				return
+			}
+			if got := reply.marshal(); !bytes.Equal(got, tc.want) {
+				t.Fatalf("expecting\n%v\ngot\n%v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReplyDestination(t *testing.T) {
+	mac := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	reply := func(broadcast bool, ciaddr, yiaddr string, htype byte) []byte {
+		b := testMessage(opBootReply, "10.200.0.1")
+		if broadcast {
+			b[flagsOffset] = 0x80
+		}
+		if ciaddr != "" {
+			copy(b[ciaddrOffset:], net.ParseIP(ciaddr).To4())
+		}
+		if yiaddr != "" {
+			copy(b[yiaddrOffset:], net.ParseIP(yiaddr).To4())
+		}
+		b[htypeOffset] = htype
+		b[hlenOffset] = 6
+		copy(b[chaddrOffset:], mac)
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		reply      []byte
+		wantIP     string
+		wantChaddr net.HardwareAddr
+	}{
+		{
+			name:   "broadcast flag set",
+			reply:  reply(true, "", "192.168.10.20", htypeEthernet),
+			wantIP: "255.255.255.255",
+		},
+		{
+			name:   "renewal",
+			reply:  reply(false, "192.168.10.20", "192.168.10.20", htypeEthernet),
+			wantIP: "192.168.10.20",
+		},
+		{
+			name:       "unicast to the offered address",
+			reply:      reply(false, "", "192.168.10.20", htypeEthernet),
+			wantIP:     "192.168.10.20",
+			wantChaddr: mac,
+		},
+		{
+			name:   "no offered address",
+			reply:  reply(false, "", "", htypeEthernet),
+			wantIP: "255.255.255.255",
+		},
+		{
+			name:   "non ethernet client",
+			reply:  reply(false, "", "192.168.10.20", 6),
+			wantIP: "255.255.255.255",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := parseMessage(tc.reply)
+			if err != nil {
+				t.Fatalf("failed to parse reply: %v", err)
+			}
+			to, chaddr := replyDestination(m)
+			if !to.IP.Equal(net.ParseIP(tc.wantIP)) || to.Port != clientPort {
+				t.Errorf("expecting destination %s:%d, got %s", tc.wantIP, clientPort, to)
+			}
+			if !bytes.Equal(chaddr, tc.wantChaddr) {
+				t.Errorf("expecting chaddr %s, got %s", tc.wantChaddr, chaddr)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package dhcprelay
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultBinPath is the well-known location of the relay binary inside
+	// the controller container image.
+	DefaultBinPath = "/dhcprelay"
+
+	restartBackoff = time.Second
+	shutdownGrace  = 5 * time.Second
+	// stableAfter is how long a restarted relay must run before its failure
+	// is considered recovered.
+	stableAfter = 10 * time.Second
+)
+
+// Supervisor runs one relay process per L2VNI with a DHCP relay, inside the
+// network namespace of the router. The processes are restarted when they
+// exit unexpectedly, and their failures are returned by Ensure until they
+// recover. The supervisor opts out of leader election so the relays run on
+// every node.
+type Supervisor struct {
+	// Logger receives the relays' stdout/stderr and supervisor events.
+	Logger *slog.Logger
+	// OnStatusChange is called when a relay exits unexpectedly and when it
+	// recovers. Callers typically use it to trigger reconciliation, so that
+	// the failures returned by Ensure are reported.
+	OnStatusChange func()
+
+	binPath string
+	mu      sync.Mutex
+	relays  map[string]*relayProcess
+}
+
+type relayProcess struct {
+	args   []string
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewSupervisor creates a Supervisor. Register it with the manager via
+// mgr.Add() so that the relays are stopped when the manager shuts down.
+func NewSupervisor(logger *slog.Logger) *Supervisor {
+	return &Supervisor{
+		Logger:  logger,
+		binPath: DefaultBinPath,
+		relays:  map[string]*relayProcess{},
+	}
+}
+
+// NeedLeaderElection returns false so the relays run on every node regardless
+// of leader election.
+func (s *Supervisor) NeedLeaderElection() bool { return false }
+
+// Start blocks until ctx is cancelled (manager shutdown), then stops all the
+// relays.
+func (s *Supervisor) Start(ctx context.Context) error {
+	<-ctx.Done()
+	s.Ensure(nil)
+	s.logger().Info("DHCP relay supervisor stopped")
+	return nil
+}
+
+// Ensure makes the running relays match the given ones, restarting the ones
+// whose configuration changed and stopping the ones not listed anymore.
+// It returns the failures of the relays, by name.
+func (s *Supervisor) Ensure(relays []Relay) map[string]error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	desired := map[string][]string{}
+	for _, r := range relays {
+		desired[r.Name] = r.Args()
+	}
+
+	for name, p := range s.relays {
+		if args, ok := desired[name]; ok && slices.Equal(args, p.args) {
+			continue
+		}
+		s.logger().Info("stopping dhcp relay", "name", name)
+		p.stop()
+		delete(s.relays, name)
+	}
+
+	failures := map[string]error{}
+	for name, args := range desired {
+		p, ok := s.relays[name]
+		if !ok {
+			s.logger().Info("starting dhcp relay", "name", name, "args", args)
+			p = s.start(name, args)
+			s.relays[name] = p
+		}
+		if err := p.err(); err != nil {
+			failures[name] = err
+		}
+	}
+	return failures
+}
+
+func (s *Supervisor) start(name string, args []string) *relayProcess {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &relayProcess{args: args, cancel: cancel, done: make(chan struct{})}
+	logger := s.logger().With("relay", name)
+
+	go func() {
+		defer close(p.done)
+		wait.UntilWithContext(
+			ctx,
+			func(ctx context.Context) {
+				err := s.runRelay(ctx, logger, p)
+				if ctx.Err() != nil {
+					return
+				}
+
+				logger.Error("dhcp relay exited, restarting", "error", err, "backoff", restartBackoff)
+				p.setErr(err)
+				s.statusChanged()
+			},
+			restartBackoff,
+		)
+	}()
+	return p
+}
+
+func (s *Supervisor) runRelay(ctx context.Context, logger *slog.Logger, p *relayProcess) error {
+	stderr := &logWriter{logger: logger, level: slog.LevelWarn}
+	cmd := exec.CommandContext(ctx, s.binPath, p.args...)
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+	cmd.WaitDelay = shutdownGrace
+	cmd.Stdout = &logWriter{logger: logger, level: slog.LevelInfo}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start dhcp relay: %w", err)
+	}
+	recovered := time.AfterFunc(stableAfter, func() {
+		if p.setErr(nil) {
+			logger.Info("dhcp relay recovered")
+			s.statusChanged()
+		}
+	})
+	defer recovered.Stop()
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("dhcp relay exited: %w: %s", err, stderr.lastLine)
+	}
+	return fmt.Errorf("dhcp relay exited: %s", stderr.lastLine)
+}
+
+func (s *Supervisor) statusChanged() {
+	if s.OnStatusChange != nil {
+		s.OnStatusChange()
+	}
+}
+
+func (s *Supervisor) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
+	}
+	return slog.Default()
+}
+
+func (p *relayProcess) stop() {
+	p.cancel()
+	<-p.done
+}
+
+func (p *relayProcess) err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastErr
+}
+
+// setErr sets the failure of the relay, returning true if it changed from
+// or to nil.
+func (p *relayProcess) setErr(err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	changed := (p.lastErr == nil) != (err == nil)
+	p.lastErr = err
+	return changed
+}
+
+// logWriter forwards a subprocess's output to slog, one line per log record,
+// and keeps the last line to describe the failures.
+type logWriter struct {
+	logger   *slog.Logger
+	level    slog.Level
+	lastLine string
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	for line := range strings.SplitSeq(strings.TrimRight(string(p), "\n"), "\n") {
+		if line != "" {
+			w.logger.Log(context.Background(), w.level, "DHCP relay", "log", line)
+			w.lastLine = line
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package dhcprelay
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeRelayPath writes a shell script standing for the relay binary, which
+// ignores its arguments and runs the given commands.
+func fakeRelayPath(t *testing.T, commands string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dhcprelay")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+commands+"\n"), 0700); err != nil {
+		t.Fatalf("failed to write fake relay: %v", err)
+	}
+	return path
+}
+
+func newTestSupervisor(binPath string) *Supervisor {
+	s := NewSupervisor(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	s.binPath = binPath
+	return s
+}
+
+var testRelay = Relay{
+	Name:      "l2vni",
+	TargetNS:  "/var/run/netns/perouter",
+	VRF:       "red",
+	Interface: "br-pe-110",
+	GatewayIP: "192.168.10.1",
+	Servers:   []string{"10.100.0.10"},
+}
+
+func TestSupervisorStartsAndStopsRelays(t *testing.T) {
+	sup := newTestSupervisor(fakeRelayPath(t, "exec sleep 60"))
+
+	if failures := sup.Ensure([]Relay{testRelay}); len(failures) != 0 {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+	running := sup.relays[testRelay.Name]
+	if running == nil {
+		t.Fatal("relay not running")
+	}
+
+	if failures := sup.Ensure([]Relay{testRelay}); len(failures) != 0 {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+	if sup.relays[testRelay.Name] != running {
+		t.Fatal("unchanged relay was restarted")
+	}
+
+	changed := testRelay
+	changed.Servers = []string{"10.100.0.11"}
+	sup.Ensure([]Relay{changed})
+	if sup.relays[testRelay.Name] == running {
+		t.Fatal("changed relay was not restarted")
+	}
+	select {
+	case <-running.done:
+	default:
+		t.Fatal("previous relay was not stopped")
+	}
+
+	running = sup.relays[testRelay.Name]
+	sup.Ensure(nil)
+	if len(sup.relays) != 0 {
+		t.Fatalf("expecting no relays, got %d", len(sup.relays))
+	}
+	select {
+	case <-running.done:
+	default:
+		t.Fatal("removed relay was not stopped")
+	}
+}
+
+func TestSupervisorReportsFailures(t *testing.T) {
+	sup := newTestSupervisor(fakeRelayPath(t, "echo 'failed to listen' >&2\nexit 1"))
+	var statusChanges atomic.Int32
+	sup.OnStatusChange = func() { statusChanges.Add(1) }
+	defer sup.Ensure(nil)
+
+	sup.Ensure([]Relay{testRelay})
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		failures := sup.Ensure([]Relay{testRelay})
+		if err := failures[testRelay.Name]; err != nil {
+			if !strings.Contains(err.Error(), "failed to listen") {
+				t.Fatalf("expecting the relay output in the failure, got %v", err)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("relay failure not reported before timeout")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if statusChanges.Load() == 0 {
+		t.Fatal("status change not notified")
+	}
+}
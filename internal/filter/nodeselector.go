@@ -52,6 +52,27 @@ func RawFRRConfigsForNode(node *corev1.Node, rawFRRConfigs []v1alpha1.RawFRRConf
 	})
 }
 
+// NodesForSelector returns the nodes whose labels match the given selector.
+// A nil selector matches all nodes.
+func NodesForSelector(nodes []corev1.Node, nodeSelector *metav1.LabelSelector) ([]corev1.Node, error) {
+	if nodeSelector == nil {
+		return nodes, nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Node
+	for _, node := range nodes {
+		if labelSelector.Matches(labels.Set(node.Labels)) {
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
 // filterForNode is a generic function that filters items based on node label selectors.
 // It takes a selector function that extracts the NodeSelector from each item.
 func filterForNode[T any](node *corev1.Node, items []T, getSelector func(T) *metav1.LabelSelector) ([]T, error) {
@@ -26,14 +26,49 @@ type RawFRRSnippet struct {
 }
 
 type Config struct {
-	Loglevel    string
-	Hostname    string
-	Underlay    UnderlayConfig
-	VNIs        []L3VNIConfig
-	VPNs        []L3VPNConfig
-	Passthrough *PassthroughConfig
-	BFDProfiles []BFDProfile
-	RawConfig   []RawFRRSnippet
+	Loglevel       string
+	Hostname       string
+	Underlay       UnderlayConfig
+	VNIs           []L3VNIConfig
+	VPNs           []L3VPNConfig
+	Passthrough    *PassthroughConfig
+	BFDProfiles    []BFDProfile
+	PrefixLists    []PrefixList
+	CommunityLists []CommunityList
+	RouteMaps      []RouteMap
+	StaticRoutes   []VRFStaticRoutes
+	// RouterAdvertisements are the IPv6 router advertisements sent on the
+	// gateway interfaces of the L2VNIs.
+	RouterAdvertisements []RouterAdvertisement
+	// L2VNIs are the L2VNIs with per-VNI EVPN settings.
+	L2VNIs    []L2VNIConfig
+	RawConfig []RawFRRSnippet
+}
+
+// L2VNIConfig holds the EVPN advertisement flags of an L2VNI, rendered in
+// the evpn address family of the default instance.
+type L2VNIConfig struct {
+	VNI                int32
+	AdvertiseDefaultGW bool
+	AdvertiseSVIIP     bool
+}
+
+// RouterAdvertisement holds the IPv6 router advertisement parameters of an
+// interface. Zero values are not rendered, leaving FRR's defaults.
+type RouterAdvertisement struct {
+	Interface string
+	Interval  int32
+	// Lifetime is the advertised router lifetime, nil for the default.
+	Lifetime   *int32
+	Prefixes   []RouterAdvertisementPrefix
+	DNSServers []string
+}
+
+// RouterAdvertisementPrefix holds the flags of an advertised prefix.
+type RouterAdvertisementPrefix struct {
+	Prefix       string
+	OffLink      bool
+	NoAutoconfig bool
 }
 
 type GracefulRestart struct {
@@ -48,11 +83,59 @@ type UnderlayConfig struct {
 	TunnelEndpoint  *TunnelEndpoint
 	GracefulRestart *GracefulRestart
 	ISIS            *UnderlayISIS
+	OSPF            *UnderlayOSPF
 	SegmentRouting  *UnderlaySegmentRouting
 	RouteReflector  *RouteReflector
+	// AdditionalTunnelEndpoints are the tunnel endpoints of the underlays
+	// sharing the BGP instance, advertised alongside TunnelEndpoint.
+	AdditionalTunnelEndpoints []TunnelEndpoint
 	// ListenLimit caps the number of dynamic sessions accepted via bgp
 	// listen range. When zero, DefaultListenLimit is rendered.
 	ListenLimit uint16
+	// EVPNImportRouteMap is applied to the EVPN routes received from the
+	// neighbors, when set.
+	EVPNImportRouteMap string
+	Multipath          *Multipath
+	// ImportVRFs, when set, leaks the routes of the given VRFs into the
+	// default VRF.
+	ImportVRFs *VRFImports
+	// PIM, when set, runs PIM sparse mode to replicate the BUM traffic of
+	// the VNIs through the underlay multicast groups.
+	PIM *UnderlayPIM
+	// DisableEVPNFlooding stops the head-end replication of the BUM
+	// traffic, when the VNIs send it to a multicast group instead.
+	DisableEVPNFlooding bool
+	// DupAddrDetection, when set, overrides the default EVPN duplicate
+	// address detection parameters.
+	DupAddrDetection *DupAddrDetection
+	// DupAddrClearToken is rendered as a comment, the reloader clears the
+	// duplicate addresses whenever it changes.
+	DupAddrClearToken string
+}
+
+// DupAddrDetection holds the EVPN duplicate address detection parameters.
+type DupAddrDetection struct {
+	Disabled bool
+	MaxMoves int32
+	Time     int32
+	// FreezeTime is the time the duplicate addresses are frozen for, when
+	// not FreezePermanent. Zero means they are not frozen.
+	FreezeTime      int32
+	FreezePermanent bool
+}
+
+// UnderlayPIM holds the PIM sparse mode parameters of the underlay.
+type UnderlayPIM struct {
+	RendezvousPoint string
+	// Interfaces are the interfaces PIM is enabled on.
+	Interfaces []string
+}
+
+// Multipath holds the BGP multipath parameters of a bgp instance.
+type Multipath struct {
+	EBGPPaths   int32
+	IBGPPaths   int32
+	ASPathRelax bool
 }
 
 // DefaultListenLimit raises the FRR default dynamic neighbors cap (100) to
@@ -86,6 +169,29 @@ func (u UnderlayConfig) BGPListenLimit() uint16 {
 	return u.ListenLimit
 }
 
+// TunnelEndpointsIPv4 returns the IPv4 tunnel endpoints to advertise.
+func (u UnderlayConfig) TunnelEndpointsIPv4() []string {
+	return u.tunnelEndpoints(func(t TunnelEndpoint) string { return t.IPv4CIDR })
+}
+
+// TunnelEndpointsIPv6 returns the IPv6 tunnel endpoints to advertise.
+func (u UnderlayConfig) TunnelEndpointsIPv6() []string {
+	return u.tunnelEndpoints(func(t TunnelEndpoint) string { return t.IPv6CIDR })
+}
+
+func (u UnderlayConfig) tunnelEndpoints(cidr func(TunnelEndpoint) string) []string {
+	if u.TunnelEndpoint == nil {
+		return nil
+	}
+	var res []string
+	for _, t := range append([]TunnelEndpoint{*u.TunnelEndpoint}, u.AdditionalTunnelEndpoints...) {
+		if c := cidr(t); c != "" {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
 type TunnelEndpoint struct {
 	IPv4CIDR string
 	IPv6CIDR string
@@ -97,6 +203,39 @@ type UnderlayISIS struct {
 	Level                int32
 	AdvertisePassiveOnly bool
 	Interfaces           []ISISInterface
+
+	AreaPassword   *ISISPassword
+	DomainPassword *ISISPassword
+	Overload       bool
+}
+
+// UnderlayOSPF holds the OSPF parameters of the underlay. OSPFv2 runs when
+// IPv4 is set, OSPFv3 when IPv6 is set.
+type UnderlayOSPF struct {
+	Area       string
+	IPv4       bool
+	IPv6       bool
+	Interfaces []OSPFInterface
+	// Authentication, when set, authenticates the packets sent on the non
+	// passive interfaces.
+	Authentication *OSPFAuthentication
+}
+
+type OSPFInterface struct {
+	Name string
+	// NetworkType is the FRR network type, the default one when empty.
+	NetworkType string
+	// Cost is the cost of the interface, derived from its bandwidth when 0.
+	Cost      int32
+	IsPassive bool
+}
+
+// OSPFAuthentication holds the key the OSPF packets are authenticated with.
+// The key is sent in clear text unless MessageDigest is set.
+type OSPFAuthentication struct {
+	MessageDigest bool
+	KeyID         int32
+	Key           string
 }
 
 type UnderlaySegmentRouting struct {
@@ -128,9 +267,39 @@ type L3VNIConfig struct {
 	LocalNeighbor   *NeighborConfig
 	VRF             string
 	VNI             int32
-	RouterID        string
-	ExportRTs       []string
-	ImportRTs       []string
+	// PrefixRoutesOnly restricts the VNI to the prefix routes, leaving the
+	// routing between the L2VNIs of the VRF to asymmetric IRB.
+	PrefixRoutesOnly bool
+	RouterID         string
+	ExportRTs        []string
+	ImportRTs        []string
+	// ExportRouteMap is applied to the routes advertised as EVPN type 5
+	// routes, when set.
+	ExportRouteMap string
+	Multipath      *Multipath
+	// StaticRoutesRouteMap, when set, redistributes the static routes of
+	// the VRF it permits into the BGP instance.
+	StaticRoutesRouteMap string
+	AggregatesIPv4       []Aggregate
+	AggregatesIPv6       []Aggregate
+	// ImportVRFs, when set, leaks the routes of the given VRFs into the VRF.
+	ImportVRFs *VRFImports
+}
+
+// Aggregate is rendered as an aggregate-address of the address family of
+// the list it belongs to.
+type Aggregate struct {
+	Prefix      string
+	SummaryOnly bool
+	ASSet       bool
+}
+
+// VRFImports are the VRFs whose routes are leaked into a bgp instance.
+// RouteMap, when set, filters the leaked routes of all the VRFs, telling
+// them apart by their source VRF.
+type VRFImports struct {
+	VRFs     []string
+	RouteMap string
 }
 
 type L3VPNConfig struct {
@@ -143,6 +312,33 @@ type L3VPNConfig struct {
 	ExportRTs          []string
 	ImportRTs          []string
 	RouteDistinguisher string
+	// ExportRouteMap and ImportRouteMap are applied to the routes leaked
+	// to and from the VPN, when set.
+	ExportRouteMap string
+	ImportRouteMap string
+	Multipath      *Multipath
+	// StaticRoutesRouteMap, when set, redistributes the static routes of
+	// the VRF it permits into the BGP instance.
+	StaticRoutesRouteMap string
+	AggregatesIPv4       []Aggregate
+	AggregatesIPv6       []Aggregate
+}
+
+// VRFStaticRoutes are the static routes of a VRF.
+type VRFStaticRoutes struct {
+	VRF    string
+	Routes []StaticRoute
+}
+
+// StaticRoute is rendered as "ip route" or "ipv6 route" depending on IPv6.
+type StaticRoute struct {
+	Prefix string
+	IPv6   bool
+	// NextHop is either the IP address of the gateway, the name of the
+	// interface or blackhole.
+	NextHop  string
+	Tag      uint32
+	Distance *int32
 }
 
 type BFDProfile struct {
@@ -154,19 +350,35 @@ type BFDProfile struct {
 	MinimumTTL       *int32
 }
 
+// NeighborGracefulRestart is the graceful restart mode of a neighbor,
+// rendered verbatim as "neighbor X <mode>".
+type NeighborGracefulRestart string
+
+const (
+	NeighborGracefulRestartEnabled  NeighborGracefulRestart = "graceful-restart"
+	NeighborGracefulRestartHelper   NeighborGracefulRestart = "graceful-restart-helper"
+	NeighborGracefulRestartDisabled NeighborGracefulRestart = "graceful-restart-disable"
+)
+
 type NeighborConfig struct {
-	Name                  string
-	ASN                   PeerASN
-	Addr                  string
-	Interface             string
-	ID                    string
-	Port                  *int32
-	HoldTime              *int64
-	KeepaliveTime         *int64
-	ConnectTime           *int64
-	Password              string
-	BFDEnabled            bool
-	BFDProfile            string
+	Name string
+	ASN  PeerASN
+	// LocalASN, when set, is the AS number the session is established
+	// with, in place of the one of the BGP instance.
+	LocalASN      int64
+	Addr          string
+	Interface     string
+	ID            string
+	Port          *int32
+	HoldTime      *int64
+	KeepaliveTime *int64
+	ConnectTime   *int64
+	Password      string
+	BFDEnabled    bool
+	BFDProfile    string
+	// GracefulRestart, when set, overrides the graceful restart mode of
+	// the BGP instance for the neighbor.
+	GracefulRestart       NeighborGracefulRestart
 	EBGPMultiHop          bool
 	EBGPMultiHopTTL       *int32
 	NetworkLayerProtocols []networklayerprotocol.NLP
@@ -180,6 +392,51 @@ type NeighborConfig struct {
 	// with v6 nexthops if you do not have v4 configured on interfaces.
 	ExtendedNexthop bool
 	UpdateSource    string
+	// ImportRouteMap and ExportRouteMap are the names of the route-maps
+	// applied to the routes received from and advertised to the neighbor.
+	ImportRouteMap string
+	ExportRouteMap string
+	// EVPNExportRouteMap, when set, is the name of the route-map applied to
+	// the EVPN routes advertised to the neighbor.
+	EVPNExportRouteMap string
+	// DefaultOriginate, when set, advertises a default route to the neighbor.
+	DefaultOriginate *DefaultOriginate
+}
+
+// DefaultOriginate advertises the default route of the enabled families.
+// RouteMap, when set, makes the advertisement conditional to the presence
+// of a route it permits in the BGP table.
+type DefaultOriginate struct {
+	IPv4     bool
+	IPv6     bool
+	RouteMap string
+}
+
+// SessionASN returns the AS number the session with the neighbor is
+// established with.
+func (n NeighborConfig) SessionASN(routerASN int64) int64 {
+	if n.LocalASN != 0 {
+		return n.LocalASN
+	}
+	return routerASN
+}
+
+// RouteMapIn returns the route-map applied to the routes received from the
+// neighbor, falling back to AllowAllRouteMap when no policy is set.
+func (n NeighborConfig) RouteMapIn() string {
+	if n.ImportRouteMap == "" {
+		return AllowAllRouteMap
+	}
+	return n.ImportRouteMap
+}
+
+// RouteMapOut returns the route-map applied to the routes advertised to the
+// neighbor, falling back to AllowAllRouteMap when no policy is set.
+func (n NeighborConfig) RouteMapOut() string {
+	if n.ExportRouteMap == "" {
+		return AllowAllRouteMap
+	}
+	return n.ExportRouteMap
 }
 
 // ActivateFor tells whether the neighbor activates the given address family.
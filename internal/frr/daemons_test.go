@@ -0,0 +1,48 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frr
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestDaemonsEnabled verifies that every daemons file shipped with the router
+// enables the daemons needed by the sections the FRR template renders, as
+// the configuration of a daemon that is not running is silently dropped.
+func TestDaemonsEnabled(t *testing.T) {
+	daemonsFiles := []string{
+		"../../config/pods/frr-cm.yaml",
+		"../../config/all-in-one/openpe.yaml",
+		"../../config/all-in-one/crio.yaml",
+		"../../charts/openperouter/templates/router.yaml",
+		"../../operator/bindata/deployment/openperouter/templates/router.yaml",
+		"../../systemdmode/frrconfig/daemons",
+	}
+	daemons := []string{"bgpd", "isisd", "ospfd", "ospf6d", "pimd", "bfdd"}
+
+	for _, f := range daemonsFiles {
+		t.Run(strings.TrimPrefix(f, "../../"), func(t *testing.T) {
+			content, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", f, err)
+			}
+			for _, d := range daemons {
+				re := regexp.MustCompile(fmt.Sprintf(`(?m)^\s*%s=(\w+)\s*$`, d))
+				matches := re.FindAllSubmatch(content, -1)
+				if len(matches) == 0 {
+					t.Errorf("%s: %s is not set", f, d)
+					continue
+				}
+				for _, m := range matches {
+					if string(m[1]) != "yes" {
+						t.Errorf("%s: expected %s=yes, got %s=%s", f, d, d, m[1])
+					}
+				}
+			}
+		})
+	}
+}
@@ -918,6 +918,899 @@ func TestPassthroughDual(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
+func TestHostSessionPolicy(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:            mustNewPeerASNFromNumber(64515),
+					Addr:           "192.169.10.1",
+					ID:             "192.169.10.1",
+					ImportRouteMap: "red-hostsession-import",
+					ExportRouteMap: "red-hostsession-export",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+			},
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:            mustNewPeerASNFromNumber(64515),
+					Addr:           "2001:db8:10::1",
+					ID:             "2001:db8:10::1",
+					ImportRouteMap: "red-hostsession-import",
+					ExportRouteMap: "red-hostsession-export",
+				},
+				ToAdvertiseIPv6: []string{
+					"2001:db8:10::1/128",
+				},
+			},
+		},
+		PrefixLists: []PrefixList{
+			{
+				Name: "red-hostsession-import",
+				Entries: []PrefixListEntry{
+					{Seq: 5, Action: "deny", Prefix: "10.100.10.0/24", LE: new(int32(32))},
+					{Seq: 10, Action: "permit", Prefix: "10.100.0.0/16", GE: new(int32(24)), LE: new(int32(28))},
+				},
+			},
+			{
+				Name: "red-hostsession-import",
+				IPv6: true,
+				Entries: []PrefixListEntry{
+					{Seq: 5, Action: "permit", Prefix: "2001:db8:100::/48", GE: new(int32(64))},
+				},
+			},
+			{
+				Name: "red-hostsession-export",
+				Entries: []PrefixListEntry{
+					{Seq: 5, Action: "permit", Prefix: "0.0.0.0/0"},
+				},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name: "red-hostsession-import",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{IPv4PrefixList: "red-hostsession-import"}},
+					{Seq: 20, Action: "permit", Match: RouteMapMatch{IPv6PrefixList: "red-hostsession-import"}},
+				},
+			},
+			{
+				Name: "red-hostsession-export",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{IPv4PrefixList: "red-hostsession-export"}},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestMultipleHostSessions(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64515),
+					Addr: "192.169.10.1",
+					ID:   "192.169.10.1",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+			},
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:      mustNewPeerASNFromNumber(64521),
+					LocalASN: 64520,
+					Addr:     "192.169.11.1",
+					ID:       "192.169.11.1",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.11.1/32",
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestHostSessionOptions(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:             mustNewPeerASNFromNumber(64515),
+					Addr:            "192.169.10.1",
+					ID:              "192.169.10.1",
+					HoldTime:        new(int64(9)),
+					KeepaliveTime:   new(int64(3)),
+					ConnectTime:     new(int64(5)),
+					Password:        "secret",
+					BFDEnabled:      true,
+					BFDProfile:      "red-hostsession",
+					GracefulRestart: NeighborGracefulRestartEnabled,
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+			},
+		},
+		Passthrough: &PassthroughConfig{
+			LocalNeighborV4: &NeighborConfig{
+				ASN:             mustNewPeerASNFromNumber(64516),
+				Addr:            "192.168.1.3",
+				ID:              "192.168.1.3",
+				ConnectTime:     new(int64(5)),
+				BFDEnabled:      true,
+				GracefulRestart: NeighborGracefulRestartDisabled,
+			},
+			ToAdvertiseIPv4: []string{
+				"192.169.20.0/24",
+			},
+		},
+		BFDProfiles: []BFDProfile{
+			{
+				Name:             "red-hostsession",
+				ReceiveInterval:  new(int32(100)),
+				TransmitInterval: new(int32(100)),
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestStaticRoutes(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64515),
+					Addr: "192.169.10.1",
+					ID:   "192.169.10.1",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+				StaticRoutesRouteMap: "red-static",
+			},
+			{
+				VRF:                  "blue",
+				ASN:                  64514,
+				VNI:                  200,
+				RouterID:             "10.0.0.1",
+				StaticRoutesRouteMap: "blue-static",
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name:    "red-static",
+				Entries: []RouteMapEntry{{Seq: 10, Action: "permit", Match: RouteMapMatch{Tag: 1}}},
+			},
+			{
+				Name:    "blue-static",
+				Entries: []RouteMapEntry{{Seq: 10, Action: "permit", Match: RouteMapMatch{Tag: 1}}},
+			},
+		},
+		StaticRoutes: []VRFStaticRoutes{
+			{
+				VRF: "red",
+				Routes: []StaticRoute{
+					{Prefix: "10.100.0.0/16", NextHop: "192.169.10.1", Tag: 1},
+					{Prefix: "10.200.0.0/16", NextHop: "192.169.10.2", Distance: new(int32(200))},
+					{Prefix: "2001:db8:100::/48", IPv6: true, NextHop: "2001:db8::1"},
+				},
+			},
+			{
+				VRF: "blue",
+				Routes: []StaticRoute{
+					{Prefix: "10.0.0.0/8", NextHop: "blackhole", Tag: 1},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestVRFImports(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+			ImportVRFs: &VRFImports{
+				VRFs: []string{"shared"},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64515),
+					Addr: "192.169.10.1",
+					ID:   "192.169.10.1",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+				ImportVRFs: &VRFImports{
+					VRFs:     []string{"shared", "default"},
+					RouteMap: "red-import-vrfs",
+				},
+			},
+			{
+				VRF:      "shared",
+				ASN:      64514,
+				VNI:      200,
+				RouterID: "10.0.0.1",
+				ImportVRFs: &VRFImports{
+					VRFs: []string{"red"},
+				},
+			},
+		},
+		PrefixLists: []PrefixList{
+			{
+				Name:    "red-import-vrfs-default",
+				Entries: []PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "10.0.0.0/8", LE: new(int32(32))}},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name: "red-import-vrfs",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{SourceVRF: "shared"}},
+					{Seq: 20, Action: "permit", Match: RouteMapMatch{SourceVRF: "default", IPv4PrefixList: "red-import-vrfs-default"}},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestHostSessionDefaultOriginate(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:            mustNewPeerASNFromNumber(64515),
+					Addr:           "192.169.10.1",
+					ID:             "192.169.10.1",
+					ExportRouteMap: "red-hostsession-export",
+					DefaultOriginate: &DefaultOriginate{
+						IPv4:     true,
+						IPv6:     true,
+						RouteMap: "red-hostsession-default-originate",
+					},
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+			},
+		},
+		Passthrough: &PassthroughConfig{
+			LocalNeighborV4: &NeighborConfig{
+				ASN:  mustNewPeerASNFromNumber(64516),
+				Addr: "192.168.1.3",
+				ID:   "192.168.1.3",
+				DefaultOriginate: &DefaultOriginate{
+					IPv4: true,
+				},
+			},
+			ToAdvertiseIPv4: []string{
+				"192.169.20.0/24",
+			},
+		},
+		PrefixLists: []PrefixList{
+			{
+				Name:    "red-hostsession-default-originate",
+				Entries: []PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "0.0.0.0/0"}},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name:    "red-hostsession-export",
+				Entries: []RouteMapEntry{{Seq: 10, Action: "deny"}},
+			},
+			{
+				Name: "red-hostsession-default-originate",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{IPv4PrefixList: "red-hostsession-default-originate"}},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestAggregates(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64514,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64515),
+					Addr: "192.169.10.1",
+					ID:   "192.169.10.1",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.1/32",
+				},
+				AggregatesIPv4: []Aggregate{
+					{Prefix: "192.169.10.0/24", SummaryOnly: true},
+				},
+				AggregatesIPv6: []Aggregate{
+					{Prefix: "2001:db8::/64", ASSet: true},
+				},
+			},
+			{
+				VRF:      "blue",
+				ASN:      64514,
+				VNI:      200,
+				RouterID: "10.0.0.1",
+				AggregatesIPv4: []Aggregate{
+					{Prefix: "192.170.1.0/24", SummaryOnly: true, ASSet: true},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestPassthroughPolicy(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64513),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+		},
+		Passthrough: &PassthroughConfig{
+			LocalNeighborV4: &NeighborConfig{
+				ASN:            mustNewPeerASNFromNumber(64513),
+				Addr:           "192.168.1.3",
+				ID:             "192.168.1.3",
+				ConnectTime:    new(int64(5)),
+				ImportRouteMap: "passthrough-import",
+			},
+			ToAdvertiseIPv4: []string{
+				"192.169.20.0/24",
+			},
+		},
+		PrefixLists: []PrefixList{
+			{
+				Name: "passthrough-import",
+				Entries: []PrefixListEntry{
+					{Seq: 5, Action: "permit", Prefix: "192.170.0.0/16", LE: new(int32(24))},
+				},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name: "passthrough-import",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{IPv4PrefixList: "passthrough-import"}},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestVRFCommunities(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+			EVPNImportRouteMap: "evpn-import",
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:            "red",
+				ASN:            64512,
+				VNI:            100,
+				RouterID:       "10.0.0.1",
+				ExportRouteMap: "red-fabric-export",
+			},
+		},
+		PrefixLists: []PrefixList{
+			{
+				Name: "red-fabric-export-1",
+				Entries: []PrefixListEntry{
+					{Seq: 5, Action: "permit", Prefix: "192.168.10.0/24", LE: new(int32(32))},
+				},
+			},
+		},
+		CommunityLists: []CommunityList{
+			{
+				Name:    "red-fabric-import",
+				Type:    StandardCommunityList,
+				Entries: []CommunityListEntry{{Seq: 5, Value: "64512:300"}},
+			},
+			{
+				Name:    "red-fabric-import",
+				Type:    ExtCommunityList,
+				Entries: []CommunityListEntry{{Seq: 5, Value: "soo 64512:1"}},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name: "red-fabric-export",
+				Entries: []RouteMapEntry{
+					{
+						Seq: 10, Action: "permit",
+						Match: RouteMapMatch{IPv4PrefixList: "red-fabric-export-1"},
+						Set: RouteMapSet{
+							Communities:      []string{"64512:100", "no-export"},
+							LargeCommunities: []string{"64512:1:200"},
+						},
+					},
+					{
+						Seq: 20, Action: "permit",
+						Set: RouteMapSet{
+							Communities:     []string{"64512:100"},
+							ExtCommunityRT:  []string{"64512:500"},
+							ExtCommunitySoO: []string{"64512:1"},
+						},
+					},
+				},
+			},
+			{
+				Name: "evpn-import",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{CommunityList: "red-fabric-import", EVPNVNI: 100}},
+					{Seq: 20, Action: "permit", Match: RouteMapMatch{ExtCommunityList: "red-fabric-import", EVPNVNI: 100}},
+					{Seq: 30, Action: "deny", Match: RouteMapMatch{EVPNRouteType: "prefix", EVPNVNI: 100}},
+					{Seq: 65535, Action: "permit"},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestL3VPNCommunities(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "fc00::2:172:31:1:12",
+					ID:   "fc00::2:172:31:1:12",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN},
+						{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN},
+					},
+					ExtendedNexthop: true,
+					UpdateSource:    "fc00::2:172:31:1:32",
+				},
+			},
+			SegmentRouting: &UnderlaySegmentRouting{
+				SourceAddress: "fc00::2:172:31:1:32",
+				Locator: SRV6Locator{
+					Name:     locatorName,
+					Prefix:   "fd00:0:32::/48",
+					BlockLen: 32,
+					NodeLen:  16,
+					Behavior: "usid",
+					Format:   "usid-f3216",
+				},
+				EncapBehavior: HEncaps,
+			},
+		},
+		VPNs: []L3VPNConfig{
+			{
+				ASN:                64512,
+				VRF:                "vrf1",
+				ExportRTs:          []string{"64512:100"},
+				ImportRTs:          []string{"64512:100"},
+				RouteDistinguisher: "10.0.0.1:100",
+				RouterID:           "10.0.0.1",
+				ExportRouteMap:     "vrf1-fabric-export",
+				ImportRouteMap:     "vrf1-fabric-import",
+			},
+		},
+		CommunityLists: []CommunityList{
+			{
+				Name: "vrf1-fabric-import",
+				Type: LargeCommunityList,
+				Entries: []CommunityListEntry{
+					{Seq: 5, Value: "64512:1:300"},
+					{Seq: 10, Value: "64512:1:301"},
+				},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name: "vrf1-fabric-export",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Set: RouteMapSet{LargeCommunities: []string{"64512:1:100"}}},
+				},
+			},
+			{
+				Name: "vrf1-fabric-import",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{LargeCommunityList: "vrf1-fabric-import"}},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestL3VPNAggregates(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "fc00::2:172:31:1:12",
+					ID:   "fc00::2:172:31:1:12",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN},
+						{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN},
+					},
+					ExtendedNexthop: true,
+					UpdateSource:    "fc00::2:172:31:1:32",
+				},
+			},
+			SegmentRouting: &UnderlaySegmentRouting{
+				SourceAddress: "fc00::2:172:31:1:32",
+				Locator: SRV6Locator{
+					Name:     locatorName,
+					Prefix:   "fd00:0:32::/48",
+					BlockLen: 32,
+					NodeLen:  16,
+					Behavior: "usid",
+					Format:   "usid-f3216",
+				},
+				EncapBehavior: HEncaps,
+			},
+		},
+		VPNs: []L3VPNConfig{
+			{
+				ASN:                64512,
+				VRF:                "vrf1",
+				ExportRTs:          []string{"64512:100"},
+				ImportRTs:          []string{"64512:100"},
+				RouteDistinguisher: "10.0.0.1:100",
+				RouterID:           "10.0.0.1",
+				AggregatesIPv4: []Aggregate{
+					{Prefix: "192.169.10.0/24", SummaryOnly: true},
+				},
+				AggregatesIPv6: []Aggregate{
+					{Prefix: "2001:db8::/64"},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestMultipath(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+				{
+					ASN:  mustNewPeerASNFromNumber(64514),
+					Addr: "192.168.2.2",
+					ID:   "192.168.2.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+			Multipath: &Multipath{
+				EBGPPaths:   2,
+				IBGPPaths:   64,
+				ASPathRelax: true,
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				Multipath: &Multipath{
+					EBGPPaths:   8,
+					IBGPPaths:   4,
+					ASPathRelax: true,
+				},
+			},
+			{
+				VRF:      "blue",
+				ASN:      64512,
+				VNI:      200,
+				RouterID: "10.0.0.1",
+				Multipath: &Multipath{
+					EBGPPaths: 64,
+					IBGPPaths: 64,
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
 func TestRawConfig(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
@@ -928,16 +1821,147 @@ func TestRawConfig(t *testing.T) {
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
-					ASN:                   mustNewPeerASNFromNumber(64513),
-					Addr:                  "192.168.1.2",
-					ID:                    "192.168.1.2",
-					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+					ASN:                   mustNewPeerASNFromNumber(64513),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+		},
+		RawConfig: []RawFRRSnippet{
+			{Priority: new(int32(5)), Config: "ip prefix-list raw-low seq 10 permit 10.0.0.0/8"},
+			{Priority: new(int32(20)), Config: "ip prefix-list raw-high seq 10 permit 10.1.0.0/16"},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+// TestTunnelEndpointConfig tests that both IPv4 and IPv6 networks are advertised via FRR.
+func TestTunnelEndpointConfig(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "192.168.10.1/24",
+				IPv6CIDR: "2001:db8:192:168::1/64",
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.2/24",
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestPIM(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.65.0.1/32",
+			},
+			PIM: &UnderlayPIM{
+				RendezvousPoint: "10.0.0.100",
+				Interfaces:      []string{"lo", "ipmr-lo", "eth0"},
+			},
+			DisableEVPNFlooding: true,
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestRouterAdvertisements(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
 				},
 			},
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.65.0.1/32",
+			},
 		},
-		RawConfig: []RawFRRSnippet{
-			{Priority: new(int32(5)), Config: "ip prefix-list raw-low seq 10 permit 10.0.0.0/8"},
-			{Priority: new(int32(20)), Config: "ip prefix-list raw-high seq 10 permit 10.1.0.0/16"},
+		RouterAdvertisements: []RouterAdvertisement{
+			{
+				Interface: "br-pe-110",
+			},
+			{
+				Interface: "br-pe-120",
+				Interval:  30,
+				Lifetime:  new(int32(0)),
+				Prefixes: []RouterAdvertisementPrefix{
+					{Prefix: "2001:db8:120::/64"},
+					{Prefix: "2001:db8:121::/64", OffLink: true, NoAutoconfig: true},
+				},
+				DNSServers: []string{"2001:db8::53", "2001:db8::54"},
+			},
 		},
 	}
 	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
@@ -947,12 +1971,55 @@ func TestRawConfig(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
-// TestTunnelEndpointConfig tests that both IPv4 and IPv6 networks are advertised via FRR.
-func TestTunnelEndpointConfig(t *testing.T) {
+func TestDupAddrDetectionPermanentFreeze(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
 
-	config := Config{
+	config := dupAddrDetectionConfig(&DupAddrDetection{
+		MaxMoves:        10,
+		Time:            600,
+		FreezePermanent: true,
+	})
+	config.Underlay.DupAddrClearToken = "2026-10-17"
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestDupAddrDetectionTimedFreeze(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := dupAddrDetectionConfig(&DupAddrDetection{
+		MaxMoves:   5,
+		Time:       180,
+		FreezeTime: 300,
+	})
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestDupAddrDetectionDisabled(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := dupAddrDetectionConfig(&DupAddrDetection{
+		Disabled: true,
+	})
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func dupAddrDetectionConfig(detection *DupAddrDetection) Config {
+	return Config{
 		Underlay: UnderlayConfig{
 			MyASN:    64512,
 			RouterID: "10.0.0.1",
@@ -968,25 +2035,41 @@ func TestTunnelEndpointConfig(t *testing.T) {
 				},
 			},
 			TunnelEndpoint: &TunnelEndpoint{
-				IPv4CIDR: "192.168.10.1/24",
-				IPv6CIDR: "2001:db8:192:168::1/64",
+				IPv4CIDR: "100.65.0.1/32",
 			},
+			DupAddrDetection: detection,
 		},
-		VNIs: []L3VNIConfig{
-			{
-				VRF:      "red",
-				ASN:      64512,
-				VNI:      100,
-				RouterID: "10.0.0.1",
-				LocalNeighbor: &NeighborConfig{
-					ASN:  mustNewPeerASNFromNumber(64513),
-					Addr: "192.168.1.2",
-					ID:   "192.168.1.2",
-				},
-				ToAdvertiseIPv4: []string{
-					"192.169.10.2/24",
-				},
-			},
+	}
+}
+
+func TestL2VNIEVPNAdvertisement(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := dupAddrDetectionConfig(nil)
+	config.L2VNIs = []L2VNIConfig{
+		{VNI: 110, AdvertiseDefaultGW: true},
+		{VNI: 120, AdvertiseSVIIP: true},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestAsymmetricIRB(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := dupAddrDetectionConfig(nil)
+	config.VNIs = []L3VNIConfig{
+		{
+			VRF:              "red",
+			ASN:              64512,
+			VNI:              100,
+			PrefixRoutesOnly: true,
+			RouterID:         "10.0.0.1",
 		},
 	}
 	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
@@ -1069,6 +2152,135 @@ func TestISISAdvertisePassiveOnly(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
+func TestISISAuthenticationAndBFD(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64512),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+			ISIS: &UnderlayISIS{
+				Net:            MustParseISISNet("49.0001.0002.0003.0004.00"),
+				Name:           isisProcessName,
+				AreaPassword:   &ISISPassword{Password: "areapass"},
+				DomainPassword: &ISISPassword{HMACMD5: true, Password: "domainpass"},
+				Overload:       true,
+				Interfaces: []ISISInterface{
+					{Name: "lo", IPv6: true, IsPassive: true},
+					{
+						Name:            "eth0",
+						IPv6:            true,
+						Metric:          100,
+						HelloInterval:   1,
+						HelloMultiplier: 3,
+						Password:        &ISISPassword{HMACMD5: true, Password: "hellopass"},
+						BFD:             true,
+						BFDProfile:      "isis-eth0",
+					},
+					{Name: "eth1", IPv6: true, BFD: true},
+				},
+			},
+		},
+		BFDProfiles: []BFDProfile{
+			{
+				Name:             "isis-eth0",
+				ReceiveInterval:  new(int32(100)),
+				TransmitInterval: new(int32(100)),
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestOSPF(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64512),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+			OSPF: &UnderlayOSPF{
+				Area: "0.0.0.0",
+				IPv4: true,
+				Interfaces: []OSPFInterface{
+					{Name: "eth0", NetworkType: "point-to-point", Cost: 10},
+					{Name: "eth1", NetworkType: "broadcast"},
+					{Name: "lo", IsPassive: true},
+				},
+				Authentication: &OSPFAuthentication{
+					Key: "s3cret",
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestOSPFDualStackMessageDigest(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64512),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+			OSPF: &UnderlayOSPF{
+				Area: "10",
+				IPv4: true,
+				IPv6: true,
+				Interfaces: []OSPFInterface{
+					{Name: "eth0", NetworkType: "point-to-point"},
+					{Name: "lo", IsPassive: true},
+				},
+				Authentication: &OSPFAuthentication{
+					MessageDigest: true,
+					KeyID:         1,
+					Key:           "s3cret",
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
 func TestSegmentRouting(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
@@ -1689,3 +2901,105 @@ func TestEBGPMultiHopWithTTL(t *testing.T) {
 
 	testCheckConfigFile(t)
 }
+
+func TestMultipleUnderlays(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoint: &TunnelEndpoint{
+				IPv4CIDR: "100.64.0.1/32",
+			},
+			AdditionalTunnelEndpoints: []TunnelEndpoint{
+				{IPv4CIDR: "100.65.0.1/32"},
+			},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+					ExportRouteMap:     "underlay-fabric-a-export",
+					EVPNExportRouteMap: "underlay-fabric-a-evpn-export",
+				},
+				{
+					ASN:      mustNewPeerASNFromNumber(64613),
+					LocalASN: 64612,
+					Addr:     "192.168.2.2",
+					ID:       "192.168.2.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+					ExportRouteMap:     "underlay-fabric-b-export",
+					EVPNExportRouteMap: "underlay-fabric-b-evpn-export",
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+			},
+			{
+				VRF:      "blue",
+				ASN:      64612,
+				VNI:      200,
+				RouterID: "10.0.0.1",
+			},
+		},
+		PrefixLists: []PrefixList{
+			{
+				Name:    "underlay-fabric-a-export",
+				Entries: []PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "100.64.0.1/32"}},
+			},
+			{
+				Name:    "underlay-fabric-b-export",
+				Entries: []PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "100.65.0.1/32"}},
+			},
+		},
+		RouteMaps: []RouteMap{
+			{
+				Name: "underlay-fabric-a-export",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{IPv4PrefixList: "underlay-fabric-a-export"}},
+					{Seq: 65535, Action: "deny"},
+				},
+			},
+			{
+				Name: "underlay-fabric-a-evpn-export",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{EVPNVNI: 100}},
+					{Seq: 65535, Action: "deny"},
+				},
+			},
+			{
+				Name: "underlay-fabric-b-export",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{IPv4PrefixList: "underlay-fabric-b-export"}},
+					{Seq: 65535, Action: "deny"},
+				},
+			},
+			{
+				Name: "underlay-fabric-b-evpn-export",
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "permit", Match: RouteMapMatch{EVPNVNI: 200}},
+					{Seq: 65535, Action: "deny"},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
@@ -16,6 +16,24 @@ type ISISInterface struct {
 	IPv4      bool
 	IPv6      bool
 	IsPassive bool
+
+	// Metric, HelloInterval and HelloMultiplier are left to the FRR
+	// defaults when 0.
+	Metric          int32
+	HelloInterval   int32
+	HelloMultiplier int32
+	Password        *ISISPassword
+	BFD             bool
+	// BFDProfile is the BFD profile of the adjacencies, the default one
+	// when empty.
+	BFDProfile string
+}
+
+// ISISPassword holds the password ISIS PDUs are authenticated with. The
+// password is sent in clear text unless HMACMD5 is set.
+type ISISPassword struct {
+	HMACMD5  bool
+	Password string
 }
 
 // ISISAreaID is the area ID part of an ISIS net address.
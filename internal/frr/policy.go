@@ -0,0 +1,86 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frr
+
+// AllowAllRouteMap is the route-map permitting every route, used by the
+// neighbors that do not have a policy.
+const AllowAllRouteMap = "allowall"
+
+// PrefixList is an ordered list of prefix entries of a single address family.
+type PrefixList struct {
+	Name    string
+	IPv6    bool
+	Entries []PrefixListEntry
+}
+
+type PrefixListEntry struct {
+	Seq    int
+	Action string
+	Prefix string
+	GE     *int32
+	LE     *int32
+}
+
+// CommunityListType is the kind of communities a CommunityList matches,
+// which is also the FRR keyword used to render it.
+type CommunityListType string
+
+const (
+	StandardCommunityList CommunityListType = "community-list"
+	LargeCommunityList    CommunityListType = "large-community-list"
+	ExtCommunityList      CommunityListType = "extcommunity-list"
+)
+
+// CommunityList matches the routes carrying at least one of its values.
+type CommunityList struct {
+	Name    string
+	Type    CommunityListType
+	Entries []CommunityListEntry
+}
+
+type CommunityListEntry struct {
+	Seq int
+	// Value is rendered verbatim, extended communities are prefixed by
+	// their type (e.g. "rt 64512:100").
+	Value string
+}
+
+// RouteMap is an ordered list of entries. A route is handled by the first
+// entry whose match conditions are all satisfied, routes not matching any
+// entry are denied.
+type RouteMap struct {
+	Name    string
+	Entries []RouteMapEntry
+}
+
+type RouteMapEntry struct {
+	Seq    int
+	Action string
+	Match  RouteMapMatch
+	Set    RouteMapSet
+}
+
+// RouteMapMatch holds the match conditions of a route-map entry, the
+// empty ones are not rendered.
+type RouteMapMatch struct {
+	IPv4PrefixList     string
+	IPv6PrefixList     string
+	CommunityList      string
+	LargeCommunityList string
+	ExtCommunityList   string
+	EVPNRouteType      string
+	EVPNVNI            int32
+	Tag                uint32
+	SourceVRF          string
+	// Peer matches the routes received from the given neighbor.
+	Peer string
+}
+
+// RouteMapSet holds the actions applied by a route-map entry to the routes
+// it permits. Communities are added to the ones the route already carries.
+type RouteMapSet struct {
+	Communities      []string
+	LargeCommunities []string
+	ExtCommunityRT   []string
+	ExtCommunitySoO  []string
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frrconfig
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/openperouter/openperouter/internal/frr/vtysh"
+)
+
+// dupAddrClearTokenPrefix prefixes the comment the frr templates render with
+// the token requesting to clear the EVPN duplicate addresses.
+const dupAddrClearTokenPrefix = "! dup-addr-detection clear-token "
+
+// dupAddrClearTokenFileSuffix is appended to the path of the frr
+// configuration to get the file holding the last token applied, so that a
+// restart of the reloader doesn't clear the duplicate addresses again.
+const dupAddrClearTokenFileSuffix = ".dup-addr-clear-token"
+
+// DupAddrClearer clears the EVPN duplicate addresses, including the frozen
+// ones, whenever the token carried by the frr configuration changes.
+type DupAddrClearer struct {
+	cli vtysh.Cli
+}
+
+func NewDupAddrClearer(cli vtysh.Cli) *DupAddrClearer {
+	return &DupAddrClearer{cli: cli}
+}
+
+// ClearOnTokenChange clears the duplicate addresses when the frr configuration
+// at the given path carries a token different from the one last applied,
+// persisted next to the configuration. Clearing is retried on the next call
+// if it fails.
+func (c *DupAddrClearer) ClearOnTokenChange(path string) error {
+	config, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	token := dupAddrClearToken(string(config))
+	tokenPath := path + dupAddrClearTokenFileSuffix
+	lastToken, err := readLastDupAddrClearToken(tokenPath)
+	if err != nil {
+		return err
+	}
+	if token == lastToken {
+		return nil
+	}
+
+	if token != "" {
+		slog.Info("clearing evpn duplicate addresses", "token", token)
+		out, err := c.cli("clear evpn dup-addr vni all")
+		if err != nil {
+			return fmt.Errorf("failed to clear evpn duplicate addresses: %w, output: %s", err, out)
+		}
+	}
+	return writeLastDupAddrClearToken(tokenPath, token)
+}
+
+// readLastDupAddrClearToken returns the token persisted at the given path, or
+// an empty string if none was.
+func readLastDupAddrClearToken(path string) (string, error) {
+	token, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the last dup-addr clear token from %s: %w", path, err)
+	}
+	return string(token), nil
+}
+
+// writeLastDupAddrClearToken persists the given token at the given path,
+// removing the file when the token is empty.
+func writeLastDupAddrClearToken(path, token string) error {
+	if token == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove the last dup-addr clear token %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(token), 0600); err != nil {
+		return fmt.Errorf("failed to persist the last dup-addr clear token to %s: %w", path, err)
+	}
+	return nil
+}
+
+func dupAddrClearToken(config string) string {
+	for line := range strings.Lines(config) {
+		token, found := strings.CutPrefix(strings.TrimSpace(line), dupAddrClearTokenPrefix)
+		if found {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
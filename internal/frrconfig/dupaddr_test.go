@@ -0,0 +1,73 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frrconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDupAddrClearer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frr.conf")
+	withToken := func(token string) string {
+		config := "router bgp 64512\n  address-family l2vpn evpn\n    advertise-all-vni\n"
+		if token != "" {
+			config += "    ! dup-addr-detection clear-token " + token + "\n"
+		}
+		return config + "  exit-address-family\nexit\n"
+	}
+
+	cleared := 0
+	cliFails := false
+	newClearer := func() *DupAddrClearer {
+		return NewDupAddrClearer(func(args string) (string, error) {
+			if args != "clear evpn dup-addr vni all" {
+				t.Fatalf("unexpected vtysh command %q", args)
+			}
+			if cliFails {
+				return "", errors.New("failed")
+			}
+			cleared++
+			return "", nil
+		})
+	}
+	clearer := newClearer()
+
+	steps := []struct {
+		name        string
+		config      string
+		cliFails    bool
+		restart     bool
+		wantCleared int
+		wantErr     bool
+	}{
+		{name: "no token", config: withToken(""), wantCleared: 0},
+		{name: "token set", config: withToken("first"), wantCleared: 1},
+		{name: "same token", config: withToken("first"), wantCleared: 1},
+		{name: "same token after a restart", config: withToken("first"), restart: true, wantCleared: 1},
+		{name: "clear fails", config: withToken("second"), cliFails: true, wantCleared: 1, wantErr: true},
+		{name: "clear retried", config: withToken("second"), wantCleared: 2},
+		{name: "token removed", config: withToken(""), wantCleared: 2},
+		{name: "token set again", config: withToken("second"), wantCleared: 3},
+		{name: "new token after a restart", config: withToken("third"), restart: true, wantCleared: 4},
+	}
+
+	for _, s := range steps {
+		if err := os.WriteFile(path, []byte(s.config), 0600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if s.restart {
+			clearer = newClearer()
+		}
+		cliFails = s.cliFails
+		err := clearer.ClearOnTokenChange(path)
+		if (err != nil) != s.wantErr {
+			t.Fatalf("%s: expecting error %v, got %v", s.name, s.wantErr, err)
+		}
+		if cleared != s.wantCleared {
+			t.Fatalf("%s: expecting %d clears, got %d", s.name, s.wantCleared, cleared)
+		}
+	}
+}
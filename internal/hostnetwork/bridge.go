@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -46,10 +47,15 @@ func setupBridge(params VNIParams, opts ...NetlinkOption) (*netlink.Bridge, erro
 }
 
 func createBridge(name string) (*netlink.Bridge, error) {
-	toCreate := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{
+	return ensureBridge(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{
 		Name: name,
-	}}
+	}})
+}
 
+// ensureBridge returns the bridge named as toCreate, creating it from
+// toCreate if it does not exist.
+func ensureBridge(toCreate *netlink.Bridge) (*netlink.Bridge, error) {
+	name := toCreate.Name
 	link, err := netlink.LinkByName(name)
 	if errors.As(err, &netlink.LinkNotFoundError{}) {
 		if err := netlink.LinkAdd(toCreate); err != nil {
@@ -95,9 +101,20 @@ const (
 
 var macHeader = []byte{0x00, 0xF3}
 
+// ensureBridgeGatewayMacAddress sets the MAC address of the anycast gateway on the bridge,
+// the configured one if any, otherwise one derived from the VNI.
+func ensureBridgeGatewayMacAddress(bridge netlink.Link, params L2VNIParams) error {
+	if params.GatewayMAC == "" {
+		return ensureBridgeFixedMacAddress(bridge, params.VNI)
+	}
+	macAddress, err := net.ParseMAC(params.GatewayMAC)
+	if err != nil {
+		return fmt.Errorf("invalid gateway mac address %q: %w", params.GatewayMAC, err)
+	}
+	return ensureBridgeMacAddress(bridge, macAddress)
+}
+
 // ensureBridgeFixedMacAddress sets a deterministic MAC address on the bridge based on the VNI.
-// It is idempotent: if the MAC is already correct, it skips the update to avoid
-// unnecessary RTM_NEWLINK events that can cause FRR to flush neighbor entries.
 func ensureBridgeFixedMacAddress(bridge netlink.Link, vni int32) error {
 	macAddress := make([]byte, macSize)
 
@@ -109,6 +126,13 @@ func ensureBridgeFixedMacAddress(bridge netlink.Link, vni int32) error {
 	copy(macAddress, macHeader)
 	copy(macAddress[2:], buf.Bytes())
 
+	return ensureBridgeMacAddress(bridge, macAddress)
+}
+
+// ensureBridgeMacAddress sets the given MAC address on the bridge.
+// It is idempotent: if the MAC is already correct, it skips the update to avoid
+// unnecessary RTM_NEWLINK events that can cause FRR to flush neighbor entries.
+func ensureBridgeMacAddress(bridge netlink.Link, macAddress net.HardwareAddr) error {
 	if bytes.Equal(bridge.Attrs().HardwareAddr, macAddress) {
 		return nil
 	}
@@ -96,4 +96,24 @@ var _ = Describe("ensureBridgeFixedMacAddress", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(bridge.Attrs().HardwareAddr).To(Equal(expectedMAC(vni)))
 	})
+
+	It("should set the configured gateway MAC address instead of the derived one", func() {
+		bridge, err := netlink.LinkByName(bridgeName)
+		Expect(err).NotTo(HaveOccurred())
+
+		params := L2VNIParams{VNIParams: VNIParams{VNI: vni}, GatewayMAC: "00:00:5e:00:01:01"}
+		Expect(ensureBridgeGatewayMacAddress(bridge, params)).To(Succeed())
+
+		bridge, err = netlink.LinkByName(bridgeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bridge.Attrs().HardwareAddr.String()).To(Equal("00:00:5e:00:01:01"))
+
+		By("falling back to the derived MAC when the gateway MAC is not set")
+		params.GatewayMAC = ""
+		Expect(ensureBridgeGatewayMacAddress(bridge, params)).To(Succeed())
+
+		bridge, err = netlink.LinkByName(bridgeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bridge.Attrs().HardwareAddr).To(Equal(expectedMAC(vni)))
+	})
 })
@@ -51,7 +51,7 @@ func New(params hostnetwork.L2VNIParams, opts StartOptions) (*BridgeRefresher, e
 	}
 
 	refresher := &BridgeRefresher{
-		bridgeName:    hostnetwork.BridgeName(params.VNI),
+		bridgeName:    hostnetwork.L2GatewayInterfaceName(params),
 		namespace:     params.TargetNS,
 		refreshPeriod: refreshPeriod,
 		vni:           params.VNI,
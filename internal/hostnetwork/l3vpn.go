@@ -25,6 +25,9 @@ type L3VPNParams struct {
 	VRF              string   `json:"vrf"`
 	TargetNS         string   `json:"targetns"`
 	RDAssignedNumber int32    `json:"rdassignednumber"`
+	// AdditionalLinkIPs are the IPs of the veth pairs of the host sessions
+	// following the first one, whose IPs are in LinkIPs.
+	AdditionalLinkIPs []LinkIPs `json:"additional_link_ips,omitempty"`
 }
 
 // SetupL3VPN sets up a Layer 3 VPN in the target namespace.
@@ -39,16 +42,11 @@ func SetupL3VPN(ctx context.Context, params L3VPNParams) error {
 		return fmt.Errorf("SetupL3VPN: failed to setup L3VPN: %w", err)
 	}
 
-	if params.LinkIPs == nil {
-		slog.DebugContext(ctx, "no host veth configured, skipping setup")
-		return nil
-	}
-
-	if err := setupHostVeth(
+	if err := setupHostSessionVeths(
 		ctx,
 		vethNamesFromL3VPN(params.RDAssignedNumber),
 		params.TargetNS,
-		params.LinkIPs,
+		sessionsLinkIPs(params.LinkIPs, params.AdditionalLinkIPs),
 		params.VRF,
 		SRv6Overhead); err != nil {
 		return fmt.Errorf("SetupL3VPN: failed to setup host veth pair: %w", err)
@@ -97,7 +95,9 @@ func RemoveNonConfiguredL3VPNs(targetNS string, params []L3VPNParams) error {
 	if err != nil {
 		return fmt.Errorf("RemoveNonConfiguredL3VPNs: failed to list links: %w", err)
 	}
-	if err := errors.Join(removeHostSideVeths(hostLinks, HostVethPrefix+SRv6Infix, rdAssignedNumbers)...); err != nil {
+	failedDeletes := removeHostSideVeths(hostLinks, HostVethPrefix+SRv6Infix, rdAssignedNumbers)
+	failedDeletes = append(failedDeletes, removeHostSessionVeths(hostLinks, SRv6Infix, rdAssignedNumbers)...)
+	if err := errors.Join(failedDeletes...); err != nil {
 		return fmt.Errorf("RemoveNonConfiguredL3VPNs: failed to remove veths: %w", err)
 	}
 	return nil
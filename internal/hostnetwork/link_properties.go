@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/vishvananda/netlink"
@@ -75,6 +76,25 @@ func AddressesForInterface(ifaceName string, filters ...AddressFilter) ([]netlin
 	return addrs, nil
 }
 
+// removeOtherAddresses removes from the link all the addresses other
+// than the given ones, except for the link local ones.
+func removeOtherAddresses(link netlink.Link, addresses ...string) error {
+	current, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("removeOtherAddresses: failed to list addresses for interface %s: %w", link.Attrs().Name, err)
+	}
+	for _, a := range current {
+		if a.IP.IsLinkLocalUnicast() || slices.Contains(addresses, a.IPNet.String()) {
+			continue
+		}
+		if err := netlink.AddrDel(link, &a); err != nil {
+			return fmt.Errorf("removeOtherAddresses: failed to remove address %s from interface %s: %w",
+				a.IPNet, link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
 // interfaceHasIP tells if the given link has the provided ip.
 func interfaceHasIP(link netlink.Link, address string) (bool, error) {
 	_, err := netlink.ParseAddr(address)
@@ -185,8 +205,41 @@ func linkSetMaster(link, master netlink.Link) error {
 	return netlink.LinkSetMaster(link, master)
 }
 
-// setNeighSuppression sets neighbor suppression to the given link.
-func setNeighSuppression(link netlink.Link) error {
+// bridgePortFlags are the flags of a bridge port that are managed by the router.
+type bridgePortFlags struct {
+	neighSuppression bool
+	proxyARP         bool
+	vlanTunnel       bool
+}
+
+// setBridgePortFlags sets the given flags to the bridge port corresponding to the link,
+// only if they differ from the current ones.
+// This avoids unnecessary RTM_NEWLINK events that can cause FRR to flush neighbor entries.
+func setBridgePortFlags(link netlink.Link, flags bridgePortFlags) error {
+	current, err := netlink.LinkGetProtinfo(link)
+	if err != nil {
+		return fmt.Errorf("failed to get bridge port flags for %s: %w", link.Attrs().Name, err)
+	}
+	if current.NeighSuppress != flags.neighSuppression {
+		if err := setNeighSuppression(link, flags.neighSuppression); err != nil {
+			return fmt.Errorf("failed to set neigh suppression for %s: %w", link.Attrs().Name, err)
+		}
+	}
+	if current.ProxyArp != flags.proxyARP {
+		if err := netlink.LinkSetBrProxyArp(link, flags.proxyARP); err != nil {
+			return fmt.Errorf("failed to set proxy arp for %s: %w", link.Attrs().Name, err)
+		}
+	}
+	if current.VlanTunnel != flags.vlanTunnel {
+		if err := netlink.LinkSetVlanTunnel(link, flags.vlanTunnel); err != nil {
+			return fmt.Errorf("failed to set vlan tunnel for %s: %w", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+// setNeighSuppression enables or disables neighbor suppression on the given link.
+func setNeighSuppression(link netlink.Link, enabled bool) error {
 	req := nl.NewNetlinkRequest(unix.RTM_SETLINK, unix.NLM_F_ACK)
 
 	msg := nl.NewIfInfomsg(unix.AF_BRIDGE)
@@ -198,7 +251,11 @@ func setNeighSuppression(link netlink.Link) error {
 	req.AddData(msg)
 
 	br := nl.NewRtAttr(unix.IFLA_PROTINFO|unix.NLA_F_NESTED, nil)
-	br.AddRtAttr(32, []byte{1})
+	value := []byte{0}
+	if enabled {
+		value = []byte{1}
+	}
+	br.AddRtAttr(32, value)
 	req.AddData(br)
 	_, err = req.Execute(unix.NETLINK_ROUTE, 0)
 	if err != nil {
@@ -207,6 +264,88 @@ func setNeighSuppression(link netlink.Link) error {
 	return nil
 }
 
+// vxlanDF returns the handling of the DF flag of the given VXLan, which the
+// netlink library does not expose.
+func vxlanDF(link netlink.Link) (VXLanDF, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_GETLINK, unix.NLM_F_ACK)
+
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	var err error
+	msg.Index, err = intToInt32(link.Attrs().Index)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index for %s", link.Attrs().Name)
+	}
+	req.AddData(msg)
+
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWLINK)
+	if err != nil {
+		return 0, fmt.Errorf("error executing request: %w", err)
+	}
+	if len(msgs) == 0 {
+		return 0, fmt.Errorf("no link found for %s", link.Attrs().Name)
+	}
+	data, err := nestedRouteAttr(msgs[0][unix.SizeofIfInfomsg:], unix.IFLA_LINKINFO, unix.IFLA_INFO_DATA)
+	if err != nil || data == nil {
+		return 0, err
+	}
+	attrs, err := nl.ParseRouteAttr(data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse vxlan attributes of %s: %w", link.Attrs().Name, err)
+	}
+	for _, attr := range attrs {
+		if attr.Attr.Type == unix.IFLA_VXLAN_DF && len(attr.Value) > 0 {
+			return VXLanDF(attr.Value[0]), nil
+		}
+	}
+	return VXLanDFUnset, nil
+}
+
+// nestedRouteAttr returns the value of the attribute found following the
+// given types through the nested attributes of b, or nil if missing.
+func nestedRouteAttr(b []byte, types ...uint16) ([]byte, error) {
+	for _, t := range types {
+		attrs, err := nl.ParseRouteAttr(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse attributes: %w", err)
+		}
+		b = nil
+		for _, attr := range attrs {
+			if attr.Attr.Type&nl.NLA_TYPE_MASK == t {
+				b = attr.Value
+				break
+			}
+		}
+		if b == nil {
+			return nil, nil
+		}
+	}
+	return b, nil
+}
+
+// setVXLanDF sets the handling of the DF flag of the given VXLan.
+func setVXLanDF(link netlink.Link, df VXLanDF) error {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_ACK)
+
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	var err error
+	msg.Index, err = intToInt32(link.Attrs().Index)
+	if err != nil {
+		return fmt.Errorf("invalid index for %s", link.Attrs().Name)
+	}
+	req.AddData(msg)
+
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated(link.Type()))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	data.AddRtAttr(unix.IFLA_VXLAN_DF, nl.Uint8Attr(uint8(df)))
+	req.AddData(linkInfo)
+	_, err = req.Execute(unix.NETLINK_ROUTE, 0)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	return nil
+}
+
 // moveInterfaceToNamespace takes the given interface and moves it from the given to the given namespace.
 func MoveInterfaceToNamespace(ctx context.Context, intf string, fromHandle, toHandle *netlink.Handle,
 	toNS netns.NsHandle, groupID uint32) error {
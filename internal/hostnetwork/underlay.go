@@ -7,6 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"slices"
+	"strings"
 
 	"github.com/openperouter/openperouter/internal/cniinvoker"
 	"github.com/openperouter/openperouter/internal/netnamespace"
@@ -23,10 +26,18 @@ const (
 // all underlay interfaces by their group membership.
 const UnderlayGroupID = 4242
 
+// underlayAliasPrefix prefixes the name of the underlay an interface belongs
+// to, which is set as alias of the interface. This allows more than one
+// underlay to share the network namespace.
+const underlayAliasPrefix = "openpe-underlay:"
+
 type UnderlayParams struct {
+	// Name is the name of the underlay, marking the interfaces it provisions.
+	Name string `json:"name"`
 	// UnderlayInterfaces are the underlay interfaces to provision: either
-	// host network devices moved into the namespace or CNI-provisioned
-	// interfaces; an underlay uses one mode or the other.
+	// host network devices moved into the namespace, bonds of host network
+	// devices, VLAN sub-interfaces of host network devices or
+	// CNI-provisioned interfaces; an underlay uses one mode only.
 	UnderlayInterfaces []UnderlayInterface           `json:"underlay_interfaces"`
 	TargetNS           string                        `json:"target_ns"`
 	TunnelEndpoint     *UnderlayTunnelEndpointParams `json:"tunnel_endpoint"`
@@ -42,6 +53,15 @@ type UnderlayInterface struct {
 	// CNI holds the CNI provisioning data; set when Kind is
 	// UnderlayInterfaceCNIDev.
 	CNI *CNIDeviceParams `json:"cni,omitempty"`
+	// Bond holds the bond parameters; set when Kind is
+	// UnderlayInterfaceBond.
+	Bond *BondParams `json:"bond,omitempty"`
+	// VLAN holds the VLAN sub-interface parameters; set when Kind is
+	// UnderlayInterfaceVLAN.
+	VLAN *VLANParams `json:"vlan,omitempty"`
+	// Underlay is the name of the underlay the interface belongs to. It is
+	// set on the provisioned interfaces only.
+	Underlay string `json:"underlay,omitempty"`
 }
 
 // CNIDeviceParams holds the data needed to provision an underlay interface
@@ -73,19 +93,24 @@ func SetupUnderlay(ctx context.Context, params UnderlayParams) error {
 		}
 	}()
 
-	// If any existing underlay interfaces were removed from the new list,
-	// clean them up before setting up the new ones: network devices are
-	// restored to the default namespace, CNI-provisioned interfaces are
+	// If any existing interfaces of the underlay were removed from the new
+	// list, clean them up before setting up the new ones: network devices
+	// are restored to the default namespace, CNI-provisioned interfaces are
 	// deleted with a CNI DEL. The caller tears down VNIs beforehand since
 	// they are bound to the old underlay and would be non-functional.
+	// The interfaces not marked with an underlay were provisioned before
+	// the interfaces were marked, and are adopted.
 	existing, err := UnderlayInterfaces(params.TargetNS)
 	if err != nil {
 		return err
 	}
+	existing = slices.DeleteFunc(existing, func(iface UnderlayInterface) bool {
+		return iface.Underlay != "" && iface.Underlay != params.Name
+	})
 	if toRemove := UnderlayInterfacesToRemove(existing, params.UnderlayInterfaces); len(toRemove) > 0 {
 		slog.InfoContext(ctx, "underlay interfaces changed, removing old interfaces before setup",
 			"removed", toRemove, "requested", params.UnderlayInterfaces)
-		if err := RestoreUnderlay(ctx, params.TargetNS, toRemove); err != nil {
+		if err := RemoveUnderlayInterfaces(ctx, params.TargetNS, toRemove); err != nil {
 			return fmt.Errorf("failed to remove old underlay interfaces: %w", err)
 		}
 	}
@@ -100,34 +125,52 @@ func SetupUnderlay(ctx context.Context, params UnderlayParams) error {
 			if err := SetupUnderlayCNIDevInterface(ctx, params.TargetNS, iface); err != nil {
 				return err
 			}
+		case UnderlayInterfaceBond:
+			if err := SetupUnderlayBondInterface(ctx, targetNetNS, iface); err != nil {
+				return err
+			}
+		case UnderlayInterfaceVLAN:
+			if err := SetupUnderlayVLANInterface(ctx, targetNetNS, iface); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("underlay interface %s has unsupported kind %q", iface.InterfaceName, iface.Kind)
 		}
-
+		if err := markUnderlayInterface(targetNetNS, iface.InterfaceName, params.Name); err != nil {
+			return err
+		}
 	}
 
 	if params.TunnelEndpoint == nil {
 		return nil
 	}
 
-	vtepIPs := make([]string, 0, 2)
-	if ip := params.TunnelEndpoint.IPv4CIDR; ip != "" {
-		vtepIPs = append(vtepIPs, ip)
-	}
-	if ip := params.TunnelEndpoint.IPv6CIDR; ip != "" {
-		vtepIPs = append(vtepIPs, ip)
-	}
-	if err := ensureLoopback(ctx, targetNetNS, vtepIPs...); err != nil {
+	if err := ensureLoopback(ctx, targetNetNS, params.vtepIPs()...); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// vtepIPs returns the VTEP addresses the underlay assigns to the loopback.
+func (p UnderlayParams) vtepIPs() []string {
+	if p.TunnelEndpoint == nil {
+		return nil
+	}
+	res := make([]string, 0, 2)
+	if ip := p.TunnelEndpoint.IPv4CIDR; ip != "" {
+		res = append(res, ip)
+	}
+	if ip := p.TunnelEndpoint.IPv6CIDR; ip != "" {
+		res = append(res, ip)
+	}
+	return res
+}
+
 // SetupUnderlayNetDevInterface provisions a single underlay net dev interface
 func SetupUnderlayNetDevInterface(ctx context.Context, ns netns.NsHandle,
 	iface UnderlayInterface) error {
-	if err := moveInterfaceFromDefaultNetns(ctx, ns, iface.InterfaceName); err != nil {
+	if err := moveInterfaceFromDefaultNetns(ctx, ns, iface.InterfaceName, UnderlayGroupID); err != nil {
 		return fmt.Errorf("failed to setup underlay net device %s: %w", iface.InterfaceName, err)
 	}
 	return nil
@@ -167,12 +210,21 @@ const (
 	// UnderlayInterfaceCNIDev is provisioned by a CNI plugin and recorded
 	// in the libcni result cache.
 	UnderlayInterfaceCNIDev UnderlayInterfaceKind = "cnidev"
+	// UnderlayInterfaceBond is a bond created in the namespace and marked
+	// with the underlay group ID, enslaving host network devices moved into
+	// the namespace.
+	UnderlayInterfaceBond UnderlayInterfaceKind = "bond"
+	// UnderlayInterfaceVLAN is a VLAN sub-interface created on a host
+	// network device and moved into the namespace, marked with the underlay
+	// VLAN group ID.
+	UnderlayInterfaceVLAN UnderlayInterfaceKind = "vlan"
 )
 
 // UnderlayInterfaces returns all the underlay interfaces currently
-// provisioned for the given network namespace: the network
-// devices marked with the underlay group ID and the CNI-provisioned
-// interfaces recorded in the libcni result cache (skipped when no invoker is
+// provisioned for the given network namespace: the network devices and the
+// bonds marked with the underlay group ID, the VLAN sub-interfaces marked
+// with the underlay VLAN group ID and the CNI-provisioned interfaces
+// recorded in the libcni result cache (skipped when no invoker is
 // configured).
 func UnderlayInterfaces(namespace string) ([]UnderlayInterface, error) {
 	ns, err := netns.GetFromPath(namespace)
@@ -193,9 +245,28 @@ func underlayInterfaces(ns netns.NsHandle) ([]UnderlayInterface, error) {
 	if err != nil {
 		return nil, err
 	}
+	owners, err := underlayInterfaceOwners(ns)
+	if err != nil {
+		return nil, err
+	}
+	bonds, err := underlayBondNames(ns)
+	if err != nil {
+		return nil, err
+	}
 	res := []UnderlayInterface{}
 	for _, name := range netdevs {
-		res = append(res, UnderlayInterface{InterfaceName: name, Kind: UnderlayInterfaceNetDev})
+		kind := UnderlayInterfaceNetDev
+		if bonds[name] {
+			kind = UnderlayInterfaceBond
+		}
+		res = append(res, UnderlayInterface{InterfaceName: name, Kind: kind, Underlay: owners[name]})
+	}
+	vlans, err := FindInterfacesInGroup(ns, UnderlayVLANGroupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range vlans {
+		res = append(res, UnderlayInterface{InterfaceName: name, Kind: UnderlayInterfaceVLAN, Underlay: owners[name]})
 	}
 	if cniinvoker.Invoker == nil {
 		return res, nil
@@ -205,11 +276,73 @@ func underlayInterfaces(ns netns.NsHandle) ([]UnderlayInterface, error) {
 		return nil, fmt.Errorf("failed to list cni underlay interfaces: %w", err)
 	}
 	for _, name := range cniIfaces {
-		res = append(res, UnderlayInterface{InterfaceName: name, Kind: UnderlayInterfaceCNIDev})
+		res = append(res, UnderlayInterface{InterfaceName: name, Kind: UnderlayInterfaceCNIDev, Underlay: owners[name]})
 	}
 	return res, nil
 }
 
+// underlayInterfaceOwners returns the name of the underlay each marked
+// interface of the namespace belongs to, by interface name.
+func underlayInterfaceOwners(ns netns.NsHandle) (map[string]string, error) {
+	owners := map[string]string{}
+	err := netnamespace.In(ns, func() error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return fmt.Errorf("failed to list links: %w", err)
+		}
+		for _, l := range links {
+			if owner, ok := strings.CutPrefix(l.Attrs().Alias, underlayAliasPrefix); ok {
+				owners[l.Attrs().Name] = owner
+			}
+		}
+		return nil
+	})
+	return owners, err
+}
+
+// markUnderlayInterface sets the alias of the interface to the name of the
+// underlay it belongs to.
+func markUnderlayInterface(ns netns.NsHandle, name, underlay string) error {
+	alias := ""
+	if underlay != "" {
+		alias = underlayAliasPrefix + underlay
+	}
+	return netnamespace.In(ns, func() error {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return fmt.Errorf("failed to find underlay interface %s: %w", name, err)
+		}
+		current := link.Attrs().Alias
+		if current == alias || (alias == "" && !strings.HasPrefix(current, underlayAliasPrefix)) {
+			return nil
+		}
+		if err := netlink.LinkSetAlias(link, alias); err != nil {
+			return fmt.Errorf("failed to mark underlay interface %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// setUnderlayInterfaceAddresses assigns the given addresses to the underlay
+// interface created by the router, removing the ones not listed anymore.
+func setUnderlayInterfaceAddresses(ns netns.NsHandle, name string, addresses []string) error {
+	return netnamespace.In(ns, func() error {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return fmt.Errorf("failed to find underlay interface %s: %w", name, err)
+		}
+		if err := removeOtherAddresses(link, addresses...); err != nil {
+			return err
+		}
+		for _, address := range addresses {
+			if err := AssignIPToInterface(link, address); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // UnderlayInterfacesToRemove returns the existing underlay interfaces that
 // are not requested anymore, preserving how they were provisioned. An
 // interface whose kind changed is returned too, so it is torn down according
@@ -264,13 +397,84 @@ func ensureLoopback(ctx context.Context, ns netns.NsHandle, vtepIPs ...string) e
 //     (`/var/run/netns/perouter`).
 //   - it moves the interfaces to remove that are identified by the groupID marker from the aforementioned
 //     namespace back to the default network namespace.
+//   - it deletes the bonds to remove, moving their members back to the default network namespace.
+//   - it deletes the VLAN sub-interfaces to remove.
 func RestoreUnderlay(ctx context.Context, fromNetNSPath string, ifacesToRemove []UnderlayInterface) error {
+	return restoreUnderlayInterfaces(ctx, fromNetNSPath, ifacesToRemove, true)
+}
+
+// RemoveUnderlayInterfaces moves the given network devices back to the
+// default network namespace and deletes the given bonds, moving their
+// members back, the given VLAN sub-interfaces and the given CNI-provisioned
+// interfaces, leaving the loopback of the namespace untouched as other
+// underlays may still use it.
+func RemoveUnderlayInterfaces(ctx context.Context, fromNetNSPath string, ifacesToRemove []UnderlayInterface) error {
+	return restoreUnderlayInterfaces(ctx, fromNetNSPath, ifacesToRemove, false)
+}
+
+// RemoveNonConfiguredUnderlays removes the interfaces of the underlays not
+// among the given ones, and their VTEP addresses from the loopback.
+func RemoveNonConfiguredUnderlays(ctx context.Context, targetNS string, underlays []UnderlayParams) error {
+	existing, err := UnderlayInterfaces(targetNS)
+	if err != nil {
+		return err
+	}
+	configured := map[string]bool{}
+	for _, u := range underlays {
+		configured[u.Name] = true
+	}
+	toRemove := slices.DeleteFunc(existing, func(iface UnderlayInterface) bool {
+		return iface.Underlay == "" || configured[iface.Underlay]
+	})
+	if len(toRemove) > 0 {
+		slog.InfoContext(ctx, "removing interfaces of deleted underlays", "removed", toRemove)
+		if err := RemoveUnderlayInterfaces(ctx, targetNS, toRemove); err != nil {
+			return err
+		}
+	}
+
+	// Only the VTEP addresses are assigned to the loopback, so the ones of
+	// the deleted underlays are those not assigned by the configured ones.
+	// They are cleared even when no interface is left to remove, as the
+	// underlay may have lost its interfaces before being deleted.
+	var configuredVTEPIPs []string
+	for _, u := range underlays {
+		configuredVTEPIPs = append(configuredVTEPIPs, u.vtepIPs()...)
+	}
+	ns, err := netns.GetFromPath(targetNS)
+	if err != nil {
+		return fmt.Errorf("failed to find network namespace %s: %w", targetNS, err)
+	}
+	defer func() {
+		if err := ns.Close(); err != nil {
+			slog.Error("failed to close namespace", "namespace", targetNS, "error", err)
+		}
+	}()
+	nsHandle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return fmt.Errorf("failed to get netlink handle for namespace %s: %w", targetNS, err)
+	}
+	defer nsHandle.Close()
+	if err := clearNonDefaultLoopbackIPs(nsHandle, loopbackName, configuredVTEPIPs...); err != nil {
+		return fmt.Errorf("failed to clear the VTEP addresses of deleted underlays from %s: %w", loopbackName, err)
+	}
+	return nil
+}
+
+func restoreUnderlayInterfaces(ctx context.Context, fromNetNSPath string, ifacesToRemove []UnderlayInterface,
+	clearLoopback bool) error {
 	// index the interfaces to remove by name for the link list lookup
 	toMoveByName := map[string]UnderlayInterface{}
+	bondsToDelete := map[string]bool{}
+	vlansToDelete := map[string]bool{}
 	for _, ifaceToRemove := range ifacesToRemove {
 		switch ifaceToRemove.Kind {
 		case UnderlayInterfaceNetDev:
 			toMoveByName[ifaceToRemove.InterfaceName] = ifaceToRemove
+		case UnderlayInterfaceBond:
+			bondsToDelete[ifaceToRemove.InterfaceName] = true
+		case UnderlayInterfaceVLAN:
+			vlansToDelete[ifaceToRemove.InterfaceName] = true
 		case UnderlayInterfaceCNIDev:
 			if err := cniinvoker.Invoker.Del(ctx, ifaceToRemove.InterfaceName); err != nil {
 				return fmt.Errorf("failed to delete cni underlay interfaces %q: %w", ifaceToRemove.InterfaceName, err)
@@ -279,9 +483,24 @@ func RestoreUnderlay(ctx context.Context, fromNetNSPath string, ifacesToRemove [
 	}
 	restoreUnderlay := func(ctx context.Context, fromNetNSHandle, defaultNetNSHandle *netlink.Handle,
 		defaultNetNS netns.NsHandle) error {
-		if err := clearNonDefaultLoopbackIPs(fromNetNSHandle, loopbackName); err != nil {
-			return fmt.Errorf("RestoreUnderlay: failed to clear non default loopback IPs from interface %s, err: %w",
-				loopbackName, err)
+		if clearLoopback {
+			if err := clearNonDefaultLoopbackIPs(fromNetNSHandle, loopbackName); err != nil {
+				return fmt.Errorf("RestoreUnderlay: failed to clear non default loopback IPs from interface %s, err: %w",
+					loopbackName, err)
+			}
+		}
+
+		var errs []error
+		if len(bondsToDelete) > 0 {
+			if err := restoreUnderlayBonds(ctx, bondsToDelete, fromNetNSHandle, defaultNetNSHandle,
+				defaultNetNS); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(vlansToDelete) > 0 {
+			if err := deleteUnderlayVLANs(vlansToDelete, fromNetNSHandle); err != nil {
+				errs = append(errs, err)
+			}
 		}
 
 		links, err := fromNetNSHandle.LinkList()
@@ -289,12 +508,17 @@ func RestoreUnderlay(ctx context.Context, fromNetNSPath string, ifacesToRemove [
 			return fmt.Errorf("failed to list links: %w", err)
 		}
 
-		var errs []error
 		for _, l := range links {
 			_, found := toMoveByName[l.Attrs().Name]
 			if !found {
 				continue
 			}
+			if strings.HasPrefix(l.Attrs().Alias, underlayAliasPrefix) {
+				if err := fromNetNSHandle.LinkSetAlias(l, ""); err != nil {
+					errs = append(errs, fmt.Errorf("failed to unmark underlay interface %s: %w", l.Attrs().Name, err))
+					continue
+				}
+			}
 			if err = MoveInterfaceToNamespace(ctx, l.Attrs().Name, fromNetNSHandle, defaultNetNSHandle, defaultNetNS,
 				0); err != nil {
 				errs = append(errs, err)
@@ -403,7 +627,18 @@ func findUnderlayMTU(ns netns.NsHandle) (int, error) {
 	return minMTU, nil
 }
 
-func clearNonDefaultLoopbackIPs(nsHandle *netlink.Handle, intf string) error {
+// clearNonDefaultLoopbackIPs removes the addresses of the given interface
+// other than the loopback ones and the ones to keep.
+func clearNonDefaultLoopbackIPs(nsHandle *netlink.Handle, intf string, keep ...string) error {
+	keepIPs := make([]net.IP, 0, len(keep))
+	for _, k := range keep {
+		ip, _, err := net.ParseCIDR(k)
+		if err != nil {
+			return fmt.Errorf("failed to parse address %s: %w", k, err)
+		}
+		keepIPs = append(keepIPs, ip)
+	}
+
 	lo, err := nsHandle.LinkByName(intf)
 	if err != nil {
 		return fmt.Errorf("failed to find %s: %w", intf, err)
@@ -416,7 +651,7 @@ func clearNonDefaultLoopbackIPs(nsHandle *netlink.Handle, intf string) error {
 
 	var errs []error
 	for _, address := range addresses {
-		if address.IP.IsLoopback() {
+		if address.IP.IsLoopback() || slices.ContainsFunc(keepIPs, address.IP.Equal) {
 			continue
 		}
 		if err := nsHandle.AddrDel(lo, &address); err != nil {
@@ -427,9 +662,9 @@ func clearNonDefaultLoopbackIPs(nsHandle *netlink.Handle, intf string) error {
 }
 
 // moveDefaultNamespaceInterface moves the host network device into the
-// namespace, marking it with the underlay group ID. It is idempotent: a
+// namespace, marking it with the given group ID. It is idempotent: a
 // device already in place is left untouched.
-func moveInterfaceFromDefaultNetns(ctx context.Context, ns netns.NsHandle, name string) error {
+func moveInterfaceFromDefaultNetns(ctx context.Context, ns netns.NsHandle, name string, groupID uint32) error {
 	defaultNetNS, err := netns.Get()
 	if err != nil {
 		return fmt.Errorf("setupNetworkDeviceInterface: failed to get netns handle for default namespace: %w", err)
@@ -451,5 +686,5 @@ func moveInterfaceFromDefaultNetns(ctx context.Context, ns netns.NsHandle, name
 	}
 	defer defaultNetNSHandle.Close()
 
-	return MoveInterfaceToNamespace(ctx, name, defaultNetNSHandle, nsHandle, ns, UnderlayGroupID)
+	return MoveInterfaceToNamespace(ctx, name, defaultNetNSHandle, nsHandle, ns, groupID)
 }
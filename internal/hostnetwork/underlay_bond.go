@@ -0,0 +1,278 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/openperouter/openperouter/internal/netnamespace"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// UnderlayBondMemberGroupID is the link group ID assigned to the members of
+// the underlay bonds moved into the network namespace, so that they are not
+// mistaken for underlay interfaces.
+const UnderlayBondMemberGroupID = 4243
+
+// BondParams holds the data needed to create an underlay bond.
+type BondParams struct {
+	// Members are the host network devices enslaved to the bond.
+	Members []string `json:"members"`
+	// Mode is the bonding mode, as named by the kernel (i.e. "802.3ad").
+	Mode string `json:"mode"`
+	// MIIMon is the link monitoring interval, in milliseconds.
+	MIIMon int `json:"miimon"`
+	// LACPRate is the rate of the LACPDUs of the 802.3ad mode, "slow" or
+	// "fast". The kernel default is used when empty.
+	LACPRate string `json:"lacp_rate,omitempty"`
+	// XmitHashPolicy is the hash policy selecting the member to transmit
+	// on (i.e. "layer3+4"). The kernel default is used when empty.
+	XmitHashPolicy string `json:"xmit_hash_policy,omitempty"`
+	// Addresses are the addresses assigned to the bond.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// SetupUnderlayBondInterface provisions a single underlay bond: the members
+// are moved from the default namespace and enslaved to the bond, created in
+// the namespace, which gets the addresses. A bond whose parameters changed
+// is recreated, and the members not listed anymore are moved back to the
+// default namespace.
+func SetupUnderlayBondInterface(ctx context.Context, ns netns.NsHandle,
+	iface UnderlayInterface) error {
+	if iface.Bond == nil {
+		return fmt.Errorf("bond parameters are missing for underlay interface %s", iface.InterfaceName)
+	}
+	for _, member := range iface.Bond.Members {
+		if err := moveInterfaceFromDefaultNetns(ctx, ns, member, UnderlayBondMemberGroupID); err != nil {
+			return fmt.Errorf("failed to move member %s of underlay bond %s: %w", member, iface.InterfaceName, err)
+		}
+	}
+
+	var staleMembers []string
+	if err := netnamespace.In(ns, func() error {
+		bond, err := ensureBond(iface.InterfaceName, *iface.Bond)
+		if err != nil {
+			return err
+		}
+		for _, member := range iface.Bond.Members {
+			if err := enslaveToBond(member, bond); err != nil {
+				return err
+			}
+		}
+		staleMembers, err = releaseStaleBondMembers(bond, iface.Bond.Members)
+		if err != nil {
+			return err
+		}
+		return linkSetUp(bond)
+	}); err != nil {
+		return fmt.Errorf("failed to setup underlay bond %s: %w", iface.InterfaceName, err)
+	}
+	if err := setUnderlayInterfaceAddresses(ns, iface.InterfaceName, iface.Bond.Addresses); err != nil {
+		return fmt.Errorf("failed to setup underlay bond %s: %w", iface.InterfaceName, err)
+	}
+
+	if len(staleMembers) == 0 {
+		return nil
+	}
+	slog.InfoContext(ctx, "moving members removed from underlay bond back to the default namespace",
+		"bond", iface.InterfaceName, "members", staleMembers)
+	return moveInterfacesToDefaultNetns(ctx, ns, staleMembers)
+}
+
+// ensureBond returns the bond with the given name and parameters, creating
+// it if it does not exist and recreating it if its parameters differ. It
+// must be called inside the namespace of the bond.
+func ensureBond(name string, params BondParams) (*netlink.Bond, error) {
+	toCreate := netlink.NewLinkBond(netlink.LinkAttrs{Name: name, Group: UnderlayGroupID})
+	toCreate.Mode = netlink.StringToBondMode(params.Mode)
+	if toCreate.Mode == netlink.BOND_MODE_UNKNOWN {
+		return nil, fmt.Errorf("invalid bond mode %q", params.Mode)
+	}
+	toCreate.Miimon = params.MIIMon
+	if params.LACPRate != "" {
+		toCreate.LacpRate = netlink.StringToBondLacpRate(params.LACPRate)
+		if toCreate.LacpRate == netlink.BOND_LACP_RATE_UNKNOWN {
+			return nil, fmt.Errorf("invalid bond lacp rate %q", params.LACPRate)
+		}
+	}
+	if params.XmitHashPolicy != "" {
+		toCreate.XmitHashPolicy = netlink.StringToBondXmitHashPolicy(params.XmitHashPolicy)
+		if toCreate.XmitHashPolicy == netlink.BOND_XMIT_HASH_POLICY_UNKNOWN {
+			return nil, fmt.Errorf("invalid bond xmit hash policy %q", params.XmitHashPolicy)
+		}
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil, fmt.Errorf("could not find bond by name %s: %w", name, err)
+	}
+	if err == nil {
+		bond, ok := link.(*netlink.Bond)
+		if ok && bondMatches(bond, toCreate) {
+			return bond, nil
+		}
+		// The mode of a bond can't be changed while it has members, delete
+		// it and recreate it: its members are released and stay in the
+		// namespace.
+		if err := netlink.LinkDel(link); err != nil {
+			return nil, fmt.Errorf("failed to delete link %s: %w", name, err)
+		}
+	}
+
+	if err := netlink.LinkAdd(toCreate); err != nil {
+		return nil, fmt.Errorf("could not create bond %s: %w", name, err)
+	}
+	link, err = netlink.LinkByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not find bond by name %s: %w", name, err)
+	}
+	bond, ok := link.(*netlink.Bond)
+	if !ok {
+		return nil, fmt.Errorf("link %s is not a bond", name)
+	}
+	return bond, nil
+}
+
+// bondMatches tells if the existing bond has the parameters of the wanted
+// one. The parameters left to the kernel default are not compared.
+func bondMatches(existing, wanted *netlink.Bond) bool {
+	if existing.Mode != wanted.Mode || existing.Miimon != wanted.Miimon {
+		return false
+	}
+	if wanted.LacpRate >= 0 && existing.LacpRate != wanted.LacpRate {
+		return false
+	}
+	if wanted.XmitHashPolicy >= 0 && existing.XmitHashPolicy != wanted.XmitHashPolicy {
+		return false
+	}
+	return true
+}
+
+// enslaveToBond enslaves the member to the bond, if not already, and sets it
+// up. The member must be down to be enslaved.
+func enslaveToBond(member string, bond *netlink.Bond) error {
+	link, err := netlink.LinkByName(member)
+	if err != nil {
+		return fmt.Errorf("could not find bond member %s: %w", member, err)
+	}
+	if link.Attrs().MasterIndex != bond.Index {
+		if err := netlink.LinkSetDown(link); err != nil {
+			return fmt.Errorf("failed to set bond member %s down: %w", member, err)
+		}
+		if err := netlink.LinkSetMaster(link, bond); err != nil {
+			return fmt.Errorf("failed to enslave %s to bond %s: %w", member, bond.Name, err)
+		}
+	}
+	return linkSetUp(link)
+}
+
+// releaseStaleBondMembers releases the members of the bond that are not
+// among the given ones, returning their names.
+func releaseStaleBondMembers(bond *netlink.Bond, members []string) ([]string, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+	var stale []string
+	for _, l := range links {
+		if l.Attrs().MasterIndex != bond.Index || slices.Contains(members, l.Attrs().Name) {
+			continue
+		}
+		if err := netlink.LinkSetNoMaster(l); err != nil {
+			return nil, fmt.Errorf("failed to release %s from bond %s: %w", l.Attrs().Name, bond.Name, err)
+		}
+		stale = append(stale, l.Attrs().Name)
+	}
+	return stale, nil
+}
+
+// underlayBondNames returns the names of the bonds of the namespace.
+func underlayBondNames(ns netns.NsHandle) (map[string]bool, error) {
+	bonds := map[string]bool{}
+	err := netnamespace.In(ns, func() error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return fmt.Errorf("failed to list links: %w", err)
+		}
+		for _, l := range links {
+			if _, ok := l.(*netlink.Bond); ok {
+				bonds[l.Attrs().Name] = true
+			}
+		}
+		return nil
+	})
+	return bonds, err
+}
+
+// restoreUnderlayBonds deletes the given bonds and moves their members back
+// to the default network namespace. The members left without a bond, i.e.
+// because the bond was deleted, are moved back too.
+func restoreUnderlayBonds(ctx context.Context, bonds map[string]bool, fromNetNSHandle,
+	defaultNetNSHandle *netlink.Handle, defaultNetNS netns.NsHandle) error {
+	links, err := fromNetNSHandle.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	bondIndexes := map[int]bool{}
+	var errs []error
+	for _, l := range links {
+		if !bonds[l.Attrs().Name] {
+			continue
+		}
+		bondIndexes[l.Attrs().Index] = true
+		if err := fromNetNSHandle.LinkDel(l); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete underlay bond %s: %w", l.Attrs().Name, err))
+		}
+	}
+	for _, l := range links {
+		if l.Attrs().Group != UnderlayBondMemberGroupID {
+			continue
+		}
+		if l.Attrs().MasterIndex != 0 && !bondIndexes[l.Attrs().MasterIndex] {
+			continue
+		}
+		if err := MoveInterfaceToNamespace(ctx, l.Attrs().Name, fromNetNSHandle, defaultNetNSHandle, defaultNetNS,
+			0); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// moveInterfacesToDefaultNetns moves the given interfaces of the namespace
+// back to the default network namespace.
+func moveInterfacesToDefaultNetns(ctx context.Context, ns netns.NsHandle, names []string) error {
+	defaultNetNS, err := netns.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get netns handle for default namespace: %w", err)
+	}
+	defer func() {
+		if err := defaultNetNS.Close(); err != nil {
+			slog.Error("failed to close default namespace", "error", err)
+		}
+	}()
+
+	nsHandle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return fmt.Errorf("failed to get netlink handle for namespace %s: %w", ns.String(), err)
+	}
+	defer nsHandle.Close()
+	defaultNetNSHandle, err := netlink.NewHandleAt(defaultNetNS)
+	if err != nil {
+		return fmt.Errorf("failed to get netlink handle for default namespace: %w", err)
+	}
+	defer defaultNetNSHandle.Close()
+
+	var errs []error
+	for _, name := range names {
+		if err := MoveInterfaceToNamespace(ctx, name, nsHandle, defaultNetNSHandle, defaultNetNS, 0); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -256,6 +256,311 @@ var _ = Describe("Underlay configuration should work when", func() {
 			}
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
+
+	It("RemoveNonConfiguredUnderlays should remove the interfaces and the VTEP of a deleted underlay", func() {
+		primary := UnderlayParams{
+			Name:               "primary",
+			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.1.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		additional := UnderlayParams{
+			Name:               "additional",
+			UnderlayInterfaces: netdevInterfaces(underlayTestInterfaceEdit),
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.2.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		Expect(SetupUnderlay(context.Background(), primary)).To(Succeed())
+		Expect(SetupUnderlay(context.Background(), additional)).To(Succeed())
+		Eventually(func(g Gomega) {
+			validateUnderlayInNS(g, testNs, primary)
+			validateUnderlayInNS(g, testNs, additional)
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		Expect(RemoveNonConfiguredUnderlays(context.Background(), underlayTestNSPath(),
+			[]UnderlayParams{primary})).To(Succeed())
+
+		By("verifying only the VTEP of the deleted underlay was removed from the loopback")
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				validateUnderlay(g, primary, nil)
+				lo, err := netlink.LinkByName(loopbackName)
+				g.Expect(err).NotTo(HaveOccurred())
+				hasIP, err := interfaceHasIP(lo, "192.168.2.1/32")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hasIP).To(BeFalse(), "the VTEP of the deleted underlay should be removed")
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("verifying the interface of the deleted underlay was moved back to the default namespace")
+		Eventually(func(g Gomega) {
+			link, err := netlink.LinkByName(underlayTestInterfaceEdit)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(link.Attrs().Group).To(Equal(uint32(0)))
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("RemoveNonConfiguredUnderlays should remove the stale VTEP even when no interface is left to remove", func() {
+		primary := UnderlayParams{
+			Name:               "primary",
+			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.1.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		Expect(SetupUnderlay(context.Background(), primary)).To(Succeed())
+		By("leaving a VTEP of a deleted underlay on the loopback")
+		Expect(netnamespace.In(testNs, func() error {
+			lo, err := netlink.LinkByName(loopbackName)
+			if err != nil {
+				return err
+			}
+			return AssignIPToInterface(lo, "192.168.2.1/32")
+		})).To(Succeed())
+
+		Expect(RemoveNonConfiguredUnderlays(context.Background(), underlayTestNSPath(),
+			[]UnderlayParams{primary})).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				validateUnderlay(g, primary, nil)
+				lo, err := netlink.LinkByName(loopbackName)
+				g.Expect(err).NotTo(HaveOccurred())
+				hasIP, err := interfaceHasIP(lo, "192.168.2.1/32")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hasIP).To(BeFalse(), "the stale VTEP should be removed")
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should create an underlay bond and restore its members on removal", func() {
+		const bondName = "testbond0"
+		members := []string{underlayTestInterface, underlayTestInterfaceEdit}
+		bondInterface := UnderlayInterface{
+			InterfaceName: bondName,
+			Kind:          UnderlayInterfaceBond,
+			Bond: &BondParams{
+				Members:        members,
+				Mode:           "802.3ad",
+				MIIMon:         100,
+				LACPRate:       "fast",
+				XmitHashPolicy: "layer3+4",
+				Addresses:      []string{"192.168.11.3/24"},
+			},
+		}
+		params := UnderlayParams{
+			UnderlayInterfaces: []UnderlayInterface{bondInterface},
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.1.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+		By("setting up the same bond twice")
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+
+		By("verifying the bond was created in the target namespace with the members enslaved")
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				link, err := netlink.LinkByName(bondName)
+				g.Expect(err).NotTo(HaveOccurred())
+				bond, ok := link.(*netlink.Bond)
+				g.Expect(ok).To(BeTrue(), "%s should be a bond", bondName)
+				g.Expect(bond.Mode).To(Equal(netlink.BOND_MODE_802_3AD))
+				g.Expect(bond.LacpRate).To(Equal(netlink.BOND_LACP_RATE_FAST))
+				g.Expect(bond.XmitHashPolicy).To(Equal(netlink.BOND_XMIT_HASH_POLICY_LAYER3_4))
+				validateGroupID(g, bond, UnderlayGroupID)
+				hasIP, err := interfaceHasIP(bond, "192.168.11.3/24")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hasIP).To(BeTrue(), "bond should have its address")
+				for _, member := range members {
+					l, err := netlink.LinkByName(member)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(l.Attrs().MasterIndex).To(Equal(bond.Attrs().Index))
+					validateGroupID(g, l, UnderlayBondMemberGroupID)
+				}
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("recreating the bond with another mode and address")
+		bondInterface.Bond.Mode = "active-backup"
+		bondInterface.Bond.LACPRate = ""
+		bondInterface.Bond.XmitHashPolicy = ""
+		bondInterface.Bond.Addresses = []string{"192.168.11.4/24"}
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				link, err := netlink.LinkByName(bondName)
+				g.Expect(err).NotTo(HaveOccurred())
+				bond, ok := link.(*netlink.Bond)
+				g.Expect(ok).To(BeTrue(), "%s should be a bond", bondName)
+				g.Expect(bond.Mode).To(Equal(netlink.BOND_MODE_ACTIVE_BACKUP))
+				hasIP, err := interfaceHasIP(bond, "192.168.11.4/24")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hasIP).To(BeTrue(), "recreated bond should have the new address")
+				hasIP, err = interfaceHasIP(bond, "192.168.11.3/24")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hasIP).To(BeFalse(), "recreated bond should not have the old address")
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		names, err := UnderlayInterfaces(underlayTestNSPath())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(ConsistOf(UnderlayInterface{InterfaceName: bondName, Kind: UnderlayInterfaceBond}))
+
+		Expect(RestoreUnderlay(context.Background(), underlayTestNSPath(), []UnderlayInterface{bondInterface})).To(Succeed())
+
+		By("verifying the bond was deleted and the members were moved back")
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				_, err := netlink.LinkByName(bondName)
+				g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "bond should be deleted")
+				return nil
+			})
+			for _, member := range members {
+				l, err := netlink.LinkByName(member)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(l.Attrs().MasterIndex).To(BeZero())
+				validateGroupID(g, l, 0)
+			}
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should move an underlay vlan sub-interface leaving the parent on the host and delete it on removal", func() {
+		vlanInterface := UnderlayInterface{
+			InterfaceName: UnderlayVLANName(underlayTestInterfaceEdit, 100),
+			Kind:          UnderlayInterfaceVLAN,
+			VLAN:          &VLANParams{Parent: underlayTestInterfaceEdit, VLANID: 100},
+		}
+		params := UnderlayParams{
+			UnderlayInterfaces: []UnderlayInterface{vlanInterface},
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.1.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+		By("setting up the same vlan twice")
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+
+		By("verifying the sub-interface was moved to the target namespace and the parent is still on the host")
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				link, err := netlink.LinkByName(vlanInterface.InterfaceName)
+				g.Expect(err).NotTo(HaveOccurred())
+				vlan, ok := link.(*netlink.Vlan)
+				g.Expect(ok).To(BeTrue(), "%s should be a vlan", vlanInterface.InterfaceName)
+				g.Expect(vlan.VlanId).To(Equal(100))
+				g.Expect(vlan.Attrs().Flags & net.FlagUp).To(Equal(net.FlagUp))
+				validateGroupID(g, vlan, UnderlayVLANGroupID)
+				return nil
+			})
+			_, err := netlink.LinkByName(underlayTestInterfaceEdit)
+			g.Expect(err).NotTo(HaveOccurred())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		names, err := UnderlayInterfaces(underlayTestNSPath())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(ConsistOf(UnderlayInterface{InterfaceName: vlanInterface.InterfaceName, Kind: UnderlayInterfaceVLAN}))
+
+		Expect(RestoreUnderlay(context.Background(), underlayTestNSPath(), names)).To(Succeed())
+
+		By("verifying the sub-interface was deleted")
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNs, func() error {
+				_, err := netlink.LinkByName(vlanInterface.InterfaceName)
+				g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "vlan should be deleted")
+				return nil
+			})
+			_, err := netlink.LinkByName(vlanInterface.InterfaceName)
+			g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "vlan should not be moved back")
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should assign the addresses to an underlay vlan sub-interface and follow their changes", func() {
+		vlanInterface := UnderlayInterface{
+			InterfaceName: UnderlayVLANName(underlayTestInterfaceEdit, 100),
+			Kind:          UnderlayInterfaceVLAN,
+			VLAN: &VLANParams{
+				Parent:    underlayTestInterfaceEdit,
+				VLANID:    100,
+				Addresses: []string{"192.168.11.3/24"},
+			},
+		}
+		params := UnderlayParams{
+			UnderlayInterfaces: []UnderlayInterface{vlanInterface},
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.1.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+
+		vlanHasIP := func(g Gomega, ip string) bool {
+			var hasIP bool
+			_ = netnamespace.In(testNs, func() error {
+				link, err := netlink.LinkByName(vlanInterface.InterfaceName)
+				g.Expect(err).NotTo(HaveOccurred())
+				hasIP, err = interfaceHasIP(link, ip)
+				g.Expect(err).NotTo(HaveOccurred())
+				return nil
+			})
+			return hasIP
+		}
+		Eventually(func(g Gomega) {
+			g.Expect(vlanHasIP(g, "192.168.11.3/24")).To(BeTrue())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("changing the addresses of the sub-interface")
+		vlanInterface.VLAN.Addresses = []string{"192.168.11.4/24"}
+		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(vlanHasIP(g, "192.168.11.3/24")).To(BeFalse())
+			g.Expect(vlanHasIP(g, "192.168.11.4/24")).To(BeTrue())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should not take over a vlan sub-interface already existing on the host", func() {
+		vlanInterface := UnderlayInterface{
+			InterfaceName: UnderlayVLANName(underlayTestInterfaceEdit, 100),
+			Kind:          UnderlayInterfaceVLAN,
+			VLAN:          &VLANParams{Parent: underlayTestInterfaceEdit, VLANID: 100},
+		}
+		parent, err := netlink.LinkByName(underlayTestInterfaceEdit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(netlink.LinkAdd(&netlink.Vlan{
+			LinkAttrs: netlink.LinkAttrs{Name: vlanInterface.InterfaceName, ParentIndex: parent.Attrs().Index},
+			VlanId:    100,
+		})).To(Succeed())
+
+		params := UnderlayParams{
+			UnderlayInterfaces: []UnderlayInterface{vlanInterface},
+			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+				IPv4CIDR: "192.168.1.1/32",
+			},
+			TargetNS: underlayTestNSPath(),
+		}
+		Expect(SetupUnderlay(context.Background(), params)).To(MatchError(ContainSubstring("not managed by the router")))
+
+		By("verifying the sub-interface was left untouched on the host")
+		link, err := netlink.LinkByName(vlanInterface.InterfaceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(link.Attrs().Group).To(BeZero())
+		_ = netnamespace.In(testNs, func() error {
+			_, err := netlink.LinkByName(vlanInterface.InterfaceName)
+			Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "vlan should not be moved")
+			return nil
+		})
+	})
 })
 
 var _ = Describe("UnderlayInterfacesToRemove", func() {
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// UnderlayVLANGroupID is the link group ID assigned to the underlay VLAN
+// sub-interfaces created on the host and moved into the network namespace,
+// so that they are deleted instead of being moved back when removed.
+const UnderlayVLANGroupID = 4244
+
+// VLANParams holds the data needed to create an underlay VLAN sub-interface.
+type VLANParams struct {
+	// Parent is the host network device the sub-interface is created on.
+	Parent string `json:"parent"`
+	// VLANID is the 802.1Q VLAN ID of the sub-interface.
+	VLANID int `json:"vlan_id"`
+	// Addresses are the addresses assigned to the sub-interface in the
+	// namespace.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// UnderlayVLANName returns the name of the VLAN sub-interface of the given
+// parent.
+func UnderlayVLANName(parent string, vlanID int) string {
+	return fmt.Sprintf("%s.%d", parent, vlanID)
+}
+
+// SetupUnderlayVLANInterface provisions a single underlay VLAN
+// sub-interface: it is created on the parent in the default namespace, if
+// not already in the namespace, and moved into the namespace, where it gets
+// its addresses. The parent stays in the default namespace. A link with the
+// same name already on the host is not adopted unless it was created by us,
+// as the sub-interface is deleted when the underlay is removed.
+func SetupUnderlayVLANInterface(ctx context.Context, ns netns.NsHandle,
+	iface UnderlayInterface) error {
+	if iface.VLAN == nil {
+		return fmt.Errorf("vlan parameters are missing for underlay interface %s", iface.InterfaceName)
+	}
+
+	nsHandle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return fmt.Errorf("failed to get netlink handle for namespace %s: %w", ns.String(), err)
+	}
+	defer nsHandle.Close()
+	_, err = nsHandle.LinkByName(iface.InterfaceName)
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return fmt.Errorf("could not find underlay vlan %s by name: %w", iface.InterfaceName, err)
+	}
+	if err != nil {
+		if err := createUnderlayVLAN(iface); err != nil {
+			return fmt.Errorf("failed to setup underlay vlan %s: %w", iface.InterfaceName, err)
+		}
+		if err := moveInterfaceFromDefaultNetns(ctx, ns, iface.InterfaceName, UnderlayVLANGroupID); err != nil {
+			return fmt.Errorf("failed to setup underlay vlan %s: %w", iface.InterfaceName, err)
+		}
+	}
+
+	if err := setUnderlayInterfaceAddresses(ns, iface.InterfaceName, iface.VLAN.Addresses); err != nil {
+		return fmt.Errorf("failed to setup underlay vlan %s: %w", iface.InterfaceName, err)
+	}
+	return nil
+}
+
+// createUnderlayVLAN creates the underlay VLAN sub-interface on the host,
+// tagged with the underlay VLAN group ID so that a sub-interface left
+// behind by a failed move is recognized as ours. It fails if a link with
+// the same name not created by us exists.
+func createUnderlayVLAN(iface UnderlayInterface) error {
+	parent, err := netlink.LinkByName(iface.VLAN.Parent)
+	if err != nil {
+		return fmt.Errorf("could not find parent %s: %w", iface.VLAN.Parent, err)
+	}
+
+	link, err := netlink.LinkByName(iface.InterfaceName)
+	if err == nil {
+		if link.Attrs().Group != UnderlayVLANGroupID {
+			return fmt.Errorf("link %s already exists on the host and is not managed by the router", iface.InterfaceName)
+		}
+		vlan, ok := link.(*netlink.Vlan)
+		if !ok || vlan.VlanId != iface.VLAN.VLANID || vlan.ParentIndex != parent.Attrs().Index {
+			return fmt.Errorf("link %s already exists on the host with different vlan parameters", iface.InterfaceName)
+		}
+		return nil
+	}
+	if !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return fmt.Errorf("could not find link %s by name: %w", iface.InterfaceName, err)
+	}
+
+	toCreate := &netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        iface.InterfaceName,
+			ParentIndex: parent.Attrs().Index,
+			Group:       UnderlayVLANGroupID,
+		},
+		VlanId: iface.VLAN.VLANID,
+	}
+	if err := netlink.LinkAdd(toCreate); err != nil {
+		return fmt.Errorf("could not create vlan interface %s: %w", iface.InterfaceName, err)
+	}
+	return nil
+}
+
+// deleteUnderlayVLANs deletes the given underlay VLAN sub-interfaces of the
+// namespace.
+func deleteUnderlayVLANs(vlans map[string]bool, fromNetNSHandle *netlink.Handle) error {
+	links, err := fromNetNSHandle.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	var errs []error
+	for _, l := range links {
+		if !vlans[l.Attrs().Name] || l.Attrs().Group != UnderlayVLANGroupID {
+			continue
+		}
+		if err := fromNetNSHandle.LinkDel(l); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete underlay vlan %s: %w", l.Attrs().Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
 	"strconv"
 	"strings"
@@ -156,6 +157,50 @@ func vethNamesFromL3VPN(rdAssignedNumber int32) VethNames {
 	return VethNames{HostSide: hostSide, NamespaceSide: peSide}
 }
 
+// HostSessionVethID returns the identifier of the host session with the
+// given name in the names of its veth legs: three hex digits of a hash of
+// the name, so that the names do not depend on the position of the session
+// and still fit in the 15 characters of an interface name.
+func HostSessionVethID(session string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(session))
+	return fmt.Sprintf("%03x", h.Sum32()&0xfff)
+}
+
+// hostSessionVethPrefix returns the prefixes replacing HostVethPrefix and
+// PEVethPrefix in the names of the veth legs of the host session with the
+// given name. The names of the veth of the first session leave no room for
+// a suffix, so the session is part of a shorter prefix.
+func hostSessionVethPrefix(session string) (string, string) {
+	id := HostSessionVethID(session)
+	return fmt.Sprintf("h%s-", id), fmt.Sprintf("p%s-", id)
+}
+
+// hostSessionVethNames returns the names of the veth legs of the host session
+// with the given name following the first one, starting from the ones of
+// the first session.
+func hostSessionVethNames(vethNames VethNames, session string) VethNames {
+	hostPrefix, pePrefix := hostSessionVethPrefix(session)
+	return VethNames{
+		HostSide:      hostPrefix + strings.TrimPrefix(vethNames.HostSide, HostVethPrefix),
+		NamespaceSide: pePrefix + strings.TrimPrefix(vethNames.NamespaceSide, PEVethPrefix),
+	}
+}
+
+// hostSessionVethSuffix returns the part of the name of a host leg of a
+// host session following the first one that comes after the session
+// prefix, i.e. "e-100" for "h1a2-e-100".
+func hostSessionVethSuffix(name string) (string, bool) {
+	prefix, suffix, found := strings.Cut(name, "-")
+	if !found || len(prefix) != 4 || prefix[0] != 'h' {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(prefix[1:], 16, 16); err != nil {
+		return "", false
+	}
+	return suffix, true
+}
+
 // interfaceIDFromPrefix extracts the interface ID (as int32) from an interface
 // starting with prefix.
 func interfaceIDFromPrefix(hostVethName string, prefix string) (int32, error) {
@@ -0,0 +1,408 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openperouter/openperouter/internal/netnamespace"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	vlanAwareBridgeName = "br-pe"
+	vlanAwareVXLanName  = "vxlan-pe"
+	sviPrefix           = "svi"
+)
+
+// vlanAwareVXLanPortFlags are the flags of the port of the VXLan device
+// shared by the VNIs mapped to the VLANs of the VLAN-aware bridge.
+var vlanAwareVXLanPortFlags = bridgePortFlags{neighSuppression: true, vlanTunnel: true}
+
+// setupVLANAwareVNI sets up the configuration required by FRR to
+// serve a given VNI mapped to a VLAN of the VLAN-aware bridge in the
+// target namespace. This includes:
+// - the VLAN-aware bridge and the VXLan interface in external mode,
+// shared by all the VNIs mapped to a VLAN
+// - the mapping between the VLAN and the VNI on the VXLan port
+// - a VLAN interface on top of the bridge, bound to the VRF when params.VRF is non-empty
+func setupVLANAwareVNI(ctx context.Context, params VNIParams) error {
+	slog.DebugContext(ctx, "setting up vlan aware VNI", "params", params)
+	defer slog.DebugContext(ctx, "end setting up vlan aware VNI", "params", params)
+	ns, err := netns.GetFromPath(params.TargetNS)
+	if err != nil {
+		return fmt.Errorf("failed to get network namespace %s: %w", params.TargetNS, err)
+	}
+	defer func() {
+		if err := ns.Close(); err != nil {
+			slog.Error("failed to close namespace", "namespace", params.TargetNS, "error", err)
+		}
+	}()
+
+	return netnamespace.In(ns, func() error {
+		bridge, err := setupVLANAwareBridge()
+		if err != nil {
+			return err
+		}
+		if err := setupVXLan(params, bridge, vlanAwareVXLanPortFlags); err != nil {
+			return err
+		}
+		vxlan, err := netlink.LinkByName(vlanAwareVXLanName)
+		if err != nil {
+			return fmt.Errorf("could not find vxlan %s: %w", vlanAwareVXLanName, err)
+		}
+		if err := mapVLANToVNI(vxlan, params.VLAN, params.VNI); err != nil {
+			return err
+		}
+		if err := ensureBridgeSelfVLAN(bridge, params.VLAN); err != nil {
+			return err
+		}
+		return setupSVI(params, bridge)
+	})
+}
+
+// setupVLANAwareBridge creates the VLAN-aware bridge and brings the link up.
+// Its ports are members of no VLAN by default, only of the ones they are
+// explicitly added to.
+func setupVLANAwareBridge() (*netlink.Bridge, error) {
+	bridge, err := ensureBridge(&netlink.Bridge{
+		LinkAttrs:       netlink.LinkAttrs{Name: vlanAwareBridgeName},
+		VlanFiltering:   new(true),
+		VlanDefaultPVID: new(uint16(0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ptr.Deref(bridge.VlanFiltering, false) {
+		if err := netlink.BridgeSetVlanFiltering(bridge, true); err != nil {
+			return nil, fmt.Errorf("could not enable vlan filtering on bridge %s: %w", bridge.Name, err)
+		}
+	}
+	if err := linkSetUp(bridge); err != nil {
+		return nil, fmt.Errorf("could not set link up for bridge %s: %v", bridge.Name, err)
+	}
+	return bridge, nil
+}
+
+// mapVLANToVNI maps the VLAN to the VNI on the port of the shared VXLan
+// device, removing the stale mappings of either of them. The shared VXLan
+// device is the only one with VLAN tunnel mappings in the namespace.
+func mapVLANToVNI(vxlan netlink.Link, vlan uint16, vni int32) error {
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	if err != nil {
+		return fmt.Errorf("failed to list vlan tunnel mappings: %w", err)
+	}
+	mapped := false
+	for _, t := range tunnels {
+		if t.Vid == vlan && int32(t.TunId) == vni {
+			mapped = true
+			continue
+		}
+		if t.Vid != vlan && int32(t.TunId) != vni {
+			continue
+		}
+		if err := unmapVLAN(vxlan, t); err != nil {
+			return err
+		}
+	}
+	if mapped {
+		return nil
+	}
+
+	if err := netlink.BridgeVlanAdd(vxlan, vlan, false, false, false, true); err != nil {
+		return fmt.Errorf("failed to add vlan %d to %s: %w", vlan, vxlan.Attrs().Name, err)
+	}
+	if err := netlink.BridgeVlanAddTunnelInfo(vxlan, vlan, uint32(vni), false, true); err != nil {
+		return fmt.Errorf("failed to map vlan %d to vni %d on %s: %w", vlan, vni, vxlan.Attrs().Name, err)
+	}
+	return nil
+}
+
+// unmapVLAN removes the given mapping and its VLAN from the port of the shared VXLan device.
+func unmapVLAN(vxlan netlink.Link, t nl.TunnelInfo) error {
+	if err := netlink.BridgeVlanDelTunnelInfo(vxlan, t.Vid, t.TunId, false, true); err != nil {
+		return fmt.Errorf("failed to unmap vlan %d from vni %d on %s: %w", t.Vid, t.TunId, vxlan.Attrs().Name, err)
+	}
+	if err := netlink.BridgeVlanDel(vxlan, t.Vid, false, false, false, true); err != nil {
+		return fmt.Errorf("failed to remove vlan %d from %s: %w", t.Vid, vxlan.Attrs().Name, err)
+	}
+	return nil
+}
+
+// bridgeVLANs returns the VLANs the given link is a member of.
+func bridgeVLANs(link netlink.Link) ([]*nl.BridgeVlanInfo, error) {
+	index, err := intToInt32(link.Attrs().Index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index for %s", link.Attrs().Name)
+	}
+	vlans, err := netlink.BridgeVlanList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bridge vlans: %w", err)
+	}
+	return vlans[index], nil
+}
+
+// ensureBridgeSelfVLAN makes the bridge itself a member of the VLAN, so that
+// the VLAN interface on top of it receives the traffic of the VLAN.
+func ensureBridgeSelfVLAN(bridge *netlink.Bridge, vlan uint16) error {
+	current, err := bridgeVLANs(bridge)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(current, func(v *nl.BridgeVlanInfo) bool { return v.Vid == vlan }) {
+		return nil
+	}
+	if err := netlink.BridgeVlanAdd(bridge, vlan, false, false, true, false); err != nil {
+		return fmt.Errorf("failed to add vlan %d to bridge %s: %w", vlan, bridge.Name, err)
+	}
+	return nil
+}
+
+// ensureAccessVLAN makes the VLAN the only one of the bridge port, as
+// untagged PVID.
+func ensureAccessVLAN(port netlink.Link, vlan uint16) error {
+	current, err := bridgeVLANs(port)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, v := range current {
+		if v.Vid == vlan && v.PortVID() && v.EngressUntag() {
+			found = true
+			continue
+		}
+		if err := netlink.BridgeVlanDel(port, v.Vid, v.PortVID(), v.EngressUntag(), false, true); err != nil {
+			return fmt.Errorf("failed to remove vlan %d from %s: %w", v.Vid, port.Attrs().Name, err)
+		}
+	}
+	if found {
+		return nil
+	}
+	if err := netlink.BridgeVlanAdd(port, vlan, true, true, false, true); err != nil {
+		return fmt.Errorf("failed to add vlan %d to %s: %w", vlan, port.Attrs().Name, err)
+	}
+	return nil
+}
+
+// setupSVI creates the VLAN interface of the VNI on top of the VLAN-aware
+// bridge, binds it to the VRF when params.VRF is non-empty and brings the
+// link up.
+func setupSVI(params VNIParams, bridge *netlink.Bridge) error {
+	svi, err := createVLANInterface(sviName(params.VNI), bridge, params.VLAN)
+	if err != nil {
+		return err
+	}
+
+	if params.VRF != "" {
+		vrf, err := lookupVRF(params.VRF)
+		if err != nil {
+			return fmt.Errorf("could not find vrf %s for vlan interface %s: %w", params.VRF, svi.Name, err)
+		}
+		if err := linkSetMaster(svi, vrf); err != nil {
+			return fmt.Errorf("could not bind vlan interface %s to vrf %s: %w", svi.Name, params.VRF, err)
+		}
+	}
+
+	if err := linkSetUp(svi); err != nil {
+		return fmt.Errorf("could not set link up for vlan interface %s: %v", svi.Name, err)
+	}
+	return nil
+}
+
+// createVLANInterface creates the VLAN interface of the given VLAN on top
+// of the parent link. If it already exists, it will return the existing one.
+func createVLANInterface(name string, parent netlink.Link, vlan uint16) (*netlink.Vlan, error) {
+	toCreate := &netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        name,
+			ParentIndex: parent.Attrs().Index,
+		},
+		VlanId: int(vlan),
+	}
+
+	link, err := netlink.LinkByName(name)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		if err := netlink.LinkAdd(toCreate); err != nil {
+			return nil, fmt.Errorf("could not create vlan interface %s: %w", name, err)
+		}
+		return toCreate, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not find vlan interface by name %s: %w", name, err)
+	}
+
+	vlanLink, ok := link.(*netlink.Vlan)
+	if ok && vlanLink.VlanId == int(vlan) && vlanLink.ParentIndex == parent.Attrs().Index {
+		return vlanLink, nil
+	}
+
+	// link exists but it's not the expected vlan interface, delete and recreate
+	if err := netlink.LinkDel(link); err != nil {
+		return nil, fmt.Errorf("failed to delete link %v: %w", link, err)
+	}
+	if err := netlink.LinkAdd(toCreate); err != nil {
+		return nil, fmt.Errorf("could not create vlan interface %s: %w", name, err)
+	}
+	return toCreate, nil
+}
+
+// setupVLANAwareL2VNIRouterSide attaches the veth leg to the VLAN-aware
+// bridge as an access port of the VLAN of the VNI, and assigns the gateway
+// IPs to the VLAN interface of the VNI.
+func setupVLANAwareL2VNIRouterSide(params L2VNIParams, vethName string, underlayMTU int) error {
+	peVeth, err := netlink.LinkByName(vethName)
+	if err != nil {
+		return fmt.Errorf("could not find peer veth %s in namespace %s: %w", vethName, params.TargetNS, err)
+	}
+
+	if err := setVethMTUForTunnelOverhead(peVeth, underlayMTU, VXLanOverhead); err != nil {
+		return fmt.Errorf("failed to set MTU on pe veth %s: %w", vethName, err)
+	}
+
+	bridge, err := netlink.LinkByName(vlanAwareBridgeName)
+	if err != nil {
+		return fmt.Errorf("could not find bridge %s in namespace %s: %w", vlanAwareBridgeName, params.TargetNS, err)
+	}
+	if err := linkSetMaster(peVeth, bridge); err != nil {
+		return fmt.Errorf("failed to set bridge %s as master of pe veth %s: %w", vlanAwareBridgeName, vethName, err)
+	}
+	if err := ensureAccessVLAN(peVeth, params.VLAN); err != nil {
+		return err
+	}
+
+	if len(params.L2GatewayIPs) == 0 {
+		return nil
+	}
+	name := sviName(params.VNI)
+	svi, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("could not find vlan interface %s in namespace %s: %w", name, params.TargetNS, err)
+	}
+	if err := removeOtherAddresses(svi, params.gatewayInterfaceIPs()...); err != nil {
+		return err
+	}
+	for _, ip := range params.gatewayInterfaceIPs() {
+		if err := AssignIPToInterface(svi, ip); err != nil {
+			return fmt.Errorf("failed to assign L2 gateway IP %s to vlan interface %s: %w", ip, name, err)
+		}
+	}
+	// setting up the same mac address for all the nodes for distributed gateway
+	if err := ensureBridgeGatewayMacAddress(svi, params); err != nil {
+		return fmt.Errorf("failed to set vlan interface mac address %s: %v", name, err)
+	}
+	return nil
+}
+
+// removeVLANAwareVNIs removes the VLAN interfaces and the VLAN mappings of
+// the VNIs that are not mapped to the given VLANs anymore, and the
+// VLAN-aware bridge and its VXLan device when no VNI is mapped to a VLAN.
+func removeVLANAwareVNIs(vnis map[int32]uint16) []error {
+	var failedDeletes []error
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return []error{fmt.Errorf("remove non configured vnis: failed to list links: %w", err)}
+	}
+	configured := map[int32]bool{}
+	for vni := range vnis {
+		configured[vni] = true
+	}
+	if err := deleteLinksForType(VLANLinkType, configured, links, vniFromSVIName); err != nil {
+		failedDeletes = append(failedDeletes, fmt.Errorf("remove vlan interfaces: %w", err))
+	}
+
+	if len(vnis) == 0 {
+		for _, name := range []string{vlanAwareVXLanName, vlanAwareBridgeName} {
+			if err := RemoveLinkByName(name); err != nil {
+				failedDeletes = append(failedDeletes, fmt.Errorf("remove non configured vnis: failed to delete %s: %w", name, err))
+			}
+		}
+		return failedDeletes
+	}
+
+	return append(failedDeletes, removeStaleVLANMappings(vnis)...)
+}
+
+// removeStaleVLANMappings removes the VLAN mappings of the shared VXLan
+// device and the VLANs of the VLAN-aware bridge that do not correspond to
+// the given VNIs.
+func removeStaleVLANMappings(vnis map[int32]uint16) []error {
+	var failedDeletes []error
+
+	vxlan, err := netlink.LinkByName(vlanAwareVXLanName)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("remove non configured vnis: failed to get vxlan %s: %w", vlanAwareVXLanName, err)}
+	}
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	if err != nil {
+		return []error{fmt.Errorf("remove non configured vnis: failed to list vlan tunnel mappings: %w", err)}
+	}
+	for _, t := range tunnels {
+		if vlan, ok := vnis[int32(t.TunId)]; ok && vlan == t.Vid {
+			continue
+		}
+		if err := unmapVLAN(vxlan, t); err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("remove non configured vnis: %w", err))
+		}
+	}
+
+	bridge, err := netlink.LinkByName(vlanAwareBridgeName)
+	if err != nil {
+		return append(failedDeletes, fmt.Errorf("remove non configured vnis: failed to get bridge %s: %w", vlanAwareBridgeName, err))
+	}
+	bridgeVLANs, err := bridgeVLANs(bridge)
+	if err != nil {
+		return append(failedDeletes, fmt.Errorf("remove non configured vnis: %w", err))
+	}
+	configuredVLANs := map[uint16]bool{}
+	for _, vlan := range vnis {
+		configuredVLANs[vlan] = true
+	}
+	for _, v := range bridgeVLANs {
+		if configuredVLANs[v.Vid] {
+			continue
+		}
+		if err := netlink.BridgeVlanDel(bridge, v.Vid, false, false, true, false); err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("remove non configured vnis: failed to remove vlan %d from bridge %s: %w", v.Vid, vlanAwareBridgeName, err))
+		}
+	}
+	return failedDeletes
+}
+
+// L2GatewayInterfaceName returns the name of the interface of the router
+// holding the gateway IPs of the L2VNI: its bridge, or its VLAN interface
+// when the VNI is mapped to a VLAN of the VLAN-aware bridge.
+func L2GatewayInterfaceName(params L2VNIParams) string {
+	if params.VLAN != 0 {
+		return sviName(params.VNI)
+	}
+	return BridgeName(params.VNI)
+}
+
+func sviName(vni int32) string {
+	return fmt.Sprintf("%s%d", sviPrefix, vni)
+}
+
+func vniFromSVIName(name string) (int32, error) {
+	if !strings.HasPrefix(name, sviPrefix) {
+		return 0, NotRouterInterfaceError{Name: name}
+	}
+	vni := strings.TrimPrefix(name, sviPrefix)
+	res, err := strconv.ParseInt(vni, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vni for vlan interface %s", name)
+	}
+	return int32(res), nil
+}
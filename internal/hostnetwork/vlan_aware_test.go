@@ -0,0 +1,244 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"context"
+	"net"
+	"slices"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openperouter/openperouter/internal/netnamespace"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("L2 VNI configuration with the VLAN-aware bridge", func() {
+	var testNS netns.NsHandle
+	const bridgeName = "testbridge"
+
+	BeforeEach(func() {
+		cleanTest(testNSName)
+		testNS = createTestNS(testNSName)
+		setupLoopback(testNS)
+		createLinuxBridge(bridgeName)
+	})
+	AfterEach(func() {
+		cleanTest(testNSName)
+	})
+
+	vlanAwareParams := func(vni int32, vlan uint16, gatewayIPs ...string) L2VNIParams {
+		return L2VNIParams{
+			VNIParams: VNIParams{
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.9/32",
+				VNI:       vni,
+				VXLanPort: new(int32(4789)),
+				VLAN:      vlan,
+			},
+			L2GatewayIPs: gatewayIPs,
+			HostMaster: &HostMaster{
+				Name: new(bridgeName),
+				Type: BridgeLinkType,
+			},
+		}
+	}
+
+	It("should map multiple L2VNIs to the VLAN-aware bridge + cleanup", func() {
+		params := []L2VNIParams{
+			vlanAwareParams(100, 10, "192.168.1.1/24", "2001:db8::1/64"),
+			vlanAwareParams(101, 11),
+		}
+		params[0].GatewayMAC = "00:00:5e:00:01:01"
+
+		for _, p := range params {
+			err := SetupL2VNI(context.Background(), p)
+			Expect(err).NotTo(HaveOccurred())
+			// Test idempotency - calling setup twice should work
+			err = SetupL2VNI(context.Background(), p)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Eventually(func(g Gomega) {
+			for _, p := range params {
+				validateL2HostLeg(g, p)
+				_ = netnamespace.In(testNS, func() error {
+					validateVLANAwareL2VNI(g, p)
+					return nil
+				})
+			}
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		remaining := params[0]
+		toDelete := params[1]
+
+		By("removing non configured L2VNIs")
+		err := RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{remaining.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2HostLeg(g, remaining)
+			checkLinkdeleted(g, vethNamesFromVNI(toDelete.VNI).HostSide)
+			_ = netnamespace.In(testNS, func() error {
+				validateVLANAwareL2VNI(g, remaining)
+				checkLinkdeleted(g, sviName(toDelete.VNI))
+				checkLinkdeleted(g, vethNamesFromVNI(toDelete.VNI).NamespaceSide)
+				tunnels, err := netlink.BridgeVlanTunnelShow()
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(tunnels).To(ConsistOf(nl.TunnelInfo{TunId: uint32(remaining.VNI), Vid: remaining.VLAN}))
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("removing all the L2VNIs")
+		err = RemoveAllVNIs(testNSPath())
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			checkLinkdeleted(g, vethNamesFromVNI(remaining.VNI).HostSide)
+			_ = netnamespace.In(testNS, func() error {
+				checkLinkdeleted(g, sviName(remaining.VNI))
+				checkLinkdeleted(g, vlanAwareVXLanName)
+				checkLinkdeleted(g, vlanAwareBridgeName)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should remap an L2VNI to a different VLAN", func() {
+		params := vlanAwareParams(100, 10, "192.168.1.1/24")
+		err := SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		params.VLAN = 20
+		err = SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{params.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateVLANAwareL2VNI(g, params)
+				tunnels, err := netlink.BridgeVlanTunnelShow()
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(tunnels).To(ConsistOf(nl.TunnelInfo{TunId: uint32(params.VNI), Vid: params.VLAN}))
+
+				bridge, err := netlink.LinkByName(vlanAwareBridgeName)
+				g.Expect(err).NotTo(HaveOccurred())
+				vlans, err := bridgeVLANs(bridge)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(vlans).To(HaveLen(1))
+				g.Expect(vlans[0].Vid).To(Equal(params.VLAN))
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should switch an L2VNI between the per-VNI and the VLAN-aware layouts", func() {
+		perVNI := vlanAwareParams(100, 0, "192.168.1.1/24")
+		vlanAware := vlanAwareParams(100, 10, "192.168.1.1/24")
+
+		By("configuring the per-VNI layout")
+		err := SetupL2VNI(context.Background(), perVNI)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{perVNI.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("moving to the VLAN-aware layout")
+		err = SetupL2VNI(context.Background(), vlanAware)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{vlanAware.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2HostLeg(g, vlanAware)
+			_ = netnamespace.In(testNS, func() error {
+				validateVLANAwareL2VNI(g, vlanAware)
+				checkLinkdeleted(g, vxLanNameFromVNI(vlanAware.VNI))
+				checkLinkdeleted(g, BridgeName(vlanAware.VNI))
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("moving back to the per-VNI layout")
+		err = SetupL2VNI(context.Background(), perVNI)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{perVNI.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2HostLeg(g, perVNI)
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, perVNI)
+				checkLinkdeleted(g, sviName(perVNI.VNI))
+				checkLinkdeleted(g, vlanAwareVXLanName)
+				checkLinkdeleted(g, vlanAwareBridgeName)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+})
+
+func validateVLANAwareL2VNI(g Gomega, params L2VNIParams) {
+	vtepDev, err := netlink.LinkByName(loopbackName)
+	g.Expect(err).NotTo(HaveOccurred(), "vtep device not found %q", loopbackName)
+
+	bridgeLink, err := netlink.LinkByName(vlanAwareBridgeName)
+	g.Expect(err).NotTo(HaveOccurred(), "bridge not found", vlanAwareBridgeName)
+	bridge := bridgeLink.(*netlink.Bridge)
+	g.Expect(bridge.OperState).To(BeEquivalentTo(netlink.OperUp))
+	g.Expect(ptr.Deref(bridge.VlanFiltering, false)).To(BeTrue(), "vlan filtering is not enabled on the bridge")
+	g.Expect(bridge.MasterIndex).To(BeZero(), "the vlan aware bridge should not be enslaved to a VRF")
+
+	vxlanLink, err := netlink.LinkByName(vlanAwareVXLanName)
+	g.Expect(err).NotTo(HaveOccurred(), "vxlan link not found %q", vlanAwareVXLanName)
+	vxlan := vxlanLink.(*netlink.Vxlan)
+	g.Expect(checkVXLanConfigured(vxlan, bridge.Index, vtepDev.Attrs().Index, params.VNIParams)).To(Succeed())
+	protinfo, err := netlink.LinkGetProtinfo(vxlan)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(protinfo.NeighSuppress).To(BeTrue(), "neigh suppression is not enabled on the vxlan port")
+	g.Expect(protinfo.VlanTunnel).To(BeTrue(), "vlan tunnel is not enabled on the vxlan port")
+
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tunnels).To(ContainElement(nl.TunnelInfo{TunId: uint32(params.VNI), Vid: params.VLAN}))
+
+	checkLinkdeleted(g, vxLanNameFromVNI(params.VNI))
+	checkLinkdeleted(g, BridgeName(params.VNI))
+
+	vethNames := vethNamesFromVNI(params.VNI)
+	peLegLink, err := netlink.LinkByName(vethNames.NamespaceSide)
+	g.Expect(err).NotTo(HaveOccurred(), "veth pe side not found", vethNames.NamespaceSide)
+	g.Expect(peLegLink.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
+	g.Expect(peLegLink.Attrs().MasterIndex).To(Equal(bridge.Index))
+	vlans, err := bridgeVLANs(peLegLink)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(vlans).To(HaveLen(1), "pe veth should be an access port of a single vlan")
+	g.Expect(vlans[0].Vid).To(Equal(params.VLAN))
+	g.Expect(vlans[0].PortVID()).To(BeTrue())
+	g.Expect(vlans[0].EngressUntag()).To(BeTrue())
+
+	sviLink, err := netlink.LinkByName(sviName(params.VNI))
+	g.Expect(err).NotTo(HaveOccurred(), "vlan interface not found", sviName(params.VNI))
+	svi := sviLink.(*netlink.Vlan)
+	g.Expect(svi.VlanId).To(BeEquivalentTo(params.VLAN))
+	g.Expect(svi.ParentIndex).To(Equal(bridge.Index))
+	g.Expect(svi.Flags&net.FlagUp).NotTo(BeZero(), "vlan interface is not up")
+	bridgeVLANs, err := bridgeVLANs(bridge)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(slices.ContainsFunc(bridgeVLANs, func(v *nl.BridgeVlanInfo) bool { return v.Vid == params.VLAN })).
+		To(BeTrue(), "bridge is not a member of vlan %d", params.VLAN)
+
+	for _, ip := range params.L2GatewayIPs {
+		hasIP, err := interfaceHasIP(svi, ip)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(hasIP).To(BeTrue(), "vlan interface does not have ip", ip)
+	}
+	if params.GatewayMAC != "" {
+		g.Expect(svi.HardwareAddr.String()).To(Equal(params.GatewayMAC), "vlan interface MAC address should be the gateway MAC")
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/openperouter/openperouter/internal/netnamespace"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"k8s.io/utils/ptr"
+)
+
+const trunkInfix = "trunk"
+
+// trunkVethNames are the names of the legs of the veth pair shared by the
+// VNIs exposed to the host as VLANs of a trunk.
+var trunkVethNames = VethNames{
+	HostSide:      HostVethPrefix + trunkInfix,
+	NamespaceSide: PEVethPrefix + trunkInfix,
+}
+
+// isVLANTrunk tells if the VNI is exposed to the host as a VLAN of the trunk
+// instead of getting its own veth pair.
+func isVLANTrunk(m *HostMaster) bool {
+	return m != nil && m.Type == VLANTrunkLinkType
+}
+
+// setupHostTrunkVLAN exposes the VLAN of the VNI on the host leg of the
+// trunk: as a tagged VLAN of its port when the host master names a bridge
+// to attach the trunk to, as a VLAN sub-interface of the trunk otherwise.
+func setupHostTrunkVLAN(hostTrunk netlink.Link, m HostMaster) error {
+	vlanName := trunkVLANName(hostTrunk.Attrs().Name, m.TrunkVLAN)
+	bridgeName := ptr.Deref(m.Name, "")
+	if bridgeName == "" {
+		if hostTrunk.Attrs().MasterIndex != 0 {
+			if err := netlink.LinkSetNoMaster(hostTrunk); err != nil {
+				return fmt.Errorf("failed to detach %s from its master: %w", hostTrunk.Attrs().Name, err)
+			}
+		}
+		_, err := setupTrunkVLANInterface(vlanName, hostTrunk, m.TrunkVLAN)
+		return err
+	}
+
+	// the sub-interface would steal the traffic of the VLAN from the bridge
+	if err := RemoveLinkByName(vlanName); err != nil {
+		return err
+	}
+	bridge, err := netlink.LinkByName(bridgeName)
+	if err != nil {
+		return fmt.Errorf("could not find host bridge %s: %w", bridgeName, err)
+	}
+	if err := linkSetMaster(hostTrunk, bridge); err != nil {
+		return fmt.Errorf("failed to set host bridge %s as master of %s: %w", bridgeName, hostTrunk.Attrs().Name, err)
+	}
+	return ensureTaggedVLAN(hostTrunk, m.TrunkVLAN)
+}
+
+// setupRouterTrunkVLAN creates the VLAN sub-interface of the VNI on the
+// router leg of the trunk, to be attached to the bridge of the VNI.
+func setupRouterTrunkVLAN(trunkName string, vlan uint16, underlayMTU int) (*netlink.Vlan, error) {
+	trunk, err := netlink.LinkByName(trunkName)
+	if err != nil {
+		return nil, fmt.Errorf("could not find trunk %s: %w", trunkName, err)
+	}
+	if err := setVethMTUForTunnelOverhead(trunk, underlayMTU, VXLanOverhead); err != nil {
+		return nil, fmt.Errorf("failed to set MTU on trunk %s: %w", trunkName, err)
+	}
+	return setupTrunkVLANInterface(trunkVLANName(trunkName, vlan), trunk, vlan)
+}
+
+// setupTrunkVLANInterface creates the VLAN sub-interface of the trunk leg,
+// aligns its MTU with the trunk one and brings the link up.
+func setupTrunkVLANInterface(name string, trunk netlink.Link, vlan uint16) (*netlink.Vlan, error) {
+	vlanLink, err := createVLANInterface(name, trunk, vlan)
+	if err != nil {
+		return nil, err
+	}
+	currentTrunk, err := netlink.LinkByIndex(trunk.Attrs().Index)
+	if err != nil {
+		return nil, fmt.Errorf("could not find trunk %s: %w", trunk.Attrs().Name, err)
+	}
+	if err := linkSetMTU(vlanLink, currentTrunk.Attrs().MTU); err != nil {
+		return nil, fmt.Errorf("failed to set MTU on vlan interface %s: %w", name, err)
+	}
+	if err := linkSetUp(vlanLink); err != nil {
+		return nil, fmt.Errorf("could not set link up for vlan interface %s: %v", name, err)
+	}
+	return vlanLink, nil
+}
+
+// ensureTaggedVLAN makes the bridge port a tagged member of the VLAN.
+func ensureTaggedVLAN(port netlink.Link, vlan uint16) error {
+	current, err := bridgeVLANs(port)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(current, func(v *nl.BridgeVlanInfo) bool { return v.Vid == vlan && !v.EngressUntag() }) {
+		return nil
+	}
+	if err := netlink.BridgeVlanAdd(port, vlan, false, false, false, true); err != nil {
+		return fmt.Errorf("failed to add vlan %d to %s: %w", vlan, port.Attrs().Name, err)
+	}
+	return nil
+}
+
+// RemoveNonConfiguredTrunkVLANs removes from both legs of the trunk the
+// VLANs that do not correspond to any of the given L2VNIs exposed as VLANs
+// of the trunk, and the trunk itself when there are none.
+func RemoveNonConfiguredTrunkVLANs(targetNS string, params []L2VNIParams) error {
+	vlans := map[uint16]bool{}
+	for _, p := range params {
+		if isVLANTrunk(p.HostMaster) {
+			vlans[p.HostMaster.TrunkVLAN] = true
+		}
+	}
+	if len(vlans) == 0 {
+		if err := RemoveLinkByName(trunkVethNames.HostSide); err != nil {
+			return fmt.Errorf("RemoveNonConfiguredTrunkVLANs: failed to delete %s: %w", trunkVethNames.HostSide, err)
+		}
+		return nil
+	}
+
+	errs := removeStaleTrunkVLANs(trunkVethNames.HostSide, vlans)
+
+	ns, err := netns.GetFromPath(targetNS)
+	if err != nil {
+		return fmt.Errorf("RemoveNonConfiguredTrunkVLANs: Failed to get network namespace %s: %w", targetNS, err)
+	}
+	defer func() {
+		if err := ns.Close(); err != nil {
+			slog.Error("failed to close namespace", "namespace", targetNS, "error", err)
+		}
+	}()
+
+	if err := netnamespace.In(ns, func() error {
+		return errors.Join(removeStaleTrunkVLANs(trunkVethNames.NamespaceSide, vlans)...)
+	}); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("RemoveNonConfiguredTrunkVLANs: %w", err)
+	}
+	return nil
+}
+
+// removeStaleTrunkVLANs removes the VLAN sub-interfaces of the trunk leg,
+// and the VLANs of its port when it is attached to a bridge, that are not
+// among the given ones.
+func removeStaleTrunkVLANs(trunkName string, vlans map[uint16]bool) []error {
+	trunk, err := netlink.LinkByName(trunkName)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("remove non configured trunk vlans: failed to get trunk %s: %w", trunkName, err)}
+	}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return []error{fmt.Errorf("remove non configured trunk vlans: failed to list links: %w", err)}
+	}
+	var failedDeletes []error
+	for _, l := range links {
+		vlanLink, ok := l.(*netlink.Vlan)
+		if !ok || vlanLink.ParentIndex != trunk.Attrs().Index || vlans[uint16(vlanLink.VlanId)] {
+			continue
+		}
+		if err := netlink.LinkDel(vlanLink); err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("remove non configured trunk vlans: failed to delete %s: %w", vlanLink.Name, err))
+		}
+	}
+
+	if trunk.Attrs().MasterIndex == 0 {
+		return failedDeletes
+	}
+	portVLANs, err := bridgeVLANs(trunk)
+	if err != nil {
+		return append(failedDeletes, fmt.Errorf("remove non configured trunk vlans: %w", err))
+	}
+	for _, v := range portVLANs {
+		if vlans[v.Vid] {
+			continue
+		}
+		if err := netlink.BridgeVlanDel(trunk, v.Vid, v.PortVID(), v.EngressUntag(), false, true); err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("remove non configured trunk vlans: failed to remove vlan %d from %s: %w", v.Vid, trunkName, err))
+		}
+	}
+	return failedDeletes
+}
+
+func trunkVLANName(trunk string, vlan uint16) string {
+	return fmt.Sprintf("%s.%d", trunk, vlan)
+}
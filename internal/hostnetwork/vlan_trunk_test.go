@@ -0,0 +1,201 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openperouter/openperouter/internal/netnamespace"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("L2 VNI configuration with the VLAN trunk", func() {
+	var testNS netns.NsHandle
+	const bridgeName = "testbridge"
+
+	BeforeEach(func() {
+		cleanTest(testNSName)
+		testNS = createTestNS(testNSName)
+		setupLoopback(testNS)
+	})
+	AfterEach(func() {
+		cleanTest(testNSName)
+		_ = RemoveLinkByName(trunkVethNames.HostSide)
+	})
+
+	trunkParams := func(vni int32, trunkVLAN uint16, hostBridge *string) L2VNIParams {
+		return L2VNIParams{
+			VNIParams: VNIParams{
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.9/32",
+				VNI:       vni,
+				VXLanPort: new(int32(4789)),
+			},
+			HostMaster: &HostMaster{
+				Name:      hostBridge,
+				Type:      VLANTrunkLinkType,
+				TrunkVLAN: trunkVLAN,
+			},
+		}
+	}
+
+	It("should expose multiple L2VNIs as VLANs of the trunk + cleanup", func() {
+		params := []L2VNIParams{
+			trunkParams(100, 10, nil),
+			trunkParams(101, 11, nil),
+		}
+
+		for _, p := range params {
+			err := SetupL2VNI(context.Background(), p)
+			Expect(err).NotTo(HaveOccurred())
+			// Test idempotency - calling setup twice should work
+			err = SetupL2VNI(context.Background(), p)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Eventually(func(g Gomega) {
+			for _, p := range params {
+				validateL2TrunkHostLeg(g, p)
+				_ = netnamespace.In(testNS, func() error {
+					validateL2TrunkVNI(g, p)
+					return nil
+				})
+			}
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		remaining := params[0]
+		toDelete := params[1]
+
+		By("removing non configured L2VNIs")
+		err := RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{remaining.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredTrunkVLANs(testNSPath(), []L2VNIParams{remaining})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2TrunkHostLeg(g, remaining)
+			checkLinkdeleted(g, trunkVLANName(trunkVethNames.HostSide, toDelete.HostMaster.TrunkVLAN))
+			_ = netnamespace.In(testNS, func() error {
+				validateL2TrunkVNI(g, remaining)
+				validateVNIIsNotConfigured(g, toDelete.VNIParams)
+				checkLinkdeleted(g, trunkVLANName(trunkVethNames.NamespaceSide, toDelete.HostMaster.TrunkVLAN))
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("removing all the L2VNIs")
+		err = RemoveAllVNIs(testNSPath())
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			checkLinkdeleted(g, trunkVethNames.HostSide)
+			checkLinkdeleted(g, trunkVLANName(trunkVethNames.HostSide, remaining.HostMaster.TrunkVLAN))
+			_ = netnamespace.In(testNS, func() error {
+				validateVNIIsNotConfigured(g, remaining.VNIParams)
+				checkLinkdeleted(g, trunkVethNames.NamespaceSide)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should attach the trunk to the host bridge", func() {
+		createLinuxBridge(bridgeName)
+		hostBridge, err := netlink.LinkByName(bridgeName)
+		Expect(err).NotTo(HaveOccurred())
+		err = netlink.BridgeSetVlanFiltering(hostBridge, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		params := []L2VNIParams{
+			trunkParams(100, 10, new(bridgeName)),
+			trunkParams(101, 11, new(bridgeName)),
+		}
+		for _, p := range params {
+			err := SetupL2VNI(context.Background(), p)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		err = RemoveNonConfiguredTrunkVLANs(testNSPath(), params[:1])
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2TrunkHostLeg(g, params[0])
+			trunk, err := netlink.LinkByName(trunkVethNames.HostSide)
+			g.Expect(err).NotTo(HaveOccurred())
+			vlans, err := bridgeVLANs(trunk)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(vlans).To(HaveLen(1), "the trunk should only carry the vlans of the configured vnis")
+			g.Expect(vlans[0].Vid).To(Equal(params[0].HostMaster.TrunkVLAN))
+			g.Expect(vlans[0].EngressUntag()).To(BeFalse())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should move an L2VNI from its own veth to the trunk", func() {
+		params := trunkParams(100, 10, nil)
+		ownVeth := params
+		ownVeth.HostMaster = nil
+
+		err := SetupL2VNI(context.Background(), ownVeth)
+		Expect(err).NotTo(HaveOccurred())
+		err = SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2TrunkHostLeg(g, params)
+			_ = netnamespace.In(testNS, func() error {
+				validateL2TrunkVNI(g, params)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+})
+
+func validateL2TrunkHostLeg(g Gomega, params L2VNIParams) {
+	checkLinkdeleted(g, vethNamesFromVNI(params.VNI).HostSide)
+
+	trunk, err := netlink.LinkByName(trunkVethNames.HostSide)
+	g.Expect(err).NotTo(HaveOccurred(), "trunk host side not found", trunkVethNames.HostSide)
+	g.Expect(trunk.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
+
+	vlanName := trunkVLANName(trunkVethNames.HostSide, params.HostMaster.TrunkVLAN)
+	hostBridge := ptr.Deref(params.HostMaster.Name, "")
+	if hostBridge != "" {
+		checkLinkdeleted(g, vlanName)
+		bridge, err := netlink.LinkByName(hostBridge)
+		g.Expect(err).NotTo(HaveOccurred(), "host bridge not found", hostBridge)
+		g.Expect(trunk.Attrs().MasterIndex).To(Equal(bridge.Attrs().Index), "trunk is not attached to the host bridge")
+		return
+	}
+
+	g.Expect(trunk.Attrs().MasterIndex).To(BeZero(), "trunk is attached to a bridge but should not be")
+	validateTrunkVLANInterface(g, trunk, vlanName, params.HostMaster.TrunkVLAN)
+}
+
+func validateL2TrunkVNI(g Gomega, params L2VNIParams) {
+	validateVNI(g, params.VNIParams)
+	checkLinkdeleted(g, vethNamesFromVNI(params.VNI).NamespaceSide)
+
+	trunk, err := netlink.LinkByName(trunkVethNames.NamespaceSide)
+	g.Expect(err).NotTo(HaveOccurred(), "trunk pe side not found", trunkVethNames.NamespaceSide)
+	g.Expect(trunk.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
+
+	vlanName := trunkVLANName(trunkVethNames.NamespaceSide, params.HostMaster.TrunkVLAN)
+	vlanLink := validateTrunkVLANInterface(g, trunk, vlanName, params.HostMaster.TrunkVLAN)
+	bridge, err := netlink.LinkByName(BridgeName(params.VNI))
+	g.Expect(err).NotTo(HaveOccurred(), "bridge not found", BridgeName(params.VNI))
+	g.Expect(vlanLink.MasterIndex).To(Equal(bridge.Attrs().Index), "trunk vlan interface is not attached to the bridge")
+}
+
+func validateTrunkVLANInterface(g Gomega, trunk netlink.Link, name string, vlan uint16) *netlink.Vlan {
+	link, err := netlink.LinkByName(name)
+	g.Expect(err).NotTo(HaveOccurred(), "trunk vlan interface not found", name)
+	vlanLink, ok := link.(*netlink.Vlan)
+	g.Expect(ok).To(BeTrue(), "%s is not a vlan interface", name)
+	g.Expect(vlanLink.VlanId).To(BeEquivalentTo(vlan))
+	g.Expect(vlanLink.ParentIndex).To(Equal(trunk.Attrs().Index))
+	g.Expect(vlanLink.OperState).To(BeEquivalentTo(netlink.OperUp))
+	return vlanLink
+}
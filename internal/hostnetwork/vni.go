@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/openperouter/openperouter/internal/netnamespace"
@@ -30,12 +31,53 @@ type VNIParams struct {
 	VTEPIP    string `json:"vtepip"`
 	VNI       int32  `json:"vni"`
 	VXLanPort *int32 `json:"vxlanPort,omitempty"`
+	// VLAN, when set, maps the VNI to this VLAN of the VLAN-aware bridge
+	// shared by all such VNIs, instead of giving it its own bridge and
+	// VXLan device.
+	VLAN uint16 `json:"vlan,omitempty"`
+	// MulticastGroup, when set, is the underlay multicast group the BUM
+	// traffic of the VNI is sent to, instead of being replicated to each
+	// remote VTEP.
+	MulticastGroup string `json:"multicastGroup,omitempty"`
+	// Tunnel holds the properties of the outer headers of the packets
+	// encapsulated by the VXLan device of the VNI.
+	Tunnel VXLanTunnelParams `json:"tunnel,omitempty"`
 }
 
+// VXLanTunnelParams are the properties of the outer headers of the packets
+// encapsulated by a VXLan device. The zero value of each of them keeps the
+// kernel default.
+type VXLanTunnelParams struct {
+	TTL int `json:"ttl,omitempty"`
+	TOS int `json:"tos,omitempty"`
+	// DF is the handling of the DF flag of the encapsulated IPv4 packets.
+	DF VXLanDF `json:"df,omitempty"`
+	// SourcePortLow and SourcePortHigh, when set, are the range the UDP
+	// source port of the encapsulated packets is picked from.
+	SourcePortLow  int `json:"sourcePortLow,omitempty"`
+	SourcePortHigh int `json:"sourcePortHigh,omitempty"`
+	// UDPChecksum, when set, computes or skips the UDP checksum of the
+	// encapsulated packets.
+	UDPChecksum *bool `json:"udpChecksum,omitempty"`
+}
+
+// VXLanDF is the handling of the DF flag of the encapsulated packets, with
+// the values of the kernel.
+type VXLanDF uint8
+
+const (
+	VXLanDFUnset VXLanDF = iota
+	VXLanDFSet
+	VXLanDFInherit
+)
+
 type L3VNIParams struct {
 	VNIParams `json:",inline"`
 	Name      string   `json:"name"`
 	LinkIPs   *LinkIPs `json:"link_ips"`
+	// AdditionalLinkIPs are the IPs of the veth pairs of the host sessions
+	// following the first one, whose IPs are in LinkIPs.
+	AdditionalLinkIPs []LinkIPs `json:"additional_link_ips,omitempty"`
 }
 
 type L3PassthroughParams struct {
@@ -44,6 +86,9 @@ type L3PassthroughParams struct {
 }
 
 type LinkIPs struct {
+	// Session is the name of the host session the veth pair belongs to,
+	// naming the veth of the sessions following the first one.
+	Session  string `json:"session,omitempty"`
 	HostIPv4 string `json:"hostipv4"`
 	NSIPv4   string `json:"nsipv4"`
 	HostIPv6 string `json:"hostipv6"`
@@ -55,12 +100,50 @@ type L2VNIParams struct {
 	Name         string      `json:"name"`
 	L2GatewayIPs []string    `json:"l2gatewayips"`
 	HostMaster   *HostMaster `json:"hostMaster"`
+	// NeighSuppression enables ARP / ND suppression on the VXLan port
+	// of the bridge. Defaults to true.
+	NeighSuppression *bool `json:"neighSuppression,omitempty"`
+	// ProxyARP enables proxy ARP on the VXLan port of the bridge.
+	// Defaults to false.
+	ProxyARP *bool `json:"proxyARP,omitempty"`
+	// GatewayMAC is the MAC address of the bridge holding the L2GatewayIPs.
+	// When empty, it is derived from the VNI.
+	GatewayMAC string `json:"gatewayMAC,omitempty"`
+	// DHCPRelaySourceIP is the address of the node, unlike the
+	// L2GatewayIPs, the DHCP requests are relayed from. It is assigned to
+	// the gateway interface together with the L2GatewayIPs.
+	DHCPRelaySourceIP string `json:"dhcpRelaySourceIP,omitempty"`
+}
+
+// defaultVXLanPortFlags are the flags of the VXLan port of the bridge
+// when not overridden.
+var defaultVXLanPortFlags = bridgePortFlags{neighSuppression: true}
+
+// gatewayInterfaceIPs returns the addresses of the gateway interface of the
+// L2VNI.
+func (p L2VNIParams) gatewayInterfaceIPs() []string {
+	if p.DHCPRelaySourceIP == "" {
+		return p.L2GatewayIPs
+	}
+	return append(slices.Clone(p.L2GatewayIPs), p.DHCPRelaySourceIP)
+}
+
+// vxlanPortFlags returns the flags of the VXLan port of the bridge of the L2VNI.
+func (p L2VNIParams) vxlanPortFlags() bridgePortFlags {
+	return bridgePortFlags{
+		neighSuppression: ptr.Deref(p.NeighSuppression, defaultVXLanPortFlags.neighSuppression),
+		proxyARP:         ptr.Deref(p.ProxyARP, defaultVXLanPortFlags.proxyARP),
+	}
 }
 
 type HostMaster struct {
 	Name       *string `json:"name,omitempty"`
 	Type       string  `json:"type,omitempty"`
 	AutoCreate *bool   `json:"autocreate,omitempty"`
+	// TrunkVLAN is the VLAN the VNI is tagged with on the trunk veth
+	// shared by the VNIs of the VLANTrunkLinkType type. Name, when set,
+	// is the host bridge the trunk is attached to.
+	TrunkVLAN uint16 `json:"trunkVLAN,omitempty"`
 }
 
 const (
@@ -68,6 +151,8 @@ const (
 	BridgeLinkType    = "LinuxBridge"
 	VXLanLinkType     = "vxlan"
 	OVSBridgeLinkType = "OVSBridge"
+	VLANLinkType      = "vlan"
+	VLANTrunkLinkType = "VLANTrunk"
 )
 
 type NotRouterInterfaceError struct {
@@ -102,22 +187,17 @@ func SetupL3VNI(ctx context.Context, params L3VNIParams) error {
 	if err := setupVRFInNS(ctx, params.VNIParams); err != nil {
 		return fmt.Errorf("SetupL3VNI: failed to setup VRF: %w", err)
 	}
-	if err := setupVNI(ctx, params.VNIParams, setAddrGenModeNone); err != nil {
+	if err := setupVNI(ctx, params.VNIParams, defaultVXLanPortFlags, setAddrGenModeNone); err != nil {
 		return fmt.Errorf("SetupL3VNI: failed to setup VNI: %w", err)
 	}
 	slog.DebugContext(ctx, "setting up l3 VNI", "params", params)
 	defer slog.DebugContext(ctx, "end setting up l3 VNI", "params", params)
 
-	if params.LinkIPs == nil {
-		slog.DebugContext(ctx, "no host veth configured, skipping setup")
-		return nil
-	}
-
-	if err := setupHostVeth(
+	if err := setupHostSessionVeths(
 		ctx,
 		vethNamesFromVNI(params.VNI),
 		params.TargetNS,
-		params.LinkIPs,
+		sessionsLinkIPs(params.LinkIPs, params.AdditionalLinkIPs),
 		params.VRF,
 		VXLanOverhead); err != nil {
 		return fmt.Errorf("SetupL3VNI: failed to setup host veth pair: %w", err)
@@ -127,15 +207,31 @@ func SetupL3VNI(ctx context.Context, params L3VNIParams) error {
 
 // SetupL2VNI sets up a Layer 2 VNI in the target namespace.
 // It uses setupVNI to create the bridge and VXLan interface,
-// and connects the veth leg to the bridge, exposing the L2
-// domain to the default host namespace.
+// or setupVLANAwareVNI to map the VNI to a VLAN of the shared
+// VLAN-aware bridge when params.VLAN is set, and connects the veth
+// leg to the bridge, exposing the L2 domain to the default host namespace.
+// When the host master is a VLAN trunk, the VNI is exposed as a VLAN of
+// the veth pair shared by all such VNIs instead of getting its own.
 // The VRF must already exist (created by SetupL3VNI); setupBridge
 // looks it up and binds the bridge to it.
 func SetupL2VNI(ctx context.Context, params L2VNIParams) error {
-	if err := setupVNI(ctx, params.VNIParams); err != nil {
-		return fmt.Errorf("SetupL2VNI: failed to setup VNI: %w", err)
+	var setupErr error
+	if params.VLAN != 0 {
+		setupErr = setupVLANAwareVNI(ctx, params.VNIParams)
+	} else {
+		setupErr = setupVNI(ctx, params.VNIParams, params.vxlanPortFlags())
+	}
+	if setupErr != nil {
+		return fmt.Errorf("SetupL2VNI: failed to setup VNI: %w", setupErr)
 	}
 	vethNames := vethNamesFromVNI(params.VNI)
+	if isVLANTrunk(params.HostMaster) {
+		// the VNI is carried by the shared trunk, its own veth is a leftover
+		if err := RemoveLinkByName(vethNames.HostSide); err != nil {
+			return fmt.Errorf("SetupL2VNI: failed to remove veth %s: %w", vethNames.HostSide, err)
+		}
+		vethNames = trunkVethNames
+	}
 	if err := setupNamespacedVeth(ctx, vethNames, params.TargetNS); err != nil {
 		return fmt.Errorf("SetupL2VNI: failed to setup VNI veth: %w", err)
 	}
@@ -178,7 +274,18 @@ func SetupL2VNI(ctx context.Context, params L2VNIParams) error {
 	}
 
 	if err := netnamespace.In(ns, func() error {
-		return setupL2VNIRouterSide(params, vethNames.NamespaceSide, underlayMTU)
+		routerPort := vethNames.NamespaceSide
+		if isVLANTrunk(params.HostMaster) {
+			trunkVLAN, err := setupRouterTrunkVLAN(vethNames.NamespaceSide, params.HostMaster.TrunkVLAN, underlayMTU)
+			if err != nil {
+				return err
+			}
+			routerPort = trunkVLAN.Name
+		}
+		if params.VLAN != 0 {
+			return setupVLANAwareL2VNIRouterSide(params, routerPort, underlayMTU)
+		}
+		return setupL2VNIRouterSide(params, routerPort, underlayMTU)
 	}); err != nil {
 		return err
 	}
@@ -205,14 +312,19 @@ func setupL2VNIRouterSide(params L2VNIParams, vethName string, underlayMTU int)
 		return fmt.Errorf("failed to set bridge %s as master of pe veth %s: %w", name, peVeth.Attrs().Name, err)
 	}
 	if len(params.L2GatewayIPs) > 0 {
-		for _, ip := range params.L2GatewayIPs {
+		// the DHCP relay source IP changes with the sourceCIDR and goes away
+		// with the relay, drop the addresses not wanted anymore.
+		if err := removeOtherAddresses(bridge, params.gatewayInterfaceIPs()...); err != nil {
+			return err
+		}
+		for _, ip := range params.gatewayInterfaceIPs() {
 			if err := AssignIPToInterface(bridge, ip); err != nil {
 				return fmt.Errorf("failed to assign L2 gateway IP %s to bridge %s: %w", ip, name, err)
 			}
 		}
 
 		// setting up the same mac address for all the nodes for distributed gateway
-		if err := ensureBridgeFixedMacAddress(bridge, params.VNI); err != nil {
+		if err := ensureBridgeGatewayMacAddress(bridge, params); err != nil {
 			return fmt.Errorf("failed to set bridge mac address %s: %v", name, err)
 		}
 	}
@@ -238,6 +350,10 @@ func setupHostMaster(ctx context.Context, params L2VNIParams, hostVeth netlink.L
 		if err := linkSetMaster(hostVeth, master); err != nil {
 			return fmt.Errorf("failed to set host master %s as master of host veth %s: %w", master.Attrs().Name, hostVeth.Attrs().Name, err)
 		}
+	case VLANTrunkLinkType:
+		if err := setupHostTrunkVLAN(hostVeth, bridgeConfig); err != nil {
+			return fmt.Errorf("SetupL2VNI: failed to setup vlan %d on trunk %s: %w", bridgeConfig.TrunkVLAN, hostVeth.Attrs().Name, err)
+		}
 	default:
 		return fmt.Errorf("provided hostmaster.Type %q is not supported", bridgeConfig.Type)
 	}
@@ -247,11 +363,11 @@ func setupHostMaster(ctx context.Context, params L2VNIParams, hostVeth netlink.L
 // setupVNI sets up the configuration required by FRR to
 // serve a given VNI in the target namespace. This includes:
 // - a linux Bridge, bound to the VRF when params.VRF is non-empty
-// - a VXLan interface
+// - a VXLan interface, with the given flags on its bridge port
 //
 // The VRF must already exist when params.VRF is set; setupBridge
 // looks it up and returns an error if it is missing.
-func setupVNI(ctx context.Context, params VNIParams, bridgeOptions ...NetlinkOption) error {
+func setupVNI(ctx context.Context, params VNIParams, vxlanPortFlags bridgePortFlags, bridgeOptions ...NetlinkOption) error {
 	slog.DebugContext(ctx, "setting up VNI", "params", params)
 	defer slog.DebugContext(ctx, "end setting up VNI", "params", params)
 	ns, err := netns.GetFromPath(params.TargetNS)
@@ -272,22 +388,39 @@ func setupVNI(ctx context.Context, params VNIParams, bridgeOptions ...NetlinkOpt
 		}
 
 		slog.DebugContext(ctx, "setting up vxlan")
-		return setupVXLan(params, bridge)
+		return setupVXLan(params, bridge, vxlanPortFlags)
 	})
 }
 
 // RemoveAllVNIs removes from the target namespace the bridges / veths
 // for all VNIs.
 func RemoveAllVNIs(targetNS string) error {
-	return RemoveNonConfiguredVNIs(targetNS, []VNIParams{})
+	return errors.Join(
+		RemoveNonConfiguredVNIs(targetNS, []VNIParams{}),
+		RemoveNonConfiguredTrunkVLANs(targetNS, []L2VNIParams{}),
+	)
 }
 
 // RemoveNonConfiguredVNIs removes from the target namespace the
-// leftovers corresponding to VNIs that are not configured anymore.
+// leftovers corresponding to VNIs that are not configured anymore,
+// including the ones of the layout a VNI is not configured with:
+// the bridge and VXLan device of a VNI mapped to a VLAN of the VLAN-aware
+// bridge, or the VLAN mapping of a VNI with its own bridge and VXLan device.
 func RemoveNonConfiguredVNIs(targetNS string, params []VNIParams) error {
 	vnis := map[int32]bool{}
+	perVNILayout := map[int32]bool{}
+	vlanAwareLayout := map[int32]uint16{}
+	multicast := false
 	for _, p := range params {
 		vnis[p.VNI] = true
+		if p.MulticastGroup != "" {
+			multicast = true
+		}
+		if p.VLAN != 0 {
+			vlanAwareLayout[p.VNI] = p.VLAN
+			continue
+		}
+		perVNILayout[p.VNI] = true
 	}
 
 	errs := removeHostSideVNIs(vnis)
@@ -303,7 +436,13 @@ func RemoveNonConfiguredVNIs(targetNS string, params []VNIParams) error {
 	}()
 
 	if err := netnamespace.In(ns, func() error {
-		nsErrors := removeNamespaceSideVNIs(vnis)
+		nsErrors := removeNamespaceSideVNIs(perVNILayout)
+		nsErrors = append(nsErrors, removeVLANAwareVNIs(vlanAwareLayout)...)
+		if !multicast {
+			if err := RemoveLinkByName(MulticastDeviceName); err != nil {
+				nsErrors = append(nsErrors, fmt.Errorf("remove multicast device: %w", err))
+			}
+		}
 		return errors.Join(nsErrors...)
 	}); err != nil {
 		errs = append(errs, err)
@@ -329,7 +468,8 @@ func removeHostSideVNIs(vnis map[int32]bool) []error {
 		failedDeletes = append(failedDeletes, fmt.Errorf("remove OVS bridges: %w", err))
 	}
 
-	return append(failedDeletes, removeHostSideVeths(hostLinks, HostVethPrefix+EvpnInfix, vnis)...)
+	failedDeletes = append(failedDeletes, removeHostSideVeths(hostLinks, HostVethPrefix+EvpnInfix, vnis)...)
+	return append(failedDeletes, removeHostSessionVeths(hostLinks, EvpnInfix, vnis)...)
 }
 
 func removeNamespaceSideVNIs(vnis map[int32]bool) []error {
@@ -44,6 +44,52 @@ func RemoveNonConfiguredVRFs(targetNS string, vrfs map[string]bool) error {
 	})
 }
 
+// setupHostSessionVeths configures a veth pair per host session of a VRF,
+// and removes the ones of the sessions that are not configured anymore.
+func setupHostSessionVeths(ctx context.Context, vethNames VethNames, targetNS string, linkIPs []LinkIPs,
+	vrfName string, tunnelOverhead int) error {
+	sessionsVethNames := make([]VethNames, len(linkIPs))
+	configured := map[string]bool{}
+	for i := range linkIPs {
+		sessionsVethNames[i] = vethNames
+		if i > 0 {
+			sessionsVethNames[i] = hostSessionVethNames(vethNames, linkIPs[i].Session)
+		}
+		configured[sessionsVethNames[i].HostSide] = true
+	}
+
+	hostLinks, err := netlink.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	sessionsSuffix := strings.TrimPrefix(vethNames.HostSide, HostVethPrefix)
+	for _, hl := range hostLinks {
+		suffix, ok := hostSessionVethSuffix(hl.Attrs().Name)
+		if hl.Type() != VethLinkType || !ok || suffix != sessionsSuffix || configured[hl.Attrs().Name] {
+			continue
+		}
+		if err := netlink.LinkDel(hl); err != nil {
+			return fmt.Errorf("failed to remove host session veth %s: %w", hl.Attrs().Name, err)
+		}
+	}
+
+	for i := range linkIPs {
+		if err := setupHostVeth(ctx, sessionsVethNames[i], targetNS, &linkIPs[i],
+			vrfName, tunnelOverhead); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionsLinkIPs returns the link IPs of all the host sessions of a VRF.
+func sessionsLinkIPs(linkIPs *LinkIPs, additionalLinkIPs []LinkIPs) []LinkIPs {
+	if linkIPs == nil {
+		return nil
+	}
+	return append([]LinkIPs{*linkIPs}, additionalLinkIPs...)
+}
+
 // setupHostVeth configures the veth pair that connects the host to the perouter namespace, for
 // L3VNI and L3VPN.
 func setupHostVeth(ctx context.Context, vethNames VethNames, targetNS string, linkIPs *LinkIPs,
@@ -74,6 +120,11 @@ func setupHostVeth(ctx context.Context, vethNames VethNames, targetNS string, li
 	if err != nil {
 		return fmt.Errorf("failed to assign IPs to host veth: %w", err)
 	}
+	// The veth of a host session is reused by the session taking its
+	// index when the sessions change, so the IPs of the old one must go.
+	if err := removeOtherAddresses(hostVethLink, linkIPs.HostIPv4, linkIPs.HostIPv6); err != nil {
+		return fmt.Errorf("failed to remove stale IPs from host veth: %w", err)
+	}
 
 	underlayMTU, err := findUnderlayMTU(ns)
 	if err != nil {
@@ -109,6 +160,9 @@ func setupHostVeth(ctx context.Context, vethNames VethNames, targetNS string, li
 		if err != nil {
 			return fmt.Errorf("failed to assign IPs to PE veth: %w", err)
 		}
+		if err := removeOtherAddresses(peVethLink, linkIPs.NSIPv4, linkIPs.NSIPv6); err != nil {
+			return fmt.Errorf("failed to remove stale IPs from PE veth: %w", err)
+		}
 		return nil
 	})
 }
@@ -154,6 +208,33 @@ func setVethMTUForTunnelOverhead(link netlink.Link, underlayMTU int, overhead in
 	return linkSetMTU(link, targetMTU)
 }
 
+// removeHostSessionVeths removes the veths of the host sessions following
+// the first one, for the interface IDs that are not configured anymore.
+func removeHostSessionVeths(hostLinks []netlink.Link, infix string, interfaceIDs map[int32]bool) []error {
+	var failedDeletes []error
+	for _, hl := range hostLinks {
+		if hl.Type() != VethLinkType {
+			continue
+		}
+		suffix, ok := hostSessionVethSuffix(hl.Attrs().Name)
+		if !ok || !strings.HasPrefix(suffix, infix) {
+			continue
+		}
+		interfaceID, err := interfaceIDFromPrefix(suffix, infix)
+		if err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("remove host leg: %s %w", hl.Attrs().Name, err))
+			continue
+		}
+		if interfaceIDs[interfaceID] {
+			continue
+		}
+		if err := netlink.LinkDel(hl); err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("remove host leg: %s %w", hl.Attrs().Name, err))
+		}
+	}
+	return failedDeletes
+}
+
 func removeHostSideVeths(hostLinks []netlink.Link, prefix string, interfaceIDs map[int32]bool) []error {
 	var failedDeletes []error
 	for _, hl := range hostLinks {
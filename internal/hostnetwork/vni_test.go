@@ -228,6 +228,123 @@ var _ = Describe("L3 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
 
+	It("should create a veth for each host session + cleanup", func() {
+		params := L3VNIParams{
+			VNIParams: VNIParams{
+				VRF:       "testred",
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.9/32",
+				VNI:       100,
+				VXLanPort: new(int32(4789)),
+			},
+			LinkIPs: &LinkIPs{
+				HostIPv4: "192.168.9.1/32",
+				NSIPv4:   "192.168.9.0/32",
+			},
+			AdditionalLinkIPs: []LinkIPs{
+				{
+					Session:  "second",
+					HostIPv4: "192.168.10.1/32",
+					NSIPv4:   "192.168.10.0/32",
+				},
+			},
+		}
+
+		err := SetupL3VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		secondSession := hostSessionVethNames(vethNamesFromVNI(params.VNI), "second")
+		Eventually(func(g Gomega) {
+			validateL3HostLeg(g, params)
+			hostLeg, err := netlink.LinkByName(secondSession.HostSide)
+			g.Expect(err).NotTo(HaveOccurred())
+			hasIP, err := interfaceHasIP(hostLeg, "192.168.10.1/32")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(hasIP).To(BeTrue(), "second session host leg does not have IPv4")
+
+			_ = netnamespace.In(testNS, func() error {
+				validateL3VNI(g, params)
+				checkLinkExists(g, secondSession.NamespaceSide)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		params.AdditionalLinkIPs = nil
+		err = SetupL3VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL3HostLeg(g, params)
+			checkLinkdeleted(g, secondSession.HostSide)
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should keep the veth names of the host sessions when they are reordered", func() {
+		params := L3VNIParams{
+			VNIParams: VNIParams{
+				VRF:       "testred",
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.9/32",
+				VNI:       100,
+				VXLanPort: new(int32(4789)),
+			},
+			LinkIPs: &LinkIPs{
+				Session:  "first",
+				HostIPv4: "192.168.9.1/32",
+				NSIPv4:   "192.168.9.0/32",
+			},
+			AdditionalLinkIPs: []LinkIPs{
+				{
+					Session:  "second",
+					HostIPv4: "192.168.10.1/32",
+					NSIPv4:   "192.168.10.0/32",
+				},
+				{
+					Session:  "third",
+					HostIPv4: "192.168.11.1/32",
+					NSIPv4:   "192.168.11.0/32",
+				},
+			},
+		}
+		Expect(SetupL3VNI(context.Background(), params)).To(Succeed())
+
+		vethNames := vethNamesFromVNI(params.VNI)
+		checkSessions := func(g Gomega) {
+			for _, linkIPs := range params.AdditionalLinkIPs {
+				hostLeg, err := netlink.LinkByName(hostSessionVethNames(vethNames, linkIPs.Session).HostSide)
+				g.Expect(err).NotTo(HaveOccurred())
+				hasIP, err := interfaceHasIP(hostLeg, linkIPs.HostIPv4)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(hasIP).To(BeTrue(), "host leg of session %s does not have %s", linkIPs.Session,
+					linkIPs.HostIPv4)
+			}
+		}
+		Eventually(checkSessions, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		secondLeg, err := netlink.LinkByName(hostSessionVethNames(vethNames, "second").HostSide)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("swapping the sessions following the first one")
+		params.AdditionalLinkIPs[0], params.AdditionalLinkIPs[1] = params.AdditionalLinkIPs[1], params.AdditionalLinkIPs[0]
+		Expect(SetupL3VNI(context.Background(), params)).To(Succeed())
+		Eventually(checkSessions, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("checking the veth of the second session was not recreated")
+		Consistently(func(g Gomega) {
+			link, err := netlink.LinkByName(secondLeg.Attrs().Name)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(link.Attrs().Index).To(Equal(secondLeg.Attrs().Index))
+		}, 5*time.Second, 1*time.Second).Should(Succeed())
+
+		By("removing the second session")
+		params.AdditionalLinkIPs = params.AdditionalLinkIPs[:1]
+		Expect(SetupL3VNI(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			checkSessions(g)
+			checkLinkdeleted(g, secondLeg.Attrs().Name)
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
 	It("should configure VXLAN and VRF when LinkIPs is nil", func() {
 		params := L3VNIParams{
 			VNIParams: VNIParams{
@@ -387,6 +504,59 @@ var _ = Describe("L2 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
 
+	It("should remove the DHCP relay source IP not configured anymore from the bridge", func() {
+		params := L2VNIParams{
+			VNIParams: VNIParams{
+				VRF:       "testred",
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.9/32",
+				VNI:       100,
+				VXLanPort: new(int32(4789)),
+			},
+			L2GatewayIPs:      []string{"192.168.1.0/24"},
+			DHCPRelaySourceIP: "192.169.0.1/32",
+			HostMaster: &HostMaster{
+				Name: new(bridgeName),
+				Type: BridgeLinkType,
+			},
+		}
+
+		createVRFInNamespace(testNS, params.VRF)
+		Expect(SetupL2VNI(context.Background(), params)).To(Succeed())
+
+		bridgeHasIP := func(g Gomega, ip string) bool {
+			var hasIP bool
+			_ = netnamespace.In(testNS, func() error {
+				bridge, err := netlink.LinkByName(BridgeName(params.VNI))
+				g.Expect(err).NotTo(HaveOccurred())
+				hasIP, err = interfaceHasIP(bridge, ip)
+				g.Expect(err).NotTo(HaveOccurred())
+				return nil
+			})
+			return hasIP
+		}
+		Eventually(func(g Gomega) {
+			g.Expect(bridgeHasIP(g, "192.169.0.1/32")).To(BeTrue())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("changing the DHCP relay source IP")
+		params.DHCPRelaySourceIP = "192.169.0.2/32"
+		Expect(SetupL2VNI(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(bridgeHasIP(g, "192.169.0.1/32")).To(BeFalse())
+			g.Expect(bridgeHasIP(g, "192.169.0.2/32")).To(BeTrue())
+			g.Expect(bridgeHasIP(g, "192.168.1.0/24")).To(BeTrue())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("removing the DHCP relay")
+		params.DHCPRelaySourceIP = ""
+		Expect(SetupL2VNI(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(bridgeHasIP(g, "192.169.0.2/32")).To(BeFalse())
+			g.Expect(bridgeHasIP(g, "192.168.1.0/24")).To(BeTrue())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
 	It("should work with multiple L2VNIs + cleanup", func() {
 		params := []L2VNIParams{
 			{
@@ -542,6 +712,37 @@ var _ = Describe("L2 VNI configuration", func() {
 				Type: BridgeLinkType,
 			},
 		}),
+		Entry("neighbor suppression disabled and proxy ARP enabled", L2VNIParams{
+			VNIParams: VNIParams{
+				VRF:       "testred",
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.14/32",
+				VNI:       600,
+				VXLanPort: new(int32(4789)),
+			},
+			L2GatewayIPs: []string{"192.168.1.0/24"},
+			HostMaster: &HostMaster{
+				Name: new(bridgeName),
+				Type: BridgeLinkType,
+			},
+			NeighSuppression: new(false),
+			ProxyARP:         new(true),
+		}),
+		Entry("configured gateway MAC", L2VNIParams{
+			VNIParams: VNIParams{
+				VRF:       "testred",
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.15/32",
+				VNI:       700,
+				VXLanPort: new(int32(4789)),
+			},
+			L2GatewayIPs: []string{"192.168.1.0/24"},
+			HostMaster: &HostMaster{
+				Name: new(bridgeName),
+				Type: BridgeLinkType,
+			},
+			GatewayMAC: "00:00:5e:00:01:01",
+		}),
 	)
 
 	It("should set veth MTU to underlay MTU minus VXLan overhead when an underlay interface is configured", func() {
@@ -578,6 +779,105 @@ var _ = Describe("L2 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
 
+	It("should set the vxlan tunnel properties and correct their drift", func() {
+		params := L2VNIParams{
+			VNIParams: VNIParams{
+				TargetNS:  testNSPath(),
+				VTEPIP:    "192.170.0.9/32",
+				VNI:       100,
+				VXLanPort: new(int32(4789)),
+				Tunnel: VXLanTunnelParams{
+					TTL:            32,
+					TOS:            184,
+					DF:             VXLanDFSet,
+					SourcePortLow:  49152,
+					SourcePortHigh: 65535,
+					UDPChecksum:    new(true),
+				},
+			},
+		}
+
+		err := SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("changing the df of the vxlan out of band")
+		_ = netnamespace.In(testNS, func() error {
+			vxlan, err := netlink.LinkByName(vxLanNameFromVNI(params.VNI))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setVXLanDF(vxlan, VXLanDFUnset)).To(Succeed())
+			return nil
+		})
+
+		err = SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("going back to the default tunnel properties")
+		params.Tunnel = VXLanTunnelParams{}
+		err = SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should send the BUM traffic to the multicast group + cleanup", func() {
+		params := L2VNIParams{
+			VNIParams: VNIParams{
+				TargetNS:       testNSPath(),
+				VTEPIP:         "192.170.0.9/32",
+				VNI:            100,
+				VXLanPort:      new(int32(4789)),
+				MulticastGroup: "239.1.1.100",
+			},
+		}
+
+		err := SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params)
+				multicastDev, err := netlink.LinkByName(MulticastDeviceName)
+				g.Expect(err).NotTo(HaveOccurred(), "multicast device not found")
+				g.Expect(multicastDev.Attrs().Flags & net.FlagUp).NotTo(BeZero())
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("moving the VNI to head-end replication")
+		params.MulticastGroup = ""
+		err = SetupL2VNI(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{params.VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params)
+				checkLinkdeleted(g, MulticastDeviceName)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
 	It("should leave veth MTU at default when no underlay interface is configured", func() {
 		// No fake underlay is set up here, so findUnderlayMTU returns 0
 		// and setVethMTUForTunnelOverhead must leave the veth MTU untouched.
@@ -611,6 +911,34 @@ var _ = Describe("L2 VNI configuration", func() {
 	})
 })
 
+var _ = Describe("validateVXLanUDPChecksum", func() {
+	ipv4VTEP := net.ParseIP("100.65.0.1")
+	ipv6VTEP := net.ParseIP("2001:db8::1")
+
+	DescribeTable("should compare the udp checksum with the effective one",
+		func(vxlan *netlink.Vxlan, vtepIP net.IP, udpChecksum *bool, shouldMatch bool) {
+			expected := &netlink.Vxlan{}
+			setVXLanTunnelAttrs(expected, VNIParams{Tunnel: VXLanTunnelParams{UDPChecksum: udpChecksum}}, vtepIP)
+			err := validateVXLanUDPChecksum(vxlan, expected, vtepIP)
+			if shouldMatch {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("ipv4 default", &netlink.Vxlan{}, ipv4VTEP, nil, true),
+		Entry("ipv4 default, checksum enabled out of band", &netlink.Vxlan{UDPCSum: true}, ipv4VTEP, nil, false),
+		Entry("ipv4 disabled", &netlink.Vxlan{}, ipv4VTEP, ptr.To(false), true),
+		Entry("ipv4 enabled", &netlink.Vxlan{UDPCSum: true}, ipv4VTEP, ptr.To(true), true),
+		Entry("ipv4 enabled, checksum disabled", &netlink.Vxlan{}, ipv4VTEP, ptr.To(true), false),
+		Entry("ipv6 default", &netlink.Vxlan{}, ipv6VTEP, nil, true),
+		Entry("ipv6 default, zero checksum set out of band",
+			&netlink.Vxlan{UDP6ZeroCSumTx: true, UDP6ZeroCSumRx: true}, ipv6VTEP, nil, false),
+		Entry("ipv6 disabled", &netlink.Vxlan{UDP6ZeroCSumTx: true, UDP6ZeroCSumRx: true}, ipv6VTEP, ptr.To(false), true),
+		Entry("ipv6 disabled, checksum enabled", &netlink.Vxlan{}, ipv6VTEP, ptr.To(false), false),
+	)
+})
+
 func validateL3HostLeg(g Gomega, params L3VNIParams) {
 	vethNames := vethNamesFromVNI(params.VNI)
 	hostLegLink, err := netlink.LinkByName(vethNames.HostSide)
@@ -706,6 +1034,13 @@ func validateL2VNI(g Gomega, params L2VNIParams) {
 	validateVNI(g, params.VNIParams)
 	validateVethForVNI(g, params.VNIParams)
 
+	vxlanLink, err := netlink.LinkByName(vxLanNameFromVNI(params.VNI))
+	g.Expect(err).NotTo(HaveOccurred(), "vxlan link not found %q", vxLanNameFromVNI(params.VNI))
+	protinfo, err := netlink.LinkGetProtinfo(vxlanLink)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(protinfo.NeighSuppress).To(Equal(params.vxlanPortFlags().neighSuppression), "unexpected neigh suppression on vxlan port")
+	g.Expect(protinfo.ProxyArp).To(Equal(params.vxlanPortFlags().proxyARP), "unexpected proxy arp on vxlan port")
+
 	bridgeLinkForMode, err := netlink.LinkByName(BridgeName(params.VNI))
 	g.Expect(err).NotTo(HaveOccurred(), "bridge not found for addr_gen_mode check", BridgeName(params.VNI))
 	g.Expect(checkAddrGenModeNone(bridgeLinkForMode)).To(BeFalse(), "L2VNI bridge must NOT have addr_gen_mode=1")
@@ -729,6 +1064,10 @@ func validateL2VNI(g Gomega, params L2VNIParams) {
 			g.Expect(hasIP).To(BeTrue(), "bridge does not have ip", ip)
 		}
 
+		if params.GatewayMAC != "" {
+			g.Expect(bridgeLink.Attrs().HardwareAddr.String()).To(Equal(params.GatewayMAC), "bridge MAC address should be the gateway MAC")
+			return
+		}
 		validateBridgeMacAddress(g, bridgeLink, params.VNI)
 		return
 	} else {
@@ -739,8 +1078,8 @@ func validateL2VNI(g Gomega, params L2VNIParams) {
 }
 
 func validateVNI(g Gomega, params VNIParams) {
-	vtepDev, err := netlink.LinkByName(loopbackName)
-	g.Expect(err).NotTo(HaveOccurred(), "vtep device not found %q", loopbackName)
+	vtepDev, err := netlink.LinkByName(vtepDeviceName(params))
+	g.Expect(err).NotTo(HaveOccurred(), "vtep device not found %q", vtepDeviceName(params))
 
 	vxlanLink, err := netlink.LinkByName(vxLanNameFromVNI(params.VNI))
 	g.Expect(err).NotTo(HaveOccurred(), "vxlan link not found %q", vxLanNameFromVNI(params.VNI))
@@ -868,6 +1207,7 @@ func validateBridgeMacAddress(g Gomega, bridge netlink.Link, vni int32) {
 		101: {0x00, 0xF3, 0x00, 0x00, 0x00, 0x66}, // VNI+1 = 102 as big-endian int32
 		300: {0x00, 0xF3, 0x00, 0x00, 0x01, 0x2D}, // VNI+1 = 301 as big-endian int32
 		400: {0x00, 0xF3, 0x00, 0x00, 0x01, 0x91}, // VNI+1 = 401 as big-endian int32
+		600: {0x00, 0xF3, 0x00, 0x00, 0x02, 0x59}, // VNI+1 = 601 as big-endian int32
 	}
 
 	expectedMac, exists := expectedMacs[vni]
@@ -10,11 +10,12 @@ import (
 	"strings"
 
 	"github.com/vishvananda/netlink"
+	"k8s.io/utils/ptr"
 )
 
 // setupVXLan sets up a vxlan interface corresponding to the provided
-// vniParams.
-func setupVXLan(params VNIParams, bridge *netlink.Bridge) error {
+// vniParams, with the given flags on its bridge port.
+func setupVXLan(params VNIParams, bridge *netlink.Bridge, portFlags bridgePortFlags) error {
 	vxlan, err := createVXLan(params, bridge)
 	if err != nil {
 		return err
@@ -23,8 +24,8 @@ func setupVXLan(params VNIParams, bridge *netlink.Bridge) error {
 	if err := setAddrGenModeNone(vxlan); err != nil {
 		return fmt.Errorf("failed to set addr_gen_mode to 1 for %s: %w", vxlan.Name, err)
 	}
-	if err := setNeighSuppression(vxlan); err != nil {
-		return fmt.Errorf("failed to set neigh suppression for %s: %w", vxlan.Name, err)
+	if err := setBridgePortFlags(vxlan, portFlags); err != nil {
+		return err
 	}
 
 	if err = linkSetUp(vxlan); err != nil {
@@ -36,12 +37,15 @@ func setupVXLan(params VNIParams, bridge *netlink.Bridge) error {
 
 // checkVXLanConfigured checks if the given VXLan has the required properties
 // passed as parameters.
-func checkVXLanConfigured(vxLan *netlink.Vxlan, bridgeIndex, loopbackIndex int, params VNIParams) error {
+func checkVXLanConfigured(vxLan *netlink.Vxlan, bridgeIndex, vtepDevIndex int, params VNIParams) error {
 	if vxLan.MasterIndex != bridgeIndex {
 		return fmt.Errorf("master index is not bridge index: %d, %d", vxLan.MasterIndex, bridgeIndex)
 	}
 
-	if vxLan.VxlanId != int(params.VNI) {
+	if params.VLAN != 0 && !vxLan.FlowBased {
+		return errors.New("external mode is not enabled")
+	}
+	if params.VLAN == 0 && vxLan.VxlanId != int(params.VNI) {
 		return fmt.Errorf("vxlanid is not vni: %d, %d", vxLan.VxlanId, params.VNI)
 	}
 
@@ -59,16 +63,21 @@ func checkVXLanConfigured(vxLan *netlink.Vxlan, bridgeIndex, loopbackIndex int,
 	if err := validateVxlan(vxLan, params); err != nil {
 		return err
 	}
-	if vxLan.VtepDevIndex != loopbackIndex {
-		return fmt.Errorf("vtep dev index is not loopback index: %d %d", vxLan.VtepDevIndex, loopbackIndex)
+	if vxLan.VtepDevIndex != vtepDevIndex {
+		return fmt.Errorf("vtep dev index is not %s index: %d %d", vtepDeviceName(params), vxLan.VtepDevIndex, vtepDevIndex)
 	}
 	return nil
 }
 
 func createVXLan(params VNIParams, bridge *netlink.Bridge) (*netlink.Vxlan, error) {
-	loopback, err := net.InterfaceByName(loopbackName)
+	if params.MulticastGroup != "" {
+		if err := ensureMulticastDevice(); err != nil {
+			return nil, err
+		}
+	}
+	vtepDev, err := net.InterfaceByName(vtepDeviceName(params))
 	if err != nil {
-		return nil, fmt.Errorf("failed looking for vtep loopback interface %s: %w", loopbackName, err)
+		return nil, fmt.Errorf("failed looking for vtep interface %s: %w", vtepDeviceName(params), err)
 	}
 
 	vtepIP, _, err := net.ParseCIDR(params.VTEPIP)
@@ -89,14 +98,26 @@ func createVXLan(params VNIParams, bridge *netlink.Bridge) (*netlink.Vxlan, erro
 		VxlanId:      int(params.VNI),
 		Port:         int(*params.VXLanPort),
 		Learning:     false,
-		VtepDevIndex: loopback.Index,
+		VtepDevIndex: vtepDev.Index,
 		SrcAddr:      vtepIP,
 	}
+	if params.MulticastGroup != "" {
+		toCreate.Group = net.ParseIP(params.MulticastGroup)
+	}
+	setVXLanTunnelAttrs(toCreate, params, vtepIP)
+	if params.VLAN != 0 {
+		// The VXLan device shared by the VNIs mapped to the VLANs of the
+		// VLAN-aware bridge takes the VNI from the VLAN tunnel mapping.
+		vxlanName = vlanAwareVXLanName
+		toCreate.Name = vxlanName
+		toCreate.VxlanId = 0
+		toCreate.FlowBased = true
+	}
 
 	link, err := netlink.LinkByName(vxlanName)
 	if err != nil && errors.As(err, &netlink.LinkNotFoundError{}) {
-		if err := netlink.LinkAdd(toCreate); err != nil {
-			return nil, fmt.Errorf("failed to create vxlan %s: %w", vxlanName, err)
+		if err := addVXLan(toCreate, params); err != nil {
+			return nil, err
 		}
 		return toCreate, nil
 	}
@@ -104,19 +125,100 @@ func createVXLan(params VNIParams, bridge *netlink.Bridge) (*netlink.Vxlan, erro
 		return nil, fmt.Errorf("failed to get vxlan link by name %s: %w", vxlanName, err)
 	}
 	vxlan, ok := link.(*netlink.Vxlan)
-	if ok && checkVXLanConfigured(vxlan, bridge.Index, loopback.Index, params) == nil {
+	if ok && checkVXLanConfigured(vxlan, bridge.Index, vtepDev.Index, params) == nil {
 		return vxlan, nil
 	}
 	if err := netlink.LinkDel(link); err != nil {
 		return nil, fmt.Errorf("failed to delete link %v: %w", link, err)
 	}
 
-	if err = netlink.LinkAdd(toCreate); err != nil {
-		return nil, fmt.Errorf("failed to create vxlan %s: %w", vxlan.Name, err)
+	if err := addVXLan(toCreate, params); err != nil {
+		return nil, err
 	}
 	return toCreate, nil
 }
 
+// addVXLan creates the given VXLan, and sets the handling of the DF flag
+// which can't be passed to the netlink library.
+func addVXLan(toCreate *netlink.Vxlan, params VNIParams) error {
+	if err := netlink.LinkAdd(toCreate); err != nil {
+		return fmt.Errorf("failed to create vxlan %s: %w", toCreate.Name, err)
+	}
+	if params.Tunnel.DF == VXLanDFUnset {
+		return nil
+	}
+	if err := setVXLanDF(toCreate, params.Tunnel.DF); err != nil {
+		return fmt.Errorf("failed to set df for vxlan %s: %w", toCreate.Name, err)
+	}
+	return nil
+}
+
+// setVXLanTunnelAttrs sets the properties of the outer headers of the
+// encapsulated packets to the given VXLan, sending from the given VTEP IP.
+func setVXLanTunnelAttrs(vxLan *netlink.Vxlan, params VNIParams, vtepIP net.IP) {
+	vxLan.TTL = params.Tunnel.TTL
+	if vxLan.TTL == 0 && params.MulticastGroup != "" {
+		vxLan.TTL = multicastVXLanTTL
+	}
+	vxLan.TOS = params.Tunnel.TOS
+	vxLan.PortLow = params.Tunnel.SourcePortLow
+	vxLan.PortHigh = params.Tunnel.SourcePortHigh
+	// When not set, the checksum follows the kernel default: skipped with
+	// an IPv4 vtep, computed with an IPv6 one.
+	udpChecksum := ptr.Deref(params.Tunnel.UDPChecksum, vtepIP.To4() == nil)
+	if vtepIP.To4() != nil {
+		vxLan.UDPCSum = udpChecksum
+		return
+	}
+	vxLan.UDP6ZeroCSumTx = !udpChecksum
+	vxLan.UDP6ZeroCSumRx = !udpChecksum
+}
+
+// validateVXLanTunnel checks that the outer headers of the packets
+// encapsulated by the given VXLan have the properties passed as parameters.
+func validateVXLanTunnel(vxLan *netlink.Vxlan, params VNIParams, vtepIP net.IP) error {
+	expected := &netlink.Vxlan{}
+	setVXLanTunnelAttrs(expected, params, vtepIP)
+	if vxLan.TTL != expected.TTL {
+		return fmt.Errorf("ttl does not match: %d, expected %d", vxLan.TTL, expected.TTL)
+	}
+	if vxLan.TOS != expected.TOS {
+		return fmt.Errorf("tos does not match: %d, expected %d", vxLan.TOS, expected.TOS)
+	}
+	if vxLan.PortLow != expected.PortLow || vxLan.PortHigh != expected.PortHigh {
+		return fmt.Errorf("source port range does not match: %d-%d, expected %d-%d",
+			vxLan.PortLow, vxLan.PortHigh, expected.PortLow, expected.PortHigh)
+	}
+	if err := validateVXLanUDPChecksum(vxLan, expected, vtepIP); err != nil {
+		return err
+	}
+	df, err := vxlanDF(vxLan)
+	if err != nil {
+		return fmt.Errorf("failed to get df of vxlan %s: %w", vxLan.Name, err)
+	}
+	if df != params.Tunnel.DF {
+		return fmt.Errorf("df does not match: %d, expected %d", df, params.Tunnel.DF)
+	}
+	return nil
+}
+
+// validateVXLanUDPChecksum checks that the UDP checksum of the packets
+// encapsulated by the given VXLan is handled as expected, the kernel default
+// being expected when the checksum is not set.
+func validateVXLanUDPChecksum(vxLan, expected *netlink.Vxlan, vtepIP net.IP) error {
+	if vtepIP.To4() != nil {
+		if vxLan.UDPCSum != expected.UDPCSum {
+			return fmt.Errorf("udp checksum does not match: %t, expected %t", vxLan.UDPCSum, expected.UDPCSum)
+		}
+		return nil
+	}
+	if vxLan.UDP6ZeroCSumTx != expected.UDP6ZeroCSumTx || vxLan.UDP6ZeroCSumRx != expected.UDP6ZeroCSumRx {
+		return fmt.Errorf("udp zero checksum does not match: tx %t rx %t, expected %t",
+			vxLan.UDP6ZeroCSumTx, vxLan.UDP6ZeroCSumRx, expected.UDP6ZeroCSumTx)
+	}
+	return nil
+}
+
 const vniPrefix = "vni"
 
 func vxLanNameFromVNI(vni int32) string {
@@ -143,5 +245,49 @@ func validateVxlan(vxLan *netlink.Vxlan, params VNIParams) error {
 	if !vxLan.SrcAddr.Equal(vtepIP) {
 		return fmt.Errorf("src addr does not match vtep ip: %v, expected %v", vxLan.SrcAddr, vtepIP)
 	}
+	group := net.ParseIP(params.MulticastGroup)
+	if !vxLan.Group.Equal(group) {
+		return fmt.Errorf("group does not match multicast group: %v, expected %v", vxLan.Group, group)
+	}
+	return validateVXLanTunnel(vxLan, params, vtepIP)
+}
+
+const (
+	// MulticastDeviceName is the dummy device the VXLan interfaces sending
+	// their BUM traffic to a multicast group are bound to, as the loopback
+	// does not support multicast. FRR runs PIM on it to register the
+	// traffic of the local VTEP.
+	MulticastDeviceName = "ipmr-lo"
+
+	// multicastVXLanTTL is the default TTL of the packets sent to a
+	// multicast group, which would otherwise be 1 and would not cross the
+	// routed underlay.
+	multicastVXLanTTL = 64
+)
+
+// vtepDeviceName returns the name of the device the VXLan interface of the
+// VNI is bound to.
+func vtepDeviceName(params VNIParams) string {
+	if params.MulticastGroup != "" {
+		return MulticastDeviceName
+	}
+	return loopbackName
+}
+
+// ensureMulticastDevice creates the dummy device the VXLan interfaces with a
+// multicast group are bound to, if missing, and sets it up.
+func ensureMulticastDevice() error {
+	link, err := netlink.LinkByName(MulticastDeviceName)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		link = &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: MulticastDeviceName}}
+		if err := netlink.LinkAdd(link); err != nil {
+			return fmt.Errorf("failed to create multicast device %s: %w", MulticastDeviceName, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get multicast device %s: %w", MulticastDeviceName, err)
+	}
+	if err := linkSetUp(link); err != nil {
+		return fmt.Errorf("could not set link up for multicast device %s: %w", MulticastDeviceName, err)
+	}
 	return nil
 }
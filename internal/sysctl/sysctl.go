@@ -178,6 +178,30 @@ func EnableSeg6All() Sysctl {
 	}
 }
 
+// IPv4MultipathHashPolicy returns the sysctl definition for the fields hashed
+// to select the path of IPv4 multipath routes: 0 for layer 3, 1 for layer 4
+// and 2 for the inner layer 3 of encapsulated packets.
+// Reference: https://docs.kernel.org/networking/ip-sysctl.html
+func IPv4MultipathHashPolicy(policy string) Sysctl {
+	return Sysctl{
+		Path:        "net/ipv4/fib_multipath_hash_policy",
+		Description: "IPv4 multipath hash policy",
+		Value:       policy,
+	}
+}
+
+// IPv6MultipathHashPolicy returns the sysctl definition for the fields hashed
+// to select the path of IPv6 multipath routes, with the same values as
+// IPv4MultipathHashPolicy.
+// Reference: https://docs.kernel.org/networking/ip-sysctl.html
+func IPv6MultipathHashPolicy(policy string) Sysctl {
+	return Sysctl{
+		Path:        "net/ipv6/fib_multipath_hash_policy",
+		Description: "IPv6 multipath hash policy",
+		Value:       policy,
+	}
+}
+
 // ensureSysctl reads the sysctl at the given path and writes the desired value if it differs
 // from the current one. If the proc file does not exist and UnsupportedWarning is set, the
 // sysctl is silently skipped with a warning instead of returning an error.
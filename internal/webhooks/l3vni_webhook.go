@@ -110,6 +110,9 @@ func validateL3VNIUpdate(l3vni *v1alpha1.L3VNI, oldL3VNI *v1alpha1.L3VNI) error
 	if !reflect.DeepEqual(localCIDR(oldL3VNI.Spec.HostSession), localCIDR(l3vni.Spec.HostSession)) {
 		return errors.New("LocalCIDR cannot be changed")
 	}
+	if err := validateHostSessionsLocalCIDRs(oldL3VNI.Spec.HostSessions, l3vni.Spec.HostSessions); err != nil {
+		return err
+	}
 
 	return validateL3VNI(l3vni)
 }
@@ -121,6 +124,22 @@ func localCIDR(hostSession *v1alpha1.HostSession) v1alpha1.LocalCIDRConfig {
 	return hostSession.LocalCIDR
 }
 
+// validateHostSessionsLocalCIDRs checks that the host sessions present before
+// and after the update kept their LocalCIDR.
+func validateHostSessionsLocalCIDRs(oldSessions, newSessions []v1alpha1.VRFHostSession) error {
+	oldCIDRs := map[string]v1alpha1.LocalCIDRConfig{}
+	for _, s := range oldSessions {
+		oldCIDRs[s.Name] = s.LocalCIDR
+	}
+	for _, s := range newSessions {
+		oldCIDR, ok := oldCIDRs[s.Name]
+		if ok && !reflect.DeepEqual(oldCIDR, s.LocalCIDR) {
+			return fmt.Errorf("LocalCIDR of host session %q cannot be changed", s.Name)
+		}
+	}
+	return nil
+}
+
 func validateL3VNIDelete(_ *v1alpha1.L3VNI) error {
 	return nil
 }
@@ -317,6 +317,73 @@ func TestValidateL3VNIUpdate(t *testing.T) {
 			},
 			errorString: "LocalCIDR cannot be changed",
 		},
+		{
+			name: "host session added to the list",
+			newL3VNI: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newL3VNI",
+				},
+				Spec: v1alpha1.L3VNISpec{
+					HostSessions: []v1alpha1.VRFHostSession{
+						{
+							Name:        "metallb",
+							HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.2.0/24")}},
+						},
+						{
+							Name:        "calico",
+							HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.3.0/24")}},
+						},
+					},
+				},
+			},
+			oldL3VNI: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newL3VNI",
+				},
+				Spec: v1alpha1.L3VNISpec{
+					HostSessions: []v1alpha1.VRFHostSession{
+						{
+							Name:        "metallb",
+							HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.2.0/24")}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "host session in the list has different LocalCIDRs",
+			newL3VNI: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newL3VNI",
+				},
+				Spec: v1alpha1.L3VNISpec{
+					HostSessions: []v1alpha1.VRFHostSession{
+						{
+							Name:        "metallb",
+							HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.3.0/24")}},
+						},
+					},
+				},
+			},
+			oldL3VNI: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newL3VNI",
+				},
+				Spec: v1alpha1.L3VNISpec{
+					HostSessions: []v1alpha1.VRFHostSession{
+						{
+							Name:        "metallb",
+							HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.2.0/24")}},
+						},
+					},
+				},
+			},
+			errorString: `LocalCIDR of host session "metallb" cannot be changed`,
+		},
 		{
 			name: "testing validateL3VNI is hit - long VRF name",
 			nodes: []*v1.Node{
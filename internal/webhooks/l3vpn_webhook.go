@@ -104,6 +104,9 @@ func validateL3VPNUpdate(l3vpn *v1alpha1.L3VPN, oldL3VPN *v1alpha1.L3VPN) error
 	if !reflect.DeepEqual(localCIDR(oldL3VPN.Spec.HostSession), localCIDR(l3vpn.Spec.HostSession)) {
 		return errors.New("LocalCIDR cannot be changed")
 	}
+	if err := validateHostSessionsLocalCIDRs(oldL3VPN.Spec.HostSessions, l3vpn.Spec.HostSessions); err != nil {
+		return err
+	}
 
 	return validateL3VPN(l3vpn)
 }
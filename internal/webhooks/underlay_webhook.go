@@ -20,6 +20,7 @@ import (
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/cniinvoker"
 	"github.com/openperouter/openperouter/internal/conversion"
+	"github.com/openperouter/openperouter/internal/hostnetwork"
 )
 
 const (
@@ -220,6 +221,16 @@ func interfaceTypesByName(interfaces []v1alpha1.UnderlayInterface) map[string]v1
 			if iface.CNIDevice != nil {
 				res[cniInterfaceName(iface)] = iface.Type
 			}
+		case v1alpha1.UnderlayInterfaceTypeBond:
+			if iface.Bond != nil {
+				res[iface.Bond.InterfaceName] = iface.Type
+			}
+		case v1alpha1.UnderlayInterfaceTypeVLAN:
+			// The name of a VLAN sub-interface carries its parent and VLAN
+			// ID: changing either of them replaces the sub-interface.
+			if iface.VLAN != nil {
+				res[hostnetwork.UnderlayVLANName(iface.VLAN.Parent, int(iface.VLAN.VLANID))] = iface.Type
+			}
 		}
 	}
 	return res
@@ -57,7 +57,7 @@ func TestValidateUnderlay(t *testing.T) {
 			},
 		},
 		{
-			name: "testing conversion.ValidateUnderlaysForNodes is hit - more than one underlay per node",
+			name: "testing conversion.ValidateUnderlaysForNodes is hit - overlapping underlays on the same node",
 			nodes: []*v1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -96,16 +96,17 @@ func TestValidateUnderlay(t *testing.T) {
 				Spec: v1alpha1.UnderlaySpec{
 					ASN: 65001,
 					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
-						CIDRs: []string{"10.0.1.0/24"},
+						CIDRs: []string{"10.0.0.0/24"},
 					},
 					NodeSelector: &metav1.LabelSelector{
 						MatchLabels: map[string]string{
 							"nodeName": "node1",
 						},
 					},
+					Neighbors: []v1alpha1.Neighbor{{}},
 				},
 			},
-			errorString: "can't have more than one underlay per node",
+			errorString: "overlapping with 10.0.0.0/24 of underlay existingUnderlay",
 		},
 		// We do not want to block underlays with invalid configuration, only overlay resources.
 		{
@@ -240,6 +241,12 @@ func TestValidateInterfaceTypeImmutable(t *testing.T) {
 			},
 		}
 	}
+	vlan := func(parent string, vlanID int32) v1alpha1.UnderlayInterface {
+		return v1alpha1.UnderlayInterface{
+			Type: v1alpha1.UnderlayInterfaceTypeVLAN,
+			VLAN: &v1alpha1.VLANDevice{Parent: parent, VLANID: vlanID},
+		}
+	}
 
 	tcs := []struct {
 		name        string
@@ -269,6 +276,17 @@ func TestValidateInterfaceTypeImmutable(t *testing.T) {
 			newUnderlay: underlayWith(netdev("net1")),
 			errorString: "type of interface \"net1\" is immutable",
 		},
+		{
+			name:        "vlan id change passes",
+			oldUnderlay: underlayWith(vlan("eth0", 100)),
+			newUnderlay: underlayWith(vlan("eth0", 200)),
+		},
+		{
+			name:        "network device to vlan with same name is rejected",
+			oldUnderlay: underlayWith(netdev("eth0.100")),
+			newUnderlay: underlayWith(vlan("eth0", 100)),
+			errorString: "type of interface \"eth0.100\" is immutable",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {